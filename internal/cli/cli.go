@@ -32,12 +32,144 @@ func Run() {
 	MainShell.AddExecutor(commands.About())
 	MainShell.AddExecutor(commands.Metrics())
 	MainShell.AddExecutor(commands.Relation())
+	MainShell.AddExecutor(commands.Deprecations())
+	MainShell.AddExecutor(commands.Reachability())
+	MainShell.AddExecutor(commands.Impact())
+	MainShell.AddExecutor(commands.HtmlReport())
 
 	var cacheDir string
 	var configPath string
 	var disableCache bool
+	var coveragePath string
 	var port int64
 
+	var reportNoGraphs bool
+	var reportOpts commands.HtmlReportOptions
+
+	collectFlags := []cli.Flag{
+		&cli.StringFlag{
+			Name:        "cache-dir",
+			Usage:       "custom directory for cache storage.",
+			Value:       utils.DefaultCacheDir(),
+			Destination: &cacheDir,
+		},
+		&cli.StringFlag{
+			Name:        "project-path",
+			Usage:       "path to the project relative to which all imports are allowed.",
+			Destination: &walkers.GlobalCtx.ProjectRoot,
+		},
+		&cli.BoolFlag{
+			Name:        "disable-cache",
+			Usage:       "",
+			Destination: &disableCache,
+		},
+		&cli.StringFlag{
+			Name:        "config-path",
+			Usage:       "path to the config.",
+			Destination: &configPath,
+			Value:       "./phpstats.yml",
+		},
+		&cli.StringFlag{
+			Name:        "coverage",
+			Usage:       "path to the Clover XML coverage report.",
+			Destination: &coveragePath,
+		},
+	}
+
+	// collect reads the config and collects information about the project
+	// from the passed directory, or from the directories in the config.
+	collect := func(analyzeDirs []string, serve bool) error {
+		cfg, errOpen, errDecode := config.OpenConfig(configPath)
+		if errDecode != nil {
+			return fmt.Errorf("config: %v", errDecode)
+		}
+		if errOpen != nil {
+			color.Yellow.Printf("Warning: config file '%s' not open (the default configuration is used)\n", configPath)
+
+			cfg = &config.Config{
+				ProjectName:  "Untitled",
+				Port:         port,
+				CacheDir:     cacheDir,
+				DisableCache: disableCache,
+				ProjectPath:  walkers.GlobalCtx.ProjectRoot,
+				Exclude:      nil,
+				Packages:     nil,
+				Extensions:   nil,
+			}
+		}
+
+		if cfg.CacheDir == "" {
+			cfg.CacheDir = utils.DefaultCacheDir()
+		}
+
+		walkers.GlobalCtx.ProjectName = cfg.ProjectName
+		if cfg.MinCloneTokens > 0 {
+			walkers.GlobalCtx.MinCloneTokens = cfg.MinCloneTokens
+		}
+		if len(cfg.TestBaseClasses) != 0 {
+			walkers.GlobalCtx.TestBaseClasses = cfg.TestBaseClasses
+		}
+		walkers.GlobalCtx.FoldAnonymous = cfg.FoldAnonymous
+		walkers.GlobalCtx.TestDirs = cfg.TestDirs
+		walkers.GlobalCtx.IncludeTestsInMetrics = cfg.IncludeTestsInMetrics
+		if cfg.EntryPoints != nil {
+			entryPoints := &walkers.GlobalCtx.EntryPoints
+			if cfg.EntryPoints.Scripts != nil {
+				entryPoints.Scripts = cfg.EntryPoints.Scripts
+			}
+			if cfg.EntryPoints.Tags != nil {
+				entryPoints.Tags = cfg.EntryPoints.Tags
+			}
+			entryPoints.Controllers = cfg.EntryPoints.Controllers
+			entryPoints.Commands = cfg.EntryPoints.Commands
+		}
+		err := walkers.GlobalCtx.SetEffectCategories(cfg.Effects)
+		if err != nil {
+			return fmt.Errorf("config: effects: %v", err)
+		}
+		cfg.AddPackagesToContext(walkers.GlobalCtx.Packages)
+		if serve {
+			server.RunServer(port)
+		}
+
+		// Normalize flags for NoVerify
+		exe := os.Args[0]
+
+		cfgCli := cfg.ToCliArgs()
+		os.Args = []string{exe}
+		os.Args = append(os.Args, cfgCli...)
+		os.Args = append(os.Args, analyzeDirs...)
+
+		if len(analyzeDirs) > 1 {
+			return fmt.Errorf("too many arguments")
+		}
+
+		if cfg.Exclude != nil {
+			excludeRegexp, err := regexp.Compile(strings.Join(cfg.Exclude, "|"))
+			if err != nil {
+				return fmt.Errorf("converting exclude to regexp: %v", err)
+			}
+			walkers.GlobalCtx.ExcludeRegexp = excludeRegexp
+		}
+
+		err = walkers.Collect()
+		if err != nil {
+			return fmt.Errorf("collect: %v", err)
+		}
+
+		if coveragePath == "" {
+			coveragePath = cfg.Coverage
+		}
+		if coveragePath != "" {
+			err := walkers.GlobalCtx.LoadCoverage(coveragePath)
+			if err != nil {
+				return fmt.Errorf("coverage: %v", err)
+			}
+		}
+
+		return nil
+	}
+
 	app := &cli.App{
 		Name:        "phpstats",
 		Version:     "v0.4.0",
@@ -56,96 +188,58 @@ func Run() {
 			{
 				Name:  "collect",
 				Usage: "Starts collecting information and starts an interactive shell",
-				Flags: []cli.Flag{
+				Flags: append([]cli.Flag{
 					&cli.Int64Flag{
 						Name:        "port",
 						Usage:       "port used by the server.",
 						Value:       3005,
 						Destination: &port,
 					},
-					&cli.StringFlag{
-						Name:        "cache-dir",
-						Usage:       "custom directory for cache storage.",
-						Value:       utils.DefaultCacheDir(),
-						Destination: &cacheDir,
-					},
-					&cli.StringFlag{
-						Name:        "project-path",
-						Usage:       "path to the project relative to which all imports are allowed.",
-						Destination: &walkers.GlobalCtx.ProjectRoot,
-					},
+				}, collectFlags...),
+				Action: func(c *cli.Context) error {
+					err := collect(c.Args().Slice(), true)
+					if err != nil {
+						return err
+					}
+
+					MainShell.Run()
+					return nil
+				},
+			},
+			{
+				Name:      "html-report",
+				Usage:     "Collects information about the project and generates a static HTML report in <dir>",
+				ArgsUsage: "<dir> [project]",
+				Flags: append([]cli.Flag{
 					&cli.BoolFlag{
-						Name:        "disable-cache",
-						Usage:       "",
-						Destination: &disableCache,
+						Name:        "tests",
+						Usage:       "include tests in report.",
+						Destination: &reportOpts.WithTests,
 					},
-					&cli.StringFlag{
-						Name:        "config-path",
-						Usage:       "path to the config.",
-						Destination: &configPath,
-						Value:       "./phpstats.yml",
+					&cli.BoolFlag{
+						Name:        "no-graphs",
+						Usage:       "do not embed graphs in the pages of classes and namespaces.",
+						Destination: &reportNoGraphs,
 					},
-				},
+				}, collectFlags...),
 				Action: func(c *cli.Context) error {
-					cfg, errOpen, errDecode := config.OpenConfig(configPath)
-					if errDecode != nil {
-						return fmt.Errorf("config: %v", errDecode)
+					if c.NArg() == 0 {
+						return fmt.Errorf("html-report: the directory for the report is not specified")
 					}
-					if errOpen != nil {
-						color.Yellow.Printf("Warning: config file '%s' not open (the default configuration is used)\n", configPath)
-
-						cfg = &config.Config{
-							ProjectName:  "Untitled",
-							Port:         port,
-							CacheDir:     cacheDir,
-							DisableCache: disableCache,
-							ProjectPath:  walkers.GlobalCtx.ProjectRoot,
-							Exclude:      nil,
-							Packages:     nil,
-							Extensions:   nil,
-						}
-					}
-
-					if cfg.CacheDir == "" {
-						cfg.CacheDir = utils.DefaultCacheDir()
-					}
-
-					walkers.GlobalCtx.ProjectName = cfg.ProjectName
-					cfg.AddPackagesToContext(walkers.GlobalCtx.Packages)
-					server.RunServer(port)
+					reportDir := c.Args().First()
 
-					// Normalize flags for NoVerify
-					exe := os.Args[0]
-
-					countArgs := c.NArg()
-					var analyzeDirs []string
-					if countArgs > 0 {
-						analyzeDirs = c.Args().Slice()
-					}
-
-					cfgCli := cfg.ToCliArgs()
-					os.Args = []string{exe}
-					os.Args = append(os.Args, cfgCli...)
-					os.Args = append(os.Args, analyzeDirs...)
-
-					if c.NArg() > 1 {
-						return fmt.Errorf("too many arguments")
-					}
-
-					if cfg.Exclude != nil {
-						excludeRegexp, err := regexp.Compile(strings.Join(cfg.Exclude, "|"))
-						if err != nil {
-							return fmt.Errorf("converting exclude to regexp: %v", err)
-						}
-						walkers.GlobalCtx.ExcludeRegexp = excludeRegexp
+					err := collect(c.Args().Tail(), false)
+					if err != nil {
+						return err
 					}
 
-					err := walkers.Collect()
+					reportOpts.WithGraphs = !reportNoGraphs
+					err = commands.WriteHtmlReport(reportDir, reportOpts)
 					if err != nil {
-						return fmt.Errorf("collect: %v", err)
+						return fmt.Errorf("html-report: %v", err)
 					}
 
-					MainShell.Run()
+					color.Green.Printf("The report was saved to directory '%s'\n", reportDir)
 					return nil
 				},
 			},
@@ -18,6 +18,29 @@ type Config struct {
 	UsePackages  bool      `yaml:"use-packages"`
 	Packages     *Packages `yaml:"packages"`
 	Extensions   []string  `yaml:"extensions"`
+
+	MinCloneTokens int64 `yaml:"minCloneTokens"`
+
+	FoldAnonymous bool `yaml:"foldAnonymousFunctions"`
+
+	TestBaseClasses       []string `yaml:"testBaseClasses"`
+	TestDirs              []string `yaml:"testDirs"`
+	IncludeTestsInMetrics bool     `yaml:"includeTestsInMetrics"`
+
+	Coverage string `yaml:"coverage"`
+
+	Effects map[string][]string `yaml:"effects"`
+
+	EntryPoints *EntryPoints `yaml:"entryPoints"`
+}
+
+// EntryPoints describes the entry points of the project, the
+// patterns are regular expressions, the unset fields keep defaults.
+type EntryPoints struct {
+	Scripts     []string `yaml:"scripts"`
+	Controllers []string `yaml:"controllers"`
+	Commands    []string `yaml:"commands"`
+	Tags        []string `yaml:"tags"`
 }
 
 type Packages []*Package
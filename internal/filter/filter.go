@@ -0,0 +1,335 @@
+package filter
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Type is the type of the column value.
+type Type int
+
+const (
+	Number Type = iota
+	String
+	Bool
+)
+
+func (t Type) String() string {
+	switch t {
+	case Number:
+		return "number"
+	case String:
+		return "string"
+	}
+	return "bool"
+}
+
+// Resolver returns the canonical name and the type of the column
+// with the passed name, false if there is no such column.
+type Resolver func(name string) (string, Type, bool)
+
+// Row is a row of a list whose values are checked by the expression.
+//
+// Value returns int64 or float64 for Number columns,
+// string for String columns and bool for Bool columns.
+type Row interface {
+	Value(column string) interface{}
+}
+
+// Expr is a parsed filter expression, for example:
+//
+//	lcom4 > 1 && aff >= 10 && namespace ~ "App\\Billing"
+//	cc > 15 || !(magic <= 5)
+//
+// The comparison operators are ==, !=, <, <=, > and >=, ~ checks if the
+// string contains the substring, !~ is the negation of ~, and =~ checks
+// if the string matches the regular expression. Bool columns can be used
+// without a comparison.
+type Expr struct {
+	node node
+}
+
+// Match checks if the row satisfies the expression, nil expression matches any row.
+func (e *Expr) Match(row Row) bool {
+	if e == nil {
+		return true
+	}
+	return e.node.eval(row)
+}
+
+// Parse parses the expression, the columns are checked using the resolver.
+func Parse(src string, resolve Resolver) (*Expr, error) {
+	tokens, err := tokenize(src)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens, resolve: resolve}
+	n, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if tok := p.peek(); tok.kind != tokEOF {
+		return nil, fmt.Errorf("unexpected '%s' at position %d", tok.text, tok.pos+1)
+	}
+
+	return &Expr{node: n}, nil
+}
+
+type node interface {
+	eval(row Row) bool
+}
+
+type orNode struct{ left, right node }
+
+func (n *orNode) eval(row Row) bool { return n.left.eval(row) || n.right.eval(row) }
+
+type andNode struct{ left, right node }
+
+func (n *andNode) eval(row Row) bool { return n.left.eval(row) && n.right.eval(row) }
+
+type notNode struct{ expr node }
+
+func (n *notNode) eval(row Row) bool { return !n.expr.eval(row) }
+
+type boolColumnNode struct{ column string }
+
+func (n *boolColumnNode) eval(row Row) bool {
+	value, _ := row.Value(n.column).(bool)
+	return value
+}
+
+type compareNode struct {
+	column string
+	op     string
+
+	number float64
+	str    string
+	boolV  bool
+	re     *regexp.Regexp
+	tp     Type
+}
+
+func (n *compareNode) eval(row Row) bool {
+	value := row.Value(n.column)
+
+	switch n.tp {
+	case Number:
+		return compareNumbers(toFloat(value), n.op, n.number)
+	case String:
+		str, _ := value.(string)
+		switch n.op {
+		case "~":
+			return strings.Contains(str, n.str)
+		case "!~":
+			return !strings.Contains(str, n.str)
+		case "=~":
+			return n.re.MatchString(str)
+		}
+		return compareStrings(str, n.op, n.str)
+	}
+
+	b, _ := value.(bool)
+	if n.op == "!=" {
+		return b != n.boolV
+	}
+	return b == n.boolV
+}
+
+func toFloat(value interface{}) float64 {
+	switch v := value.(type) {
+	case int64:
+		return float64(v)
+	case int:
+		return float64(v)
+	case float64:
+		return v
+	}
+	return 0
+}
+
+func compareNumbers(a float64, op string, b float64) bool {
+	switch op {
+	case "==":
+		return a == b
+	case "!=":
+		return a != b
+	case "<":
+		return a < b
+	case "<=":
+		return a <= b
+	case ">":
+		return a > b
+	}
+	return a >= b
+}
+
+func compareStrings(a string, op string, b string) bool {
+	switch op {
+	case "==":
+		return a == b
+	case "!=":
+		return a != b
+	case "<":
+		return a < b
+	case "<=":
+		return a <= b
+	case ">":
+		return a > b
+	}
+	return a >= b
+}
+
+type parser struct {
+	tokens  []token
+	cur     int
+	resolve Resolver
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.cur]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.cur]
+	if tok.kind != tokEOF {
+		p.cur++
+	}
+	return tok
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek().kind == tokOp && p.peek().text == "||" {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &orNode{left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek().kind == tokOp && p.peek().text == "&&" {
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &andNode{left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseUnary() (node, error) {
+	tok := p.next()
+
+	switch {
+	case tok.kind == tokOp && tok.text == "!":
+		expr, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &notNode{expr: expr}, nil
+
+	case tok.kind == tokLParen:
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.kind != tokRParen {
+			return nil, fmt.Errorf("expected ')' at position %d", closing.pos+1)
+		}
+		return expr, nil
+
+	case tok.kind == tokIdent:
+		return p.parseComparison(tok)
+
+	case tok.kind == tokEOF:
+		return nil, fmt.Errorf("unexpected end of expression")
+	}
+
+	return nil, fmt.Errorf("unexpected '%s' at position %d", tok.text, tok.pos+1)
+}
+
+func (p *parser) parseComparison(ident token) (node, error) {
+	column, tp, ok := p.resolve(ident.text)
+	if !ok {
+		return nil, fmt.Errorf("unknown column '%s'", ident.text)
+	}
+
+	op := p.peek()
+	if op.kind != tokOp || !isComparison(op.text) {
+		if tp != Bool {
+			return nil, fmt.Errorf("column '%s' is a %s, expected comparison after it", ident.text, tp)
+		}
+		return &boolColumnNode{column: column}, nil
+	}
+	p.next()
+
+	value := p.next()
+	n := &compareNode{column: column, op: op.text, tp: tp}
+
+	switch tp {
+	case Number:
+		if value.kind != tokNumber {
+			return nil, fmt.Errorf("column '%s' is a number, got '%s'", ident.text, value.text)
+		}
+		if op.text == "~" || op.text == "!~" || op.text == "=~" {
+			return nil, fmt.Errorf("operator %s can't be used with number column '%s'", op.text, ident.text)
+		}
+		number, err := strconv.ParseFloat(value.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number '%s' at position %d", value.text, value.pos+1)
+		}
+		n.number = number
+
+	case String:
+		if value.kind != tokString && value.kind != tokIdent {
+			return nil, fmt.Errorf("column '%s' is a string, got '%s'", ident.text, value.text)
+		}
+		n.str = value.text
+		if op.text == "=~" {
+			re, err := regexp.Compile(value.text)
+			if err != nil {
+				return nil, fmt.Errorf("invalid regular expression for column '%s': %v", ident.text, err)
+			}
+			n.re = re
+		}
+
+	case Bool:
+		if value.kind != tokIdent || (value.text != "true" && value.text != "false") {
+			return nil, fmt.Errorf("column '%s' is a bool, got '%s'", ident.text, value.text)
+		}
+		if op.text != "==" && op.text != "!=" {
+			return nil, fmt.Errorf("operator %s can't be used with bool column '%s'", op.text, ident.text)
+		}
+		n.boolV = value.text == "true"
+	}
+
+	return n, nil
+}
+
+func isComparison(op string) bool {
+	switch op {
+	case "==", "!=", "<", "<=", ">", ">=", "~", "!~", "=~":
+		return true
+	}
+	return false
+}
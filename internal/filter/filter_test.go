@@ -0,0 +1,90 @@
+package filter
+
+import (
+	"testing"
+)
+
+type testRow map[string]interface{}
+
+func (r testRow) Value(column string) interface{} {
+	return r[column]
+}
+
+func testResolver(name string) (string, Type, bool) {
+	switch name {
+	case "cc", "lcom4":
+		return name, Number, true
+	case "aff":
+		return name, Number, true
+	case "namespace":
+		return name, String, true
+	case "isTest":
+		return name, Bool, true
+	}
+	return "", Number, false
+}
+
+func TestMatch(t *testing.T) {
+	row := testRow{
+		"cc":        int64(20),
+		"lcom4":     int64(2),
+		"aff":       12.0,
+		"namespace": `\App\Billing\Invoices`,
+		"isTest":    false,
+	}
+
+	tests := []struct {
+		expr  string
+		match bool
+	}{
+		{`lcom4 > 1 && aff >= 10 && namespace ~ "App\\Billing"`, true},
+		{`cc > 15 || aff > 100`, true},
+		{`cc <= 15 || aff > 100`, false},
+		{`!(cc == 20)`, false},
+		{`namespace =~ "^\\\\App\\\\Billing"`, true},
+		{`namespace !~ 'Billing'`, false},
+		{`!isTest && isTest == false`, true},
+		{`lcom4 == 2 && (cc < 10 || aff != 12)`, false},
+	}
+
+	for _, test := range tests {
+		expr, err := Parse(test.expr, testResolver)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.expr, err)
+			continue
+		}
+
+		if got := expr.Match(row); got != test.match {
+			t.Errorf("%s: expected %t, got %t", test.expr, test.match, got)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	exprs := []string{
+		`unknown > 1`,
+		`cc > "a"`,
+		`cc ~ 1`,
+		`namespace`,
+		`cc > 1 &&`,
+		`(cc > 1`,
+		`isTest > true`,
+		`namespace == "App`,
+	}
+
+	for _, expr := range exprs {
+		if _, err := Parse(expr, testResolver); err == nil {
+			t.Errorf("%s: expected error", expr)
+		}
+	}
+}
+
+func TestParseInvalidNumber(t *testing.T) {
+	_, err := Parse(`cc > 1.2.3`, testResolver)
+	if err == nil {
+		t.Fatal("expected error for invalid number")
+	}
+	if want := "invalid number '1.2.3' at position 6"; err.Error() != want {
+		t.Errorf("got error %q, want %q", err, want)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/i582/phpstats/internal/filter"
 	"github.com/i582/phpstats/internal/representator"
 	"github.com/i582/phpstats/internal/stats/symbols"
 	"github.com/i582/phpstats/internal/utils"
@@ -13,6 +14,11 @@ type ClassesGetOptions struct {
 	OnlyInterfaces bool
 	OnlyClasses    bool
 	OnlyTraits     bool
+	OnlyUntested   bool
+	WithTests      bool
+	Where          *filter.Expr
+	Namespace      string
+	Path           string
 	Count          int64
 	Offset         int64
 	SortColumn     int64
@@ -36,6 +42,14 @@ func GetClassesByOption(c *symbols.Classes, opt ClassesGetOptions) []*symbols.Cl
 			continue
 		}
 
+		if class.IsTest && (!opt.WithTests || opt.OnlyUntested) {
+			continue
+		}
+
+		if opt.OnlyUntested && class.CountTests() != 0 {
+			continue
+		}
+
 		if !all {
 			if !class.IsInterface && opt.OnlyInterfaces {
 				continue
@@ -54,6 +68,18 @@ func GetClassesByOption(c *symbols.Classes, opt ClassesGetOptions) []*symbols.Cl
 			}
 		}
 
+		if opt.Namespace != "" && !representator.InNamespace(class.NamespaceName(), opt.Namespace) {
+			continue
+		}
+
+		if opt.Path != "" && !strings.Contains(class.File.Path, opt.Path) {
+			continue
+		}
+
+		if opt.Where != nil && !opt.Where.Match(representator.ClassToData(class)) {
+			continue
+		}
+
 		classes = append(classes, class)
 	}
 
@@ -100,6 +126,9 @@ func GetClassesByOption(c *symbols.Classes, opt ClassesGetOptions) []*symbols.Cl
 			addition = func() bool {
 				return classes[i].Methods.Len() > classes[j].Methods.Len()
 			}
+		case 10: // CountTests
+			class1 = float64(classes[i].CountTests())
+			class2 = float64(classes[j].CountTests())
 		default:
 			return i < j
 		}
@@ -0,0 +1,37 @@
+package getter
+
+import (
+	"github.com/i582/phpstats/internal/stats/clones"
+)
+
+type ClonesGetOptions struct {
+	OnlyExact bool
+	Count     int64
+	Offset    int64
+}
+
+func GetClonesByOptions(g []*clones.Group, opt ClonesGetOptions) []*clones.Group {
+	groups := make([]*clones.Group, 0, len(g))
+
+	if opt.Offset < 0 {
+		opt.Offset = 0
+	}
+
+	for _, group := range g {
+		if opt.OnlyExact && !group.Exact {
+			continue
+		}
+
+		groups = append(groups, group)
+	}
+
+	if opt.Count+opt.Offset < int64(len(groups)) {
+		groups = groups[:opt.Count+opt.Offset]
+	}
+
+	if opt.Offset < int64(len(groups)) {
+		groups = groups[opt.Offset:]
+	}
+
+	return groups
+}
@@ -0,0 +1,48 @@
+package getter
+
+import (
+	"github.com/i582/phpstats/internal/stats/deprecations"
+)
+
+type DeprecationsGetOptions struct {
+	OnlyUsed    bool
+	OnlyVendor  bool
+	OnlyProject bool
+	// WithUnusedVendor enables the unused deprecated symbols from vendor and
+	// built-in code, there are many of them in the stubs of the standard library.
+	WithUnusedVendor bool
+	Count            int64
+	Offset           int64
+}
+
+func GetDeprecationsByOptions(d []*deprecations.Deprecation, opt DeprecationsGetOptions) []*deprecations.Deprecation {
+	list := make([]*deprecations.Deprecation, 0, len(d))
+
+	if opt.Offset < 0 {
+		opt.Offset = 0
+	}
+
+	for _, deprecation := range d {
+		if len(deprecation.Users) == 0 && (opt.OnlyUsed || deprecation.IsVendor && !opt.WithUnusedVendor) {
+			continue
+		}
+		if opt.OnlyVendor && !deprecation.IsVendor {
+			continue
+		}
+		if opt.OnlyProject && deprecation.IsVendor {
+			continue
+		}
+
+		list = append(list, deprecation)
+	}
+
+	if opt.Count+opt.Offset < int64(len(list)) {
+		list = list[:opt.Count+opt.Offset]
+	}
+
+	if opt.Offset < int64(len(list)) {
+		list = list[opt.Offset:]
+	}
+
+	return list
+}
@@ -0,0 +1,82 @@
+package getter
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/i582/phpstats/internal/stats/symbols"
+)
+
+type ExceptionsGetOptions struct {
+	OnlyUncaught bool
+	Count        int64
+	Offset       int64
+	SortColumn   int64
+	ReverseSort  bool
+}
+
+func GetExceptionsByOptions(c *symbols.Classes, opt ExceptionsGetOptions) []*symbols.Class {
+	exceptions := make([]*symbols.Class, 0)
+
+	if opt.Offset < 0 {
+		opt.Offset = 0
+	}
+
+	for _, class := range c.Classes {
+		if class.ThrownIn.Len() == 0 && class.CaughtIn.Len() == 0 {
+			continue
+		}
+
+		if opt.OnlyUncaught && class.UncaughtIn.Len() == 0 {
+			continue
+		}
+
+		exceptions = append(exceptions, class)
+	}
+
+	sort.Slice(exceptions, func(i, j int) bool {
+		var exception1 int
+		var exception2 int
+		switch opt.SortColumn {
+		case 0, 1: // Name
+			exception1 := strings.ToLower(exceptions[i].Name)
+			exception2 := strings.ToLower(exceptions[j].Name)
+			if opt.ReverseSort {
+				exception1, exception2 = exception2, exception1
+			}
+			return exception1 < exception2
+
+		case 2: // Throw sites
+			exception1 = exceptions[i].ThrownIn.Len()
+			exception2 = exceptions[j].ThrownIn.Len()
+		case 3: // Catch sites
+			exception1 = exceptions[i].CaughtIn.Len()
+			exception2 = exceptions[j].CaughtIn.Len()
+		case 4: // Uncaught at entry points
+			exception1 = exceptions[i].UncaughtIn.Len()
+			exception2 = exceptions[j].UncaughtIn.Len()
+		default:
+			return i < j
+		}
+
+		if opt.ReverseSort {
+			exception1, exception2 = exception2, exception1
+		}
+
+		if exception1 == exception2 {
+			return exceptions[i].Name < exceptions[j].Name
+		}
+
+		return exception1 > exception2
+	})
+
+	if opt.Count+opt.Offset < int64(len(exceptions)) {
+		exceptions = exceptions[:opt.Count+opt.Offset]
+	}
+
+	if opt.Offset < int64(len(exceptions)) {
+		exceptions = exceptions[opt.Offset:]
+	}
+
+	return exceptions
+}
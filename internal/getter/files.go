@@ -4,10 +4,14 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/i582/phpstats/internal/filter"
+	"github.com/i582/phpstats/internal/representator"
 	"github.com/i582/phpstats/internal/stats/symbols"
 )
 
 type FilesGetOptions struct {
+	Where       *filter.Expr
+	Path        string
 	Count       int64
 	Offset      int64
 	SortColumn  int64
@@ -22,6 +26,14 @@ func GetFilesByOptions(f *symbols.Files, opt FilesGetOptions) []*symbols.File {
 	}
 
 	for _, fn := range f.Files {
+		if opt.Path != "" && !strings.Contains(fn.Path, opt.Path) {
+			continue
+		}
+
+		if opt.Where != nil && !opt.Where.Match(representator.FileRow(fn)) {
+			continue
+		}
+
 		files = append(files, fn)
 	}
 
@@ -4,12 +4,27 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/i582/phpstats/internal/filter"
+	"github.com/i582/phpstats/internal/representator"
 	"github.com/i582/phpstats/internal/stats/symbols"
 )
 
 type FunctionsGetOptions struct {
 	OnlyMethods       bool
 	OnlyFuncs         bool
+	OnlyUntested      bool
+	WithTests         bool
+	WithAnonymous     bool
+	MinCrap           float64
+	UseMaxCoverage    bool
+	MaxCoverage       float64
+	Effect            string
+	OnlyPure          bool
+	OnlyUnused        bool
+	Mode              symbols.CallGraphMode
+	Where             *filter.Expr
+	Namespace         string
+	Path              string
 	Count             int64
 	Offset            int64
 	WithEmbeddedFuncs bool
@@ -38,6 +53,38 @@ func GetFunctionsByOptions(f *symbols.Functions, opt FunctionsGetOptions) []*sym
 			continue
 		}
 
+		if fn.IsAnonymousFunction() && !opt.WithAnonymous {
+			continue
+		}
+
+		if fn.IsTest() && (!opt.WithTests || opt.OnlyUntested) {
+			continue
+		}
+
+		if opt.OnlyUntested && fn.CountTests() != 0 {
+			continue
+		}
+
+		if opt.MinCrap != 0 && (!fn.HasCoverage || fn.Crap() < opt.MinCrap) {
+			continue
+		}
+
+		if opt.UseMaxCoverage && (!fn.HasCoverage || fn.Coverage() > opt.MaxCoverage) {
+			continue
+		}
+
+		if opt.Effect != "" && !fn.Effects.Has(opt.Effect) {
+			continue
+		}
+
+		if opt.OnlyPure && !fn.IsPure() {
+			continue
+		}
+
+		if opt.OnlyUnused && !fn.IsUnused(opt.Mode) {
+			continue
+		}
+
 		if !all {
 			if !key.IsMethod() && opt.OnlyMethods {
 				continue
@@ -48,12 +95,24 @@ func GetFunctionsByOptions(f *symbols.Functions, opt FunctionsGetOptions) []*sym
 			}
 		}
 
+		if opt.Namespace != "" && !representator.InNamespace(functionNamespace(fn), opt.Namespace) {
+			continue
+		}
+
+		if opt.Path != "" && !strings.Contains(fn.Pos.Filename, opt.Path) {
+			continue
+		}
+
+		if opt.Where != nil && !opt.Where.Match(representator.FunctionRow(fn)) {
+			continue
+		}
+
 		funcs = append(funcs, fn)
 	}
 
 	sort.Slice(funcs, func(i, j int) bool {
-		var fun1 int64
-		var fun2 int64
+		var fun1 float64
+		var fun2 float64
 		switch opt.SortColumn {
 		case 0, 1: // Name
 			fun1 := strings.ToLower(funcs[i].Name.Name)
@@ -64,26 +123,35 @@ func GetFunctionsByOptions(f *symbols.Functions, opt FunctionsGetOptions) []*sym
 			return fun1 < fun2
 
 		case 2: // UsesCount
-			fun1 = funcs[i].UsesCount
-			fun2 = funcs[j].UsesCount
+			fun1 = float64(funcs[i].UsesCount)
+			fun2 = float64(funcs[j].UsesCount)
 		case 3: // CountDeps
-			fun1 = funcs[i].CountDeps()
-			fun2 = funcs[j].CountDeps()
+			fun1 = float64(funcs[i].CountDeps())
+			fun2 = float64(funcs[j].CountDeps())
 		case 4: // CountDepsBy
-			fun1 = funcs[i].CountDepsBy()
-			fun2 = funcs[j].CountDepsBy()
+			fun1 = float64(funcs[i].CountDepsBy())
+			fun2 = float64(funcs[j].CountDepsBy())
 		case 5: // Called
-			fun1 = int64(funcs[i].Called.Len())
-			fun2 = int64(funcs[j].Called.Len())
+			fun1 = float64(funcs[i].Called.Len())
+			fun2 = float64(funcs[j].Called.Len())
 		case 6: // CalledBy
-			fun1 = int64(funcs[i].CalledBy.Len())
-			fun2 = int64(funcs[j].CalledBy.Len())
+			fun1 = float64(funcs[i].CalledBy.Len())
+			fun2 = float64(funcs[j].CalledBy.Len())
 		case 7: // CyclomaticComplexity
-			fun1 = funcs[i].CyclomaticComplexity
-			fun2 = funcs[j].CyclomaticComplexity
+			fun1 = float64(funcs[i].CyclomaticComplexity)
+			fun2 = float64(funcs[j].CyclomaticComplexity)
 		case 8: // CountMagicNumbers
-			fun1 = funcs[i].CountMagicNumbers
-			fun2 = funcs[j].CountMagicNumbers
+			fun1 = float64(funcs[i].CountMagicNumbers)
+			fun2 = float64(funcs[j].CountMagicNumbers)
+		case 9: // CountTests
+			fun1 = float64(funcs[i].CountTests())
+			fun2 = float64(funcs[j].CountTests())
+		case 10: // Coverage
+			fun1 = funcs[i].Coverage()
+			fun2 = funcs[j].Coverage()
+		case 11: // Crap
+			fun1 = funcs[i].Crap()
+			fun2 = funcs[j].Crap()
 		default:
 			return i < j
 		}
@@ -105,3 +173,10 @@ func GetFunctionsByOptions(f *symbols.Functions, opt FunctionsGetOptions) []*sym
 
 	return funcs
 }
+
+func functionNamespace(fn *symbols.Function) string {
+	if fn.Name.IsMethod() {
+		return representator.NamespaceOfName(fn.Name.ClassName)
+	}
+	return representator.NamespaceOfName(fn.Name.Name)
+}
@@ -0,0 +1,78 @@
+package getter
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/i582/phpstats/internal/stats/symbols"
+)
+
+type GlobalsGetOptions struct {
+	OnlyShared  bool
+	Count       int64
+	Offset      int64
+	SortColumn  int64
+	ReverseSort bool
+}
+
+func GetGlobalsByOptions(g *symbols.Globals, opt GlobalsGetOptions) []*symbols.Global {
+	globals := make([]*symbols.Global, 0, g.Len())
+
+	if opt.Offset < 0 {
+		opt.Offset = 0
+	}
+
+	for _, global := range g.Globals {
+		if opt.OnlyShared && global.Users().Len() < 2 {
+			continue
+		}
+
+		globals = append(globals, global)
+	}
+
+	sort.Slice(globals, func(i, j int) bool {
+		var global1 int
+		var global2 int
+		switch opt.SortColumn {
+		case 0, 1: // Name
+			global1 := strings.ToLower(globals[i].Name)
+			global2 := strings.ToLower(globals[j].Name)
+			if opt.ReverseSort {
+				global1, global2 = global2, global1
+			}
+			return global1 < global2
+
+		case 2: // Kind
+			global1 = int(globals[i].Kind)
+			global2 = int(globals[j].Kind)
+		case 3: // Readers
+			global1 = globals[i].Readers.Len()
+			global2 = globals[j].Readers.Len()
+		case 4: // Writers
+			global1 = globals[i].Writers.Len()
+			global2 = globals[j].Writers.Len()
+		default:
+			return i < j
+		}
+
+		if opt.ReverseSort {
+			global1, global2 = global2, global1
+		}
+
+		if global1 == global2 {
+			return globals[i].Name < globals[j].Name
+		}
+
+		return global1 > global2
+	})
+
+	if opt.Count+opt.Offset < int64(len(globals)) {
+		globals = globals[:opt.Count+opt.Offset]
+	}
+
+	if opt.Offset < int64(len(globals)) {
+		globals = globals[opt.Offset:]
+	}
+
+	return globals
+}
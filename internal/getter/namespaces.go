@@ -4,6 +4,7 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/i582/phpstats/internal/filter"
 	"github.com/i582/phpstats/internal/representator"
 	"github.com/i582/phpstats/internal/stats/metrics"
 	"github.com/i582/phpstats/internal/stats/symbols"
@@ -11,6 +12,8 @@ import (
 
 type NamespacesGetOptions struct {
 	Level       int64
+	Where       *filter.Expr
+	Namespace   string
 	Count       int64
 	Offset      int64
 	SortColumn  int64
@@ -20,6 +23,20 @@ type NamespacesGetOptions struct {
 func GetNamespacesByOptions(n *symbols.Namespaces, opt NamespacesGetOptions) []*symbols.Namespace {
 	namespaces := n.GetNamespacesWithSpecificLevel(opt.Level, 100000, 0)
 
+	filtered := namespaces[:0]
+	for _, ns := range namespaces {
+		if opt.Namespace != "" && !representator.InNamespace(ns.FullName, opt.Namespace) {
+			continue
+		}
+
+		if opt.Where != nil && !opt.Where.Match(representator.NamespaceToData(ns)) {
+			continue
+		}
+
+		filtered = append(filtered, ns)
+	}
+	namespaces = filtered
+
 	if opt.Offset < 0 {
 		opt.Offset = 0
 	}
@@ -0,0 +1,89 @@
+package getter
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/i582/phpstats/internal/stats/overrides"
+	"github.com/i582/phpstats/internal/stats/symbols"
+)
+
+type OverridesGetOptions struct {
+	// Class limits the list to the methods of the class, if it is not nil.
+	Class          *symbols.Class
+	OnlySuspicious bool
+	OnlyRefused    bool
+	Count          int64
+	Offset         int64
+	SortColumn     int64
+	ReverseSort    bool
+}
+
+func GetOverridesByOptions(o []*overrides.Override, opt OverridesGetOptions) []*overrides.Override {
+	list := make([]*overrides.Override, 0, len(o))
+
+	if opt.Offset < 0 {
+		opt.Offset = 0
+	}
+
+	for _, override := range o {
+		if opt.Class != nil && override.Method.Class != opt.Class {
+			continue
+		}
+		if opt.Class == nil && override.Method.Class != nil && override.Method.Class.IsVendor {
+			continue
+		}
+		if opt.OnlySuspicious && !override.IsSuspicious() {
+			continue
+		}
+		if opt.OnlyRefused && !override.RefusedBequest {
+			continue
+		}
+
+		list = append(list, override)
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		var override1 int
+		var override2 int
+		switch opt.SortColumn {
+		case 0, 1, 2: // Method, Parent
+			override1 := strings.ToLower(list[i].Method.Name.String())
+			override2 := strings.ToLower(list[j].Method.Name.String())
+			if opt.SortColumn == 2 {
+				override1 = strings.ToLower(list[i].Parent.Name.String())
+				override2 = strings.ToLower(list[j].Parent.Name.String())
+			}
+			if opt.ReverseSort {
+				override1, override2 = override2, override1
+			}
+			return override1 < override2
+
+		case 3: // Problems
+			override1 = len(list[i].Problems)
+			override2 = len(list[j].Problems)
+		default:
+			return i < j
+		}
+
+		if opt.ReverseSort {
+			override1, override2 = override2, override1
+		}
+
+		if override1 == override2 {
+			return list[i].Method.Name.String() < list[j].Method.Name.String()
+		}
+
+		return override1 > override2
+	})
+
+	if opt.Count+opt.Offset < int64(len(list)) {
+		list = list[:opt.Count+opt.Offset]
+	}
+
+	if opt.Offset < int64(len(list)) {
+		list = list[opt.Offset:]
+	}
+
+	return list
+}
@@ -0,0 +1,143 @@
+package getter
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/i582/phpstats/internal/stats/reachability"
+	"github.com/i582/phpstats/internal/stats/symbols"
+)
+
+type EntryPointsGetOptions struct {
+	Kind        string
+	Count       int64
+	Offset      int64
+	SortColumn  int64
+	ReverseSort bool
+}
+
+func GetEntryPointsByOptions(e []*reachability.EntryPoint, opt EntryPointsGetOptions) []*reachability.EntryPoint {
+	list := make([]*reachability.EntryPoint, 0, len(e))
+
+	if opt.Offset < 0 {
+		opt.Offset = 0
+	}
+
+	for _, entry := range e {
+		if opt.Kind != "" && entry.Kind != opt.Kind {
+			continue
+		}
+
+		list = append(list, entry)
+	}
+
+	sort.SliceStable(list, func(i, j int) bool {
+		var entry1 int64
+		var entry2 int64
+		switch opt.SortColumn {
+		case 0, 1: // Name
+			entry1 := strings.ToLower(list[i].Name)
+			entry2 := strings.ToLower(list[j].Name)
+			if opt.ReverseSort {
+				entry1, entry2 = entry2, entry1
+			}
+			return entry1 < entry2
+
+		case 2: // Functions
+			entry1, _, _ = list[i].Reachable.CountProject()
+			entry2, _, _ = list[j].Reachable.CountProject()
+		case 3: // Classes
+			_, entry1, _ = list[i].Reachable.CountProject()
+			_, entry2, _ = list[j].Reachable.CountProject()
+		case 4: // Files
+			_, _, entry1 = list[i].Reachable.CountProject()
+			_, _, entry2 = list[j].Reachable.CountProject()
+		default:
+			return i < j
+		}
+
+		if opt.ReverseSort {
+			entry1, entry2 = entry2, entry1
+		}
+
+		if entry1 == entry2 {
+			return list[i].Name < list[j].Name
+		}
+
+		return entry1 > entry2
+	})
+
+	if opt.Count+opt.Offset < int64(len(list)) {
+		list = list[:opt.Count+opt.Offset]
+	}
+
+	if opt.Offset < int64(len(list)) {
+		list = list[opt.Offset:]
+	}
+
+	return list
+}
+
+type UnreachableGetOptions struct {
+	Count  int64
+	Offset int64
+}
+
+func GetUnreachableFunctionsByOptions(s *reachability.Set, opt UnreachableGetOptions) []*symbols.Function {
+	funcs := make([]*symbols.Function, 0, len(s.Funcs))
+	for fn := range s.Funcs {
+		funcs = append(funcs, fn)
+	}
+
+	sort.Slice(funcs, func(i, j int) bool {
+		return funcs[i].Name.String() < funcs[j].Name.String()
+	})
+
+	start, end := pageBounds(int64(len(funcs)), opt.Count, opt.Offset)
+	return funcs[start:end]
+}
+
+func GetUnreachableClassesByOptions(s *reachability.Set, opt UnreachableGetOptions) []*symbols.Class {
+	classes := make([]*symbols.Class, 0, len(s.Classes))
+	for class := range s.Classes {
+		classes = append(classes, class)
+	}
+
+	sort.Slice(classes, func(i, j int) bool {
+		return classes[i].Name < classes[j].Name
+	})
+
+	start, end := pageBounds(int64(len(classes)), opt.Count, opt.Offset)
+	return classes[start:end]
+}
+
+func GetUnreachableFilesByOptions(s *reachability.Set, opt UnreachableGetOptions) []*symbols.File {
+	files := make([]*symbols.File, 0, len(s.Files))
+	for file := range s.Files {
+		files = append(files, file)
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].Path < files[j].Path
+	})
+
+	start, end := pageBounds(int64(len(files)), opt.Count, opt.Offset)
+	return files[start:end]
+}
+
+// pageBounds returns the bounds of the page of the list with the passed length.
+func pageBounds(length, count, offset int64) (start, end int64) {
+	if offset < 0 {
+		offset = 0
+	}
+	if offset > length {
+		offset = length
+	}
+
+	end = length
+	if count >= 0 && offset+count < length {
+		end = offset + count
+	}
+
+	return offset, end
+}
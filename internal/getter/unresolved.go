@@ -0,0 +1,82 @@
+package getter
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/i582/phpstats/internal/stats/symbols"
+)
+
+type UnresolvedGetOptions struct {
+	Kind        string
+	Count       int64
+	Offset      int64
+	SortColumn  int64
+	ReverseSort bool
+}
+
+// GetFunctionsWithUnresolvedByOptions returns the functions
+// that contain unresolved calls of the passed kind.
+func GetFunctionsWithUnresolvedByOptions(f *symbols.Functions, opt UnresolvedGetOptions) []*symbols.Function {
+	funcs := make([]*symbols.Function, 0)
+
+	if opt.Offset < 0 {
+		opt.Offset = 0
+	}
+
+	counts := make(map[*symbols.Function]int)
+
+	for _, fn := range f.Funcs {
+		if fn.IsVendorFunction() || fn.IsEmbeddedFunc() {
+			continue
+		}
+
+		count := len(fn.Unresolved.Sorted(opt.Kind))
+		if count == 0 {
+			continue
+		}
+
+		counts[fn] = count
+		funcs = append(funcs, fn)
+	}
+
+	sort.Slice(funcs, func(i, j int) bool {
+		var func1 int
+		var func2 int
+		switch opt.SortColumn {
+		case 0, 1: // Name
+			func1 := strings.ToLower(funcs[i].Name.String())
+			func2 := strings.ToLower(funcs[j].Name.String())
+			if opt.ReverseSort {
+				func1, func2 = func2, func1
+			}
+			return func1 < func2
+
+		case 2: // Unresolved calls
+			func1 = counts[funcs[i]]
+			func2 = counts[funcs[j]]
+		default:
+			return i < j
+		}
+
+		if opt.ReverseSort {
+			func1, func2 = func2, func1
+		}
+
+		if func1 == func2 {
+			return funcs[i].Name.String() < funcs[j].Name.String()
+		}
+
+		return func1 > func2
+	})
+
+	if opt.Count+opt.Offset < int64(len(funcs)) {
+		funcs = funcs[:opt.Count+opt.Offset]
+	}
+
+	if opt.Offset < int64(len(funcs)) {
+		funcs = funcs[opt.Offset:]
+	}
+
+	return funcs
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/i582/phpstats/internal/graph"
 	"github.com/i582/phpstats/internal/grapher/templates"
+	"github.com/i582/phpstats/internal/stats/metrics"
 	"github.com/i582/phpstats/internal/stats/symbols"
 	"github.com/i582/phpstats/internal/stats/walkers"
 	"github.com/i582/phpstats/internal/utils"
@@ -103,6 +104,18 @@ func (g *Grapher) classImplementsExtendsDepsRecursive(classGraph *graph.Graph, c
 		}
 	}
 
+	for _, trait := range c.Uses.Classes {
+		traitNode := templates.TemplateClassNode(trait)
+		traitNode, _ = classGraph.AddNode(traitNode)
+
+		classGraph.AddEdgeByNode(classNode, traitNode, templates.TemplateUseTraitEdgeStyle())
+
+		if _, found := visitedClasses[trait]; !found {
+			visitedClasses[trait] = struct{}{}
+			g.classImplementsExtendsDepsRecursive(classGraph, trait, levelRecursion+1, maxRecursion, visitedClasses)
+		}
+	}
+
 	for _, implementClass := range c.ImplementsBy.Classes {
 		implementClassNode := templates.TemplateClassNode(implementClass)
 		implementClassNode, _ = classGraph.AddNode(implementClassNode)
@@ -126,6 +139,18 @@ func (g *Grapher) classImplementsExtendsDepsRecursive(classGraph *graph.Graph, c
 			g.classImplementsExtendsDepsRecursive(classGraph, extendedClass, levelRecursion+1, maxRecursion, visitedClasses)
 		}
 	}
+
+	for _, usedByClass := range c.UsedBy.Classes {
+		usedByClassNode := templates.TemplateClassNode(usedByClass)
+		usedByClassNode, _ = classGraph.AddNode(usedByClassNode)
+
+		classGraph.AddEdgeByNode(usedByClassNode, classNode, templates.TemplateUseTraitEdgeStyle())
+
+		if _, found := visitedClasses[usedByClass]; !found {
+			visitedClasses[usedByClass] = struct{}{}
+			g.classImplementsExtendsDepsRecursive(classGraph, usedByClass, levelRecursion+1, maxRecursion, visitedClasses)
+		}
+	}
 }
 
 func ColorizeClassGraph(classGraph *graph.Graph) {
@@ -249,7 +274,12 @@ func (g *Grapher) classDepsRecursive(classGraph *graph.Graph, c *symbols.Class,
 		depsClassNode := templates.TemplateClassNode(depsClass)
 		depsClassNode, _ = classGraph.AddNode(depsClassNode)
 
-		classGraph.AddEdgeByNode(classNode, depsClassNode, templates.TemplateClassConnectionEdgeStyle())
+		edgeStyle := templates.TemplateClassConnectionEdgeStyle()
+		if dep, ok := c.DepEdges.Get(depsClass.Name); ok {
+			edgeStyle = templates.TemplateDependencyEdgeStyle(dep)
+		}
+
+		classGraph.AddEdgeByNode(classNode, depsClassNode, edgeStyle)
 
 		g.classDepsRecursive(classGraph, depsClass, levelRecursion+1, maxRecursion, withGroups)
 	}
@@ -354,3 +384,83 @@ func (g *Grapher) lcom4(classGraph *graph.Graph, c *symbols.Class) {
 		}
 	}
 }
+
+func (g *Grapher) Lcom4Split(c *symbols.Class) string {
+	graphName := "GraphFor_" + utils.NameToIdentifier(c.Name)
+	classGraph := &graph.Graph{
+		Name:       graphName,
+		IsSubgraph: false,
+		GraphStyle: graph.Styles{
+			Label:      "Suggested split of " + utils.NormalizeSlashes(c.Name) + " by LCOM4 components",
+			Padding:    2.5,
+			NodeMargin: 1.5,
+		},
+		NodeStyle: graph.NodeStyles{},
+		EdgeStyle: graph.EdgeStyles{},
+	}
+
+	g.lcom4Split(classGraph, c)
+
+	return classGraph.String()
+}
+
+func (g *Grapher) lcom4Split(classGraph *graph.Graph, c *symbols.Class) {
+	components := metrics.LackOfCohesionInMethods4Components(c)
+
+	for index, component := range components {
+		subgraphName := fmt.Sprintf("Component%d", index+1)
+		subgraph := classGraph.AddSubGraph(&graph.Graph{
+			Name: subgraphName,
+			GraphStyle: graph.Styles{
+				Label:       fmt.Sprintf("Suggested class #%d", index+1),
+				BorderColor: templates.DefaultOutlineColor,
+				FontColor:   templates.DefaultOutlineColor,
+			},
+		})
+
+		for _, method := range component.Methods {
+			methodNode, _ := subgraph.AddNode(templates.TemplateFunctionNode(method))
+
+			methodNode.Styles.FillColor = templates.FillColorLevel3
+			methodNode.Styles.EdgeColor = templates.OutlineColorLevel3
+			methodNode.Scale(1.5)
+		}
+
+		for _, field := range component.Fields {
+			subgraph.AddNode(templates.TemplateFieldNode(field))
+		}
+	}
+
+	for _, method := range c.Methods.Funcs {
+		methodNode, found := classGraph.GetNodeInSubgraphs(utils.NameToIdentifier(method.Name.String()))
+		if !found {
+			continue
+		}
+
+		for _, calledFunction := range method.Called.Funcs {
+			if calledFunction.Class != c || calledFunction == method {
+				continue
+			}
+
+			calledFunctionNode, found := classGraph.GetNodeInSubgraphs(utils.NameToIdentifier(calledFunction.Name.String()))
+			if !found {
+				continue
+			}
+
+			classGraph.AddEdgeByNode(methodNode, calledFunctionNode, graph.EdgeStyles{Color: templates.OutlineColorLevel3})
+		}
+
+		for _, field := range method.UsedFields.Fields {
+			if field.Class != c {
+				continue
+			}
+
+			fieldNode, found := classGraph.GetNodeInSubgraphs(utils.NameToIdentifier(field.String()))
+			if !found {
+				continue
+			}
+
+			classGraph.AddEdgeByNode(methodNode, fieldNode, graph.EdgeStyles{Color: templates.OutlineColorLevel1, Style: "dashed"})
+		}
+	}
+}
@@ -1,9 +1,11 @@
 package grapher
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 	"os/exec"
+	"strings"
 )
 
 type OutputFormat int8
@@ -47,3 +49,32 @@ func (d *Dot) Execute() error {
 
 	return nil
 }
+
+// DotAvailable checks whether Graphviz is installed.
+func DotAvailable() bool {
+	_, err := exec.LookPath("dot")
+	return err == nil
+}
+
+// RenderSvg renders the graph in the DOT language to SVG
+// suitable to be embedded in the HTML page.
+func RenderSvg(graph string) (string, error) {
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.Command("dot", Svg.String())
+	cmd.Stdin = strings.NewReader(graph)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err := cmd.Run()
+	if err != nil {
+		return "", fmt.Errorf("dot: %v %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	svg := stdout.String()
+	if index := strings.Index(svg, "<svg"); index != -1 {
+		svg = svg[index:]
+	}
+
+	return svg, nil
+}
@@ -0,0 +1,74 @@
+package grapher
+
+import (
+	"github.com/i582/phpstats/internal/graph"
+	"github.com/i582/phpstats/internal/grapher/templates"
+	"github.com/i582/phpstats/internal/stats/exceptions"
+	"github.com/i582/phpstats/internal/stats/symbols"
+	"github.com/i582/phpstats/internal/utils"
+)
+
+// ExceptionPropagation builds a graph of the functions through which
+// the exception passes from the places where it is thrown to the places
+// where it is caught or to the entry points where it remains uncaught.
+func (g *Grapher) ExceptionPropagation(e *symbols.Class, funcs *symbols.Functions) string {
+	graphName := "GraphFor_" + utils.NameToIdentifier(e.Name)
+	exceptionGraph := &graph.Graph{
+		Name:       graphName,
+		IsSubgraph: false,
+		GraphStyle: graph.Styles{
+			Label:      "Exception " + utils.NormalizeSlashes(e.Name) + " propagation",
+			Padding:    2.0,
+			NodeMargin: 1.5,
+		},
+		NodeStyle: graph.NodeStyles{},
+		EdgeStyle: templates.TemplateFunctionConnectionEdgeStyle(),
+	}
+
+	addFunctionNode := func(f *symbols.Function) *graph.Node {
+		subGraph := g.createSubGraphForFunctionClass(f, exceptionGraph)
+		node, _ := subGraph.AddNode(templates.TemplateFunctionNode(f))
+		return node
+	}
+
+	for _, fn := range funcs.Funcs {
+		if _, ok := fn.ThrowsTransitive.Get(e.Name); !ok {
+			continue
+		}
+
+		calledNode := addFunctionNode(fn)
+
+		for _, caller := range fn.CalledBy.Funcs {
+			callerNode := addFunctionNode(caller)
+
+			if exceptions.CanEscape(caller, fn, e) {
+				exceptionGraph.AddEdgeByNode(calledNode, callerNode, templates.TemplateExceptionEscapeEdgeStyle())
+				continue
+			}
+
+			exceptionGraph.AddEdgeByNode(calledNode, callerNode, templates.TemplateExceptionCaughtEdgeStyle())
+			callerNode.Styles.FillColor = templates.CaughtFillColor
+			callerNode.Styles.EdgeColor = templates.CaughtOutlineColor
+		}
+	}
+
+	for _, site := range e.ThrownIn.Sites {
+		node := addFunctionNode(site.Func)
+		node.Styles.FillColor = templates.FillColorLevel3
+		node.Styles.EdgeColor = templates.OutlineColorLevel3
+		node.Scale(1.5)
+	}
+
+	for _, fn := range e.UncaughtIn.Funcs {
+		node, found := exceptionGraph.GetNodeInSubgraphs(utils.NameToIdentifier(fn.Name.String()))
+		if !found {
+			continue
+		}
+
+		node.Styles.FillColor = templates.FillColorLevel4
+		node.Styles.EdgeColor = templates.OutlineColorLevel4
+		node.Scale(1.7)
+	}
+
+	return exceptionGraph.String()
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/i582/phpstats/internal/graph"
 	"github.com/i582/phpstats/internal/grapher/templates"
 	"github.com/i582/phpstats/internal/relations"
+	"github.com/i582/phpstats/internal/stats/symbols"
 	"github.com/i582/phpstats/internal/utils"
 )
 
@@ -45,27 +46,23 @@ func (g *Grapher) functionReachability(functionGraph *graph.Graph, rel *relation
 	functionGraph.AddNode(childFunctionNode)
 
 	var prevNode *graph.Node
+	var prevFunction *symbols.Function
 	for _, path := range rel.Paths {
-		var pathContainsExcludeFunction bool
-		for _, function := range path {
-			if _, isExcluded := rel.ExcludedFunctions[function]; isExcluded {
-				pathContainsExcludeFunction = true
-				break
-			}
-		}
-		if pathContainsExcludeFunction {
-			continue
-		}
-
 		for _, function := range path {
 			functionNode := templates.TemplateFunctionNode(function)
 			functionNode, _ = functionGraph.AddNode(functionNode)
 
 			if prevNode != nil {
-				functionGraph.AddEdgeByNode(prevNode, functionNode, templates.TemplateFunctionConnectionEdgeStyle())
+				edgeStyle := templates.TemplateFunctionConnectionEdgeStyle()
+				if prevFunction.IsVirtualCall(function) {
+					edgeStyle = templates.TemplateVirtualCallEdgeStyle()
+				}
+
+				functionGraph.AddEdgeByNode(prevNode, functionNode, edgeStyle)
 			}
 
 			prevNode = functionNode
+			prevFunction = function
 		}
 		prevNode = nil
 	}
@@ -7,7 +7,7 @@ import (
 	"github.com/i582/phpstats/internal/utils"
 )
 
-func (g *Grapher) FuncDeps(f *symbols.Function, maxRecursion int64) string {
+func (g *Grapher) FuncDeps(f *symbols.Function, maxRecursion int64, mode symbols.CallGraphMode) string {
 	graphName := "GraphFor_" + utils.NameToIdentifier(f.Name.String())
 	functionGraph := &graph.Graph{
 		Name:       graphName,
@@ -21,7 +21,7 @@ func (g *Grapher) FuncDeps(f *symbols.Function, maxRecursion int64) string {
 		EdgeStyle: templates.TemplateFunctionConnectionEdgeStyle(),
 	}
 
-	g.funcDepsRecursive(functionGraph, f, 0, maxRecursion)
+	g.funcDepsRecursive(functionGraph, f, 0, maxRecursion, mode)
 
 	funcNode, found := functionGraph.GetNodeInSubgraphs(utils.NameToIdentifier(f.Name.String()))
 	if found {
@@ -33,7 +33,10 @@ func (g *Grapher) FuncDeps(f *symbols.Function, maxRecursion int64) string {
 	return functionGraph.String()
 }
 
-func (g *Grapher) funcDepsRecursive(functionGraph *graph.Graph, f *symbols.Function, levelRecursion, maxRecursion int64) {
+func (g *Grapher) funcDepsRecursive(functionGraph *graph.Graph, f *symbols.Function, levelRecursion, maxRecursion int64, mode symbols.CallGraphMode) {
+	callees := f.Callees(mode)
+	callers := f.Callers(mode)
+
 	mainFunctionSubGraph := g.createSubGraphForFunctionClass(f, functionGraph)
 	mainFuncNode, _ := mainFunctionSubGraph.AddNode(templates.TemplateFunctionNode(f))
 
@@ -41,18 +44,18 @@ func (g *Grapher) funcDepsRecursive(functionGraph *graph.Graph, f *symbols.Funct
 		return
 	}
 
-	for _, function := range f.Called.Funcs {
+	for _, function := range callees.Funcs {
 		subGraph := g.createSubGraphForFunctionClass(function, functionGraph)
 		subGraph.AddNode(templates.TemplateFunctionNode(function))
 
-		g.funcDepsRecursive(functionGraph, function, levelRecursion+1, maxRecursion)
+		g.funcDepsRecursive(functionGraph, function, levelRecursion+1, maxRecursion, mode)
 	}
 
-	for _, function := range f.CalledBy.Funcs {
+	for _, function := range callers.Funcs {
 		subGraph := g.createSubGraphForFunctionClass(function, functionGraph)
 		subGraph.AddNode(templates.TemplateFunctionNode(function))
 
-		g.funcDepsRecursive(functionGraph, function, levelRecursion+1, maxRecursion)
+		g.funcDepsRecursive(functionGraph, function, levelRecursion+1, maxRecursion, mode)
 	}
 
 	for _, field := range f.UsedFields.Fields {
@@ -60,22 +63,38 @@ func (g *Grapher) funcDepsRecursive(functionGraph *graph.Graph, f *symbols.Funct
 		subGraph.AddNode(templates.TemplateFieldNode(field))
 	}
 
-	for _, function := range f.Called.Funcs {
+	for _, function := range callees.Funcs {
 		funcNode, found := functionGraph.GetNodeInSubgraphs(utils.NameToIdentifier(function.Name.String()))
 		if !found {
 			continue
 		}
 
-		functionGraph.AddEdgeByNode(mainFuncNode, funcNode, graph.EdgeStyles{Color: templates.OutlineColorLevel2})
+		edgeStyle := graph.EdgeStyles{Color: templates.OutlineColorLevel2}
+		if dep, ok := f.CallEdges.Get(function.Name.String()); ok {
+			edgeStyle.Width = templates.DependencyEdgeWidth(dep)
+			edgeStyle.Label = dep.String()
+		} else if f.IsVirtualCall(function) {
+			edgeStyle = templates.TemplateVirtualCallEdgeStyle()
+		}
+
+		functionGraph.AddEdgeByNode(mainFuncNode, funcNode, edgeStyle)
 	}
 
-	for _, function := range f.CalledBy.Funcs {
+	for _, function := range callers.Funcs {
 		funcNode, found := functionGraph.GetNodeInSubgraphs(utils.NameToIdentifier(function.Name.String()))
 		if !found {
 			continue
 		}
 
-		functionGraph.AddEdgeByNode(funcNode, mainFuncNode, graph.EdgeStyles{})
+		edgeStyle := graph.EdgeStyles{}
+		if dep, ok := function.CallEdges.Get(f.Name.String()); ok {
+			edgeStyle.Width = templates.DependencyEdgeWidth(dep)
+			edgeStyle.Label = dep.String()
+		} else if function.IsVirtualCall(f) {
+			edgeStyle = templates.TemplateVirtualCallEdgeStyle()
+		}
+
+		functionGraph.AddEdgeByNode(funcNode, mainFuncNode, edgeStyle)
 	}
 
 	for _, field := range f.UsedFields.Fields {
@@ -0,0 +1,55 @@
+package grapher
+
+import (
+	"strings"
+
+	"github.com/i582/phpstats/internal/graph"
+	"github.com/i582/phpstats/internal/grapher/templates"
+	"github.com/i582/phpstats/internal/stats/symbols"
+)
+
+// SharedGlobals builds a graph of the functions that share global state,
+// the writers point to the global and the global points to the readers.
+// Only the globals used by more than one function and containing
+// the passed substring in the name are included.
+func (g *Grapher) SharedGlobals(globals *symbols.Globals, name string) string {
+	globalsGraph := &graph.Graph{
+		Name:       "GraphForSharedGlobals",
+		IsSubgraph: false,
+		GraphStyle: graph.Styles{
+			Label:      "Functions sharing global state",
+			Padding:    2.0,
+			NodeMargin: 1.5,
+		},
+		NodeStyle: graph.NodeStyles{},
+		EdgeStyle: templates.TemplateFunctionConnectionEdgeStyle(),
+	}
+
+	addFunctionNode := func(f *symbols.Function) *graph.Node {
+		subGraph := g.createSubGraphForFunctionClass(f, globalsGraph)
+		node, _ := subGraph.AddNode(templates.TemplateFunctionNode(f))
+		return node
+	}
+
+	for _, global := range globals.Sorted() {
+		if !strings.Contains(global.Name, name) {
+			continue
+		}
+
+		if global.Users().Len() < 2 {
+			continue
+		}
+
+		globalNode, _ := globalsGraph.AddNode(templates.TemplateGlobalNode(global))
+
+		for _, fn := range global.Writers.Funcs {
+			globalsGraph.AddEdgeByNode(addFunctionNode(fn), globalNode, templates.TemplateGlobalWriteEdgeStyle())
+		}
+
+		for _, fn := range global.Readers.Funcs {
+			globalsGraph.AddEdgeByNode(globalNode, addFunctionNode(fn), templates.TemplateGlobalReadEdgeStyle())
+		}
+	}
+
+	return globalsGraph.String()
+}
@@ -0,0 +1,69 @@
+package grapher
+
+import (
+	"github.com/i582/phpstats/internal/graph"
+	"github.com/i582/phpstats/internal/grapher/templates"
+	"github.com/i582/phpstats/internal/stats/impact"
+	"github.com/i582/phpstats/internal/utils"
+)
+
+// Impact builds a graph of the symbols affected by the change,
+// each edge goes from the symbol to the symbol that depends on it.
+func (g *Grapher) Impact(name string, res *impact.Result) string {
+	graphName := "GraphFor_" + utils.NameToIdentifier(name)
+	impactGraph := &graph.Graph{
+		Name:       graphName,
+		IsSubgraph: false,
+		GraphStyle: graph.Styles{
+			Label:      "Impact of the change of " + utils.NormalizeSlashes(name),
+			Padding:    2.0,
+			NodeMargin: 1.5,
+		},
+		NodeStyle: graph.NodeStyles{},
+		EdgeStyle: templates.TemplateFunctionConnectionEdgeStyle(),
+	}
+
+	nodes := map[*impact.Node]*graph.Node{}
+	addNode := func(node *impact.Node) *graph.Node {
+		if graphNode, ok := nodes[node]; ok {
+			return graphNode
+		}
+
+		graphNode, _ := impactGraph.AddNode(impactNodeTemplate(node))
+		nodes[node] = graphNode
+		return graphNode
+	}
+
+	for _, root := range res.Roots {
+		node := addNode(root)
+		node.Styles.FillColor = templates.FillColorLevel4
+		node.Styles.EdgeColor = templates.OutlineColorLevel4
+		node.Scale(1.7)
+	}
+
+	for _, affected := range res.Affected {
+		node := addNode(affected)
+		if affected.IsTest() {
+			node.Styles.FillColor = templates.CaughtFillColor
+			node.Styles.EdgeColor = templates.CaughtOutlineColor
+		}
+
+		impactGraph.AddEdgeByNode(addNode(affected.Via), node, templates.TemplateFunctionConnectionEdgeStyle())
+	}
+
+	return impactGraph.String()
+}
+
+func impactNodeTemplate(node *impact.Node) *graph.Node {
+	switch {
+	case node.Func != nil:
+		return templates.TemplateFunctionNode(node.Func)
+	case node.Class != nil:
+		return templates.TemplateClassNode(node.Class)
+	case node.Constant != nil:
+		return templates.TemplateConstantNode(node.Constant)
+	case node.Field != nil:
+		return templates.TemplateFieldNode(node.Field)
+	}
+	return templates.TemplateFileNode(node.File)
+}
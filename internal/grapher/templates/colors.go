@@ -21,6 +21,9 @@ const (
 
 	FillColorLevel4    = "#EDDBD5"
 	OutlineColorLevel4 = "#B22F00"
+
+	CaughtFillColor    = "#E2EDE0"
+	CaughtOutlineColor = "#4F9B3F"
 )
 
 func ColorByScale(scale float64) (string, string) {
@@ -1,7 +1,10 @@
 package templates
 
 import (
+	"math"
+
 	"github.com/i582/phpstats/internal/graph"
+	"github.com/i582/phpstats/internal/stats/symbols"
 )
 
 func TemplateImplementEdgeStyle() graph.EdgeStyles {
@@ -28,6 +31,18 @@ func TemplateExtendEdgeStyle() graph.EdgeStyles {
 	}
 }
 
+func TemplateUseTraitEdgeStyle() graph.EdgeStyles {
+	return graph.EdgeStyles{
+		ArrowTail: "empty",
+		Style:     "dashed",
+		Width:     2,
+		Color:     OutlineColorLevel2,
+		FontColor: OutlineColorLevel2,
+		Label:     "use",
+		ToolTip:   "Uses trait",
+	}
+}
+
 func TemplateNamespaceConnectionEdgeStyle() graph.EdgeStyles {
 	return graph.EdgeStyles{
 		ArrowTail: "empty",
@@ -49,6 +64,17 @@ func TemplateFunctionConnectionEdgeStyle() graph.EdgeStyles {
 	}
 }
 
+// TemplateVirtualCallEdgeStyle is the style of the calls
+// of the overriding methods through the dynamic dispatch.
+func TemplateVirtualCallEdgeStyle() graph.EdgeStyles {
+	return graph.EdgeStyles{
+		ArrowTail: "empty",
+		Style:     "dashed",
+		Color:     DefaultEdgeColor,
+		Label:     "virtual",
+	}
+}
+
 func TemplateRootFileEdgeStyle() graph.EdgeStyles {
 	return graph.EdgeStyles{
 		ArrowTail: "empty",
@@ -70,3 +96,64 @@ func TemplateBlockFileEdgeStyle() graph.EdgeStyles {
 		ToolTip:   "Included in block",
 	}
 }
+
+func TemplateExceptionEscapeEdgeStyle() graph.EdgeStyles {
+	return graph.EdgeStyles{
+		ArrowTail: "empty",
+		Width:     2,
+		Color:     OutlineColorLevel4,
+		ToolTip:   "Exception is thrown out",
+	}
+}
+
+func TemplateExceptionCaughtEdgeStyle() graph.EdgeStyles {
+	return graph.EdgeStyles{
+		ArrowTail: "empty",
+		Style:     "dashed",
+		Width:     2,
+		Color:     CaughtOutlineColor,
+		ToolTip:   "Exception is caught",
+	}
+}
+
+// TemplateDependencyEdgeStyle returns the style of the edge for the dependency,
+// the more occurrences the dependency has, the thicker the edge.
+func TemplateDependencyEdgeStyle(dep *symbols.Dependency) graph.EdgeStyles {
+	return graph.EdgeStyles{
+		ArrowTail: "empty",
+		Color:     DefaultEdgeColor,
+		FontColor: OutlineColorLevel2,
+		Width:     DependencyEdgeWidth(dep),
+		Label:     dep.String(),
+		ToolTip:   dep.String(),
+	}
+}
+
+// DependencyEdgeWidth returns the width of the edge
+// depending on the number of occurrences of the dependency.
+func DependencyEdgeWidth(dep *symbols.Dependency) float64 {
+	width := math.Min(1+math.Log2(float64(dep.Count())), 6)
+	return math.Round(width*10) / 10
+}
+
+func TemplateGlobalWriteEdgeStyle() graph.EdgeStyles {
+	return graph.EdgeStyles{
+		ArrowTail: "empty",
+		Width:     2,
+		Color:     OutlineColorLevel4,
+		FontColor: OutlineColorLevel4,
+		Label:     "write",
+		ToolTip:   "Writes global state",
+	}
+}
+
+func TemplateGlobalReadEdgeStyle() graph.EdgeStyles {
+	return graph.EdgeStyles{
+		ArrowTail: "empty",
+		Style:     "dashed",
+		Color:     DefaultEdgeColor,
+		FontColor: DefaultEdgeColor,
+		Label:     "read",
+		ToolTip:   "Reads global state",
+	}
+}
@@ -0,0 +1,24 @@
+package templates
+
+import (
+	"github.com/i582/phpstats/internal/graph"
+	"github.com/i582/phpstats/internal/stats/symbols"
+	"github.com/i582/phpstats/internal/utils"
+)
+
+func TemplateGlobalNode(g *symbols.Global) *graph.Node {
+	name := "global_" + utils.NameToIdentifier(g.Name)
+	label := g.Kind.String() + "\\n" + utils.NormalizeSlashes(g.Name)
+
+	return &graph.Node{
+		Name: name,
+		Styles: graph.NodeStyles{
+			Label:     label,
+			Shape:     "ellipse",
+			FillColor: FillColorLevel3,
+			EdgeColor: OutlineColorLevel3,
+			Style:     "filled",
+			FontSize:  12,
+		},
+	}
+}
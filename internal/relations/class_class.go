@@ -28,9 +28,16 @@ type Class2ClassRelation struct {
 
 	IsTargetImplements bool
 	IsTargetExtends    bool
+	IsTargetUses       bool
 
 	IsRelatedImplements bool
 	IsRelatedExtends    bool
+	IsRelatedUses       bool
+
+	// TargetDependency and RelatedDependency store the number of
+	// dependencies of one class on another by kind.
+	TargetDependency  *symbols.Dependency
+	RelatedDependency *symbols.Dependency
 }
 
 func NewClass2ClassRelation() *Class2ClassRelation {
@@ -57,12 +64,16 @@ func (r *Class2ClassRelation) String() string {
 
 	res += cfmt.Sprintf("    Class {{%s}}::green extends class {{%s}}::yellow:         %t\n", r.TargetClass.Name, r.RelatedClass.Name, r.IsTargetExtends)
 	res += cfmt.Sprintf("    Class {{%s}}::green implements interface {{%s}}::yellow:  %t\n", r.TargetClass.Name, r.RelatedClass.Name, r.IsTargetImplements)
+	res += cfmt.Sprintf("    Class {{%s}}::green uses trait {{%s}}::yellow:            %t\n", r.TargetClass.Name, r.RelatedClass.Name, r.IsTargetUses)
+	if r.TargetDependency != nil {
+		res += cfmt.Sprintf("    Class {{%s}}::green depends on class {{%s}}::yellow:      %d {{(%s)}}::gray\n", r.TargetClass.Name, r.RelatedClass.Name, r.TargetDependency.Count(), r.TargetDependency)
+	}
 
 	if r.UsedRelatedMethods.Len() != 0 || r.UsedRelatedFields.Len() != 0 || r.UsedRelatedConstants.Len() != 0 {
 		res += cfmt.Sprintf("    Class {{%s}}::green uses\n", r.TargetClass.Name)
 
 		for _, method := range r.UsedRelatedMethods.Funcs {
-			res += cfmt.Sprintf("         method   {{%s}}::yellow\n            in method {{%s}}::green.\n", method.Name, r.WhereRelatedUsedMethods[method].Name)
+			res += cfmt.Sprintf("         method   {{%s}}::yellow\n            in method {{%s}}::green%s.\n", method.Name, r.WhereRelatedUsedMethods[method].Name, stringSitesSuffix(r.WhereRelatedUsedMethods[method], method))
 		}
 		for _, field := range r.UsedRelatedFields.Fields {
 			res += cfmt.Sprintf("         field    {{%s}}::yellow\n            in method {{%s}}::green.\n", field, r.WhereRelatedUsedFields[field].Name)
@@ -76,12 +87,16 @@ func (r *Class2ClassRelation) String() string {
 
 	res += cfmt.Sprintf("    Class {{%s}}::yellow extends class {{%s}}::green:         %t\n", r.RelatedClass.Name, r.TargetClass.Name, r.IsRelatedExtends)
 	res += cfmt.Sprintf("    Class {{%s}}::yellow implements interface {{%s}}::green:  %t\n", r.RelatedClass.Name, r.TargetClass.Name, r.IsRelatedImplements)
+	res += cfmt.Sprintf("    Class {{%s}}::yellow uses trait {{%s}}::green:            %t\n", r.RelatedClass.Name, r.TargetClass.Name, r.IsRelatedUses)
+	if r.RelatedDependency != nil {
+		res += cfmt.Sprintf("    Class {{%s}}::yellow depends on class {{%s}}::green:      %d {{(%s)}}::gray\n", r.RelatedClass.Name, r.TargetClass.Name, r.RelatedDependency.Count(), r.RelatedDependency)
+	}
 
 	if r.UsedTargetMethods.Len() != 0 || r.UsedTargetFields.Len() != 0 || r.UsedTargetConstants.Len() != 0 {
 		res += cfmt.Sprintf("    Class {{%s}}::yellow uses\n", r.RelatedClass.Name)
 
 		for _, method := range r.UsedTargetMethods.Funcs {
-			res += cfmt.Sprintf("         method   {{%s}}::green\n            in method {{%s}}::yellow.\n", method.Name, r.WhereTargetUsedMethods[method].Name)
+			res += cfmt.Sprintf("         method   {{%s}}::green\n            in method {{%s}}::yellow%s.\n", method.Name, r.WhereTargetUsedMethods[method].Name, stringSitesSuffix(r.WhereTargetUsedMethods[method], method))
 		}
 		for _, field := range r.UsedTargetFields.Fields {
 			res += cfmt.Sprintf("         field    {{%s}}::green\n            in method {{%s}}::yellow.\n", field, r.WhereTargetUsedFields[field].Name)
@@ -94,6 +109,38 @@ func (r *Class2ClassRelation) String() string {
 	return res
 }
 
+// classFunctions returns the methods of the class together with
+// the anonymous functions declared in them.
+func classFunctions(class *symbols.Class) []*symbols.Function {
+	var funcs []*symbols.Function
+
+	var add func(fn *symbols.Function)
+	add = func(fn *symbols.Function) {
+		funcs = append(funcs, fn)
+		for _, anonymous := range fn.Anonymous.Funcs {
+			if anonymous.IsAnonymousFunction() {
+				add(anonymous)
+			}
+		}
+	}
+
+	for _, method := range class.Methods.Funcs {
+		add(method)
+	}
+
+	return funcs
+}
+
+// stringSitesSuffix returns the places where the caller calls the callee
+// in the form suitable to be appended to the name of the caller.
+func stringSitesSuffix(caller, callee *symbols.Function) string {
+	sites := stringCallSites(caller, callee)
+	if sites == "" {
+		return ""
+	}
+	return cfmt.Sprintf(" {{at %s}}::gray", sites)
+}
+
 func GetClass2ClassRelation(targetClass, relatedClass *symbols.Class) *Class2ClassRelation {
 	if targetClass == relatedClass {
 		return nil
@@ -102,7 +149,7 @@ func GetClass2ClassRelation(targetClass, relatedClass *symbols.Class) *Class2Cla
 	rel.TargetClass = targetClass
 	rel.RelatedClass = relatedClass
 
-	for _, method := range targetClass.Methods.Funcs {
+	for _, method := range classFunctions(targetClass) {
 		for _, calledFunction := range method.Called.Funcs {
 			if calledFunction.Class != relatedClass {
 				continue
@@ -131,7 +178,7 @@ func GetClass2ClassRelation(targetClass, relatedClass *symbols.Class) *Class2Cla
 		}
 	}
 
-	for _, method := range relatedClass.Methods.Funcs {
+	for _, method := range classFunctions(relatedClass) {
 		for _, calledFunction := range method.Called.Funcs {
 			if calledFunction.Class != targetClass {
 				continue
@@ -184,5 +231,16 @@ func GetClass2ClassRelation(targetClass, relatedClass *symbols.Class) *Class2Cla
 		}
 	}
 
+	rel.TargetDependency, _ = targetClass.DepEdges.Get(relatedClass.Name)
+	rel.RelatedDependency, _ = relatedClass.DepEdges.Get(targetClass.Name)
+
+	if _, ok := targetClass.Uses.Get(relatedClass.Name); ok {
+		rel.IsTargetUses = true
+	}
+
+	if _, ok := relatedClass.Uses.Get(targetClass.Name); ok {
+		rel.IsRelatedUses = true
+	}
+
 	return rel
 }
@@ -58,7 +58,7 @@ func (r *Class2FuncRelation) String() string {
 	if r.FunctionUsedInClass {
 		res += cfmt.Sprintf("    Uses in the following methods:\n")
 		for _, methodWhereUsed := range r.MethodsWhereUsed.Funcs {
-			res += cfmt.Sprintf("        {{%s}}::green\n", methodWhereUsed.Name)
+			res += cfmt.Sprintf("        {{%s}}::green%s\n", methodWhereUsed.Name, stringSitesSuffix(methodWhereUsed, r.RelatedFunction))
 		}
 	}
 
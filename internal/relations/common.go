@@ -0,0 +1,365 @@
+package relations
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/i582/cfmt"
+
+	"github.com/i582/phpstats/internal/representator"
+	"github.com/i582/phpstats/internal/stats/symbols"
+)
+
+// CommonAncestor is a function or class from which all the
+// given symbols are reachable.
+type CommonAncestor struct {
+	Func  *symbols.Function
+	Class *symbols.Class
+
+	// Distances stores the distance to each of the given symbols
+	// in the order in which they were passed.
+	Distances []int
+}
+
+func (a CommonAncestor) Name() string {
+	if a.Func != nil {
+		return a.Func.Name.String()
+	}
+	return a.Class.Name
+}
+
+func (a CommonAncestor) maxDistance() int {
+	var max int
+	for _, distance := range a.Distances {
+		if distance > max {
+			max = distance
+		}
+	}
+	return max
+}
+
+func (a CommonAncestor) sumDistance() int {
+	var sum int
+	for _, distance := range a.Distances {
+		sum += distance
+	}
+	return sum
+}
+
+type CommonRelation struct {
+	Functions []*symbols.Function
+	Classes   []*symbols.Class
+	Mode      symbols.CallGraphMode
+
+	CommonCallers []*symbols.Function
+	CommonCallees []*symbols.Function
+	// LowestCommonCallers are the common callers closest to the functions.
+	LowestCommonCallers []CommonAncestor
+
+	CommonDependents []*symbols.Class
+	CommonDeps       []*symbols.Class
+	// LowestCommonDependents are the common dependents closest to the classes.
+	LowestCommonDependents []CommonAncestor
+}
+
+func (r *CommonRelation) String() string {
+	var res string
+
+	if len(r.Functions) > 1 {
+		names := make([]string, 0, len(r.Functions))
+		for _, fn := range r.Functions {
+			names = append(names, fn.Name.String())
+		}
+
+		res += cfmt.Sprintf("Common relations of functions {{%s}}::green\n\n", strings.Join(names, ", "))
+
+		res += cfmt.Sprintf("    Common callers:   %d\n", len(r.CommonCallers))
+		for _, fn := range r.CommonCallers {
+			res += cfmt.Sprintf("        {{%s}}::yellow\n", fn.Name)
+		}
+
+		res += cfmt.Sprintf("    Common callees:   %d\n", len(r.CommonCallees))
+		for _, fn := range r.CommonCallees {
+			res += cfmt.Sprintf("        {{%s}}::yellow\n", fn.Name)
+		}
+
+		res += stringLowestCommon("Lowest common caller", r.LowestCommonCallers, names)
+	}
+
+	if len(r.Functions) > 1 && len(r.Classes) > 1 {
+		res += cfmt.Sprintln()
+	}
+
+	if len(r.Classes) > 1 {
+		names := make([]string, 0, len(r.Classes))
+		for _, class := range r.Classes {
+			names = append(names, class.Name)
+		}
+
+		res += cfmt.Sprintf("Common relations of classes {{%s}}::green\n\n", strings.Join(names, ", "))
+
+		res += cfmt.Sprintf("    Common dependents:     %d\n", len(r.CommonDependents))
+		for _, class := range r.CommonDependents {
+			res += cfmt.Sprintf("        {{%s}}::yellow\n", class.Name)
+		}
+
+		res += cfmt.Sprintf("    Common dependencies:   %d\n", len(r.CommonDeps))
+		for _, class := range r.CommonDeps {
+			res += cfmt.Sprintf("        {{%s}}::yellow\n", class.Name)
+		}
+
+		res += stringLowestCommon("Lowest common dependent", r.LowestCommonDependents, names)
+	}
+
+	return res
+}
+
+// Table returns the common symbols, the distances are specified
+// only for the lowest common callers and dependents.
+func (r *CommonRelation) Table() *representator.Table {
+	table := representator.NewTable(
+		representator.TableColumn{Header: "Relation"},
+		representator.TableColumn{Header: "Name", Wrap: true},
+		representator.TableColumn{Header: "Distances"},
+	)
+
+	var names []string
+	for _, fn := range r.Functions {
+		names = append(names, fn.Name.String())
+	}
+	for _, class := range r.Classes {
+		names = append(names, class.Name)
+	}
+	table.Title = "Common relations of " + strings.Join(names, ", ")
+
+	for _, fn := range r.CommonCallers {
+		table.AddRow("common caller", fn.Name.String(), "")
+	}
+	for _, fn := range r.CommonCallees {
+		table.AddRow("common callee", fn.Name.String(), "")
+	}
+	for _, ancestor := range r.LowestCommonCallers {
+		table.AddRow("lowest common caller", ancestor.Name(), stringDistances(ancestor.Distances))
+	}
+	for _, class := range r.CommonDependents {
+		table.AddRow("common dependent", class.Name, "")
+	}
+	for _, class := range r.CommonDeps {
+		table.AddRow("common dependency", class.Name, "")
+	}
+	for _, ancestor := range r.LowestCommonDependents {
+		table.AddRow("lowest common dependent", ancestor.Name(), stringDistances(ancestor.Distances))
+	}
+
+	return table
+}
+
+func stringDistances(distances []int) string {
+	parts := make([]string, 0, len(distances))
+	for _, distance := range distances {
+		parts = append(parts, strconv.Itoa(distance))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func stringLowestCommon(title string, ancestors []CommonAncestor, names []string) string {
+	if len(ancestors) == 0 {
+		return cfmt.Sprintf("    %s:   {{not found}}::gray\n", title)
+	}
+
+	var res string
+	res += cfmt.Sprintf("    %s:\n", title)
+	for _, ancestor := range ancestors {
+		res += cfmt.Sprintf("        {{%s}}::yellow\n", ancestor.Name())
+		for i, distance := range ancestor.Distances {
+			res += cfmt.Sprintf("            {{%d}}::blue to %s\n", distance, names[i])
+		}
+	}
+	return res
+}
+
+// GetCommonRelation returns the common callers and callees of the functions,
+// the common dependents and dependencies of the classes, and the lowest common
+// caller and dependent.
+//
+// The lowest common caller is the function from which all the functions are
+// reachable with the minimum greatest distance, the sum of distances breaks ties.
+// The function itself is also considered, so if one function calls all the
+// others, it is the lowest common caller.
+func GetCommonRelation(funcs []*symbols.Function, classes []*symbols.Class, mode symbols.CallGraphMode) *CommonRelation {
+	rel := &CommonRelation{
+		Functions: funcs,
+		Classes:   classes,
+		Mode:      mode,
+	}
+
+	if len(funcs) > 1 {
+		callers := make([]*symbols.Functions, 0, len(funcs))
+		callees := make([]*symbols.Functions, 0, len(funcs))
+		for _, fn := range funcs {
+			callers = append(callers, fn.Callers(mode))
+			callees = append(callees, fn.Callees(mode))
+		}
+
+		rel.CommonCallers = intersectFuncs(callers)
+		rel.CommonCallees = intersectFuncs(callees)
+		rel.LowestCommonCallers = lowestCommonCallers(funcs, mode)
+	}
+
+	if len(classes) > 1 {
+		dependents := make([]*symbols.Classes, 0, len(classes))
+		deps := make([]*symbols.Classes, 0, len(classes))
+		for _, class := range classes {
+			dependents = append(dependents, class.DepsBy)
+			deps = append(deps, class.Deps)
+		}
+
+		rel.CommonDependents = intersectClasses(dependents)
+		rel.CommonDeps = intersectClasses(deps)
+		rel.LowestCommonDependents = lowestCommonDependents(classes)
+	}
+
+	return rel
+}
+
+func intersectFuncs(sets []*symbols.Functions) []*symbols.Function {
+	var res []*symbols.Function
+
+outer:
+	for _, fn := range sets[0].Funcs {
+		for _, set := range sets[1:] {
+			if _, ok := set.Get(fn.Name); !ok {
+				continue outer
+			}
+		}
+		res = append(res, fn)
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Name.String() < res[j].Name.String()
+	})
+
+	return res
+}
+
+func intersectClasses(sets []*symbols.Classes) []*symbols.Class {
+	var res []*symbols.Class
+
+outer:
+	for _, class := range sets[0].Classes {
+		for _, set := range sets[1:] {
+			if _, ok := set.Get(class.Name); !ok {
+				continue outer
+			}
+		}
+		res = append(res, class)
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Name < res[j].Name
+	})
+
+	return res
+}
+
+func lowestCommonCallers(funcs []*symbols.Function, mode symbols.CallGraphMode) []CommonAncestor {
+	distances := make([]map[*symbols.Function]int, 0, len(funcs))
+	for _, fn := range funcs {
+		dist := map[*symbols.Function]int{fn: 0}
+		queue := []*symbols.Function{fn}
+
+		for len(queue) != 0 {
+			cur := queue[0]
+			queue = queue[1:]
+
+			for _, caller := range cur.Callers(mode).Funcs {
+				if _, visited := dist[caller]; visited {
+					continue
+				}
+				dist[caller] = dist[cur] + 1
+				queue = append(queue, caller)
+			}
+		}
+
+		distances = append(distances, dist)
+	}
+
+	var candidates []CommonAncestor
+outer:
+	for fn := range distances[0] {
+		ancestor := CommonAncestor{Func: fn}
+		for _, dist := range distances {
+			distance, ok := dist[fn]
+			if !ok {
+				continue outer
+			}
+			ancestor.Distances = append(ancestor.Distances, distance)
+		}
+		candidates = append(candidates, ancestor)
+	}
+
+	return lowestAncestors(candidates)
+}
+
+func lowestCommonDependents(classes []*symbols.Class) []CommonAncestor {
+	distances := make([]map[*symbols.Class]int, 0, len(classes))
+	for _, class := range classes {
+		dist := map[*symbols.Class]int{class: 0}
+		queue := []*symbols.Class{class}
+
+		for len(queue) != 0 {
+			cur := queue[0]
+			queue = queue[1:]
+
+			for _, dependent := range cur.DepsBy.Classes {
+				if _, visited := dist[dependent]; visited {
+					continue
+				}
+				dist[dependent] = dist[cur] + 1
+				queue = append(queue, dependent)
+			}
+		}
+
+		distances = append(distances, dist)
+	}
+
+	var candidates []CommonAncestor
+outer:
+	for class := range distances[0] {
+		ancestor := CommonAncestor{Class: class}
+		for _, dist := range distances {
+			distance, ok := dist[class]
+			if !ok {
+				continue outer
+			}
+			ancestor.Distances = append(ancestor.Distances, distance)
+		}
+		candidates = append(candidates, ancestor)
+	}
+
+	return lowestAncestors(candidates)
+}
+
+// lowestAncestors returns the candidates with the minimum greatest
+// distance and the minimum sum of distances.
+func lowestAncestors(candidates []CommonAncestor) []CommonAncestor {
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].maxDistance() != candidates[j].maxDistance() {
+			return candidates[i].maxDistance() < candidates[j].maxDistance()
+		}
+		if candidates[i].sumDistance() != candidates[j].sumDistance() {
+			return candidates[i].sumDistance() < candidates[j].sumDistance()
+		}
+		return candidates[i].Name() < candidates[j].Name()
+	})
+
+	for i := range candidates {
+		if candidates[i].maxDistance() != candidates[0].maxDistance() ||
+			candidates[i].sumDistance() != candidates[0].sumDistance() {
+			return candidates[:i]
+		}
+	}
+
+	return candidates
+}
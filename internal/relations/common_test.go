@@ -0,0 +1,39 @@
+package relations
+
+import (
+	"testing"
+
+	"github.com/i582/phpstats/internal/stats/symbols"
+	"github.com/i582/phpstats/internal/stats/symbols/symbolstest"
+)
+
+func TestGetCommonRelation(t *testing.T) {
+	g := symbolstest.NewGraph("foo.php")
+
+	main := g.Func(`\main`)
+	checkout := g.Func(`\checkout`)
+	billing := g.Func(`\billing`)
+	shipping := g.Func(`\shipping`)
+	charge := g.Func(`\charge`)
+	log := g.Func(`\log`)
+
+	g.Call(main, checkout)
+	g.Call(checkout, billing)
+	g.Call(checkout, shipping)
+	g.Call(main, shipping)
+	g.Call(billing, charge)
+	g.Call(billing, log)
+	g.Call(shipping, log)
+
+	rel := GetCommonRelation([]*symbols.Function{billing, shipping}, nil, symbols.DirectCalls)
+
+	if len(rel.CommonCallers) != 1 || rel.CommonCallers[0] != checkout {
+		t.Errorf("expected %s to be the only common caller, got %v", checkout.Name, rel.CommonCallers)
+	}
+	if len(rel.CommonCallees) != 1 || rel.CommonCallees[0] != log {
+		t.Errorf("expected %s to be the only common callee, got %v", log.Name, rel.CommonCallees)
+	}
+	if len(rel.LowestCommonCallers) != 1 || rel.LowestCommonCallers[0].Func != checkout {
+		t.Fatalf("expected %s to be the lowest common caller, got %v", checkout.Name, rel.LowestCommonCallers)
+	}
+}
@@ -0,0 +1,247 @@
+package relations
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/i582/cfmt"
+
+	"github.com/i582/phpstats/internal/representator"
+	"github.com/i582/phpstats/internal/stats/symbols"
+)
+
+// SymbolUsage is a usage of the symbol declared in one file in another file.
+type SymbolUsage struct {
+	// Kind is one of class, function, method, constant or field.
+	Kind string
+	Name string
+	// Where is the name of the class or function where the symbol
+	// is used, or the path to the file for the top-level code.
+	Where string
+}
+
+type File2FileRelation struct {
+	TargetFile  *symbols.File
+	RelatedFile *symbols.File
+
+	// TargetRequiresRelated and RelatedRequiresTarget store the shortest
+	// require chains between files, nil if there is no such chain.
+	TargetRequiresRelated []*symbols.File
+	RelatedRequiresTarget []*symbols.File
+
+	RelatedUsedInTarget []SymbolUsage
+	TargetUsedInRelated []SymbolUsage
+}
+
+func (r *File2FileRelation) String() string {
+	var res string
+
+	res += cfmt.Sprintf("File {{%s}}::green connection with file {{%s}}::yellow\n\n", r.TargetFile.Path, r.RelatedFile.Path)
+
+	res += stringFileRelationPart(r.TargetFile, r.RelatedFile, r.TargetRequiresRelated, r.RelatedUsedInTarget, "green", "yellow")
+	res += cfmt.Sprintln()
+	res += stringFileRelationPart(r.RelatedFile, r.TargetFile, r.RelatedRequiresTarget, r.TargetUsedInRelated, "yellow", "green")
+
+	return res
+}
+
+// Tables returns the require chains between the files and
+// the symbols of each file used in the other one.
+func (r *File2FileRelation) Tables() []*representator.Table {
+	requires := representator.NewTable(
+		representator.TableColumn{Header: "File", Wrap: true},
+		representator.TableColumn{Header: "Requires", Wrap: true},
+		representator.TableColumn{Header: "Through", Wrap: true},
+	)
+	requires.Title = fmt.Sprintf("Require chains between %s and %s", r.TargetFile.Name, r.RelatedFile.Name)
+
+	usages := representator.NewTable(
+		representator.TableColumn{Header: "File", Wrap: true},
+		representator.TableColumn{Header: "Kind"},
+		representator.TableColumn{Header: "Symbol", Wrap: true},
+		representator.TableColumn{Header: "Used in", Wrap: true},
+	)
+	usages.Title = fmt.Sprintf("Symbols of %s and %s used in each other", r.TargetFile.Name, r.RelatedFile.Name)
+
+	parts := []struct {
+		from, to *symbols.File
+		chain    []*symbols.File
+		usages   []SymbolUsage
+	}{
+		{from: r.TargetFile, to: r.RelatedFile, chain: r.TargetRequiresRelated, usages: r.RelatedUsedInTarget},
+		{from: r.RelatedFile, to: r.TargetFile, chain: r.RelatedRequiresTarget, usages: r.TargetUsedInRelated},
+	}
+
+	for _, part := range parts {
+		if part.chain != nil {
+			var through []string
+			for _, file := range part.chain[1 : len(part.chain)-1] {
+				through = append(through, file.Name)
+			}
+			requires.AddRow(part.from.Name, part.to.Name, strings.Join(through, " -> "))
+		}
+
+		for _, usage := range part.usages {
+			usages.AddRow(part.from.Name, usage.Kind, usage.Name, usage.Where)
+		}
+	}
+
+	return []*representator.Table{requires, usages}
+}
+
+func stringFileRelationPart(from, to *symbols.File, chain []*symbols.File, usages []SymbolUsage, fromColor, toColor string) string {
+	var res string
+
+	res += cfmt.Sprintf("    File {{%s}}::%s requires file {{%s}}::%s:   %t\n", from.Name, fromColor, to.Name, toColor, chain != nil)
+	if len(chain) > 2 {
+		names := make([]string, 0, len(chain))
+		for _, file := range chain {
+			names = append(names, file.Name)
+		}
+		res += cfmt.Sprintf("       Through: {{%s}}::gray\n", strings.Join(names, " -> "))
+	}
+
+	res += cfmt.Sprintf("    File {{%s}}::%s uses symbols of file {{%s}}::%s:   %d\n", from.Name, fromColor, to.Name, toColor, len(usages))
+	for _, usage := range usages {
+		res += cfmt.Sprintf("        %-8s {{%s}}::%s\n            in {{%s}}::%s\n", usage.Kind, usage.Name, toColor, usage.Where, fromColor)
+	}
+
+	return res
+}
+
+// GetFile2FileRelation returns the require chains between two files
+// and the symbols of each file used in the other one.
+func GetFile2FileRelation(targetFile, relatedFile *symbols.File) *File2FileRelation {
+	if targetFile == relatedFile {
+		return nil
+	}
+
+	return &File2FileRelation{
+		TargetFile:            targetFile,
+		RelatedFile:           relatedFile,
+		TargetRequiresRelated: requireChain(targetFile, relatedFile),
+		RelatedRequiresTarget: requireChain(relatedFile, targetFile),
+		RelatedUsedInTarget:   fileUsages(targetFile, relatedFile),
+		TargetUsedInRelated:   fileUsages(relatedFile, targetFile),
+	}
+}
+
+// requireChain finds the shortest chain of required files from one file to another.
+func requireChain(from, to *symbols.File) []*symbols.File {
+	prev := map[*symbols.File]*symbols.File{from: nil}
+	queue := []*symbols.File{from}
+
+	for len(queue) != 0 {
+		file := queue[0]
+		queue = queue[1:]
+
+		if file == to {
+			var chain []*symbols.File
+			for cur := to; cur != nil; cur = prev[cur] {
+				chain = append([]*symbols.File{cur}, chain...)
+			}
+			return chain
+		}
+
+		for _, required := range sortedFiles(file.RequiredRoot, file.RequiredBlock) {
+			if _, visited := prev[required]; visited {
+				continue
+			}
+			prev[required] = file
+			queue = append(queue, required)
+		}
+	}
+
+	return nil
+}
+
+func sortedFiles(sets ...*symbols.Files) []*symbols.File {
+	var files []*symbols.File
+	for _, set := range sets {
+		for _, file := range set.Files {
+			files = append(files, file)
+		}
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].Path < files[j].Path
+	})
+	return files
+}
+
+// fileUsages returns the symbols declared in the used file that are used in the file.
+func fileUsages(file, used *symbols.File) []SymbolUsage {
+	seen := map[SymbolUsage]struct{}{}
+	var usages []SymbolUsage
+
+	add := func(kind, name, where string) {
+		usage := SymbolUsage{Kind: kind, Name: name, Where: where}
+		if _, ok := seen[usage]; ok {
+			return
+		}
+		seen[usage] = struct{}{}
+		usages = append(usages, usage)
+	}
+
+	declaredIn := func(class *symbols.Class) bool {
+		return class != nil && class.File == used
+	}
+
+	addCalled := func(called *symbols.Function, where string) {
+		if called.Pos.Filename != used.Path {
+			return
+		}
+		kind := "function"
+		if called.IsMethod() {
+			kind = "method"
+		}
+		add(kind, called.Name.String(), where)
+	}
+
+	var funcs []*symbols.Function
+	for _, fn := range file.Funcs.Funcs {
+		funcs = append(funcs, fn)
+	}
+	for _, class := range file.Classes.Classes {
+		for _, dep := range class.Deps.Classes {
+			if declaredIn(dep) {
+				add("class", dep.Name, class.Name)
+			}
+		}
+		funcs = append(funcs, classFunctions(class)...)
+	}
+
+	for _, fn := range funcs {
+		for _, called := range fn.Called.Funcs {
+			addCalled(called, fn.Name.String())
+		}
+		for _, constant := range fn.UsedConstants.Constants {
+			if declaredIn(constant.Class) {
+				add("constant", constant.String(), fn.Name.String())
+			}
+		}
+		for _, field := range fn.UsedFields.Fields {
+			if declaredIn(field.Class) {
+				add("field", field.String(), fn.Name.String())
+			}
+		}
+	}
+
+	for _, called := range file.Called.Funcs {
+		addCalled(called, file.Path)
+	}
+	for _, class := range file.Instantiates.Classes {
+		if declaredIn(class) {
+			add("class", class.Name, file.Path)
+		}
+	}
+
+	sort.Slice(usages, func(i, j int) bool {
+		if usages[i].Name != usages[j].Name {
+			return usages[i].Name < usages[j].Name
+		}
+		return usages[i].Where < usages[j].Where
+	})
+
+	return usages
+}
@@ -0,0 +1,38 @@
+package relations
+
+import (
+	"testing"
+
+	"github.com/VKCOM/noverify/src/meta"
+
+	"github.com/i582/phpstats/internal/stats/symbols"
+)
+
+func TestGetFile2FileRelation(t *testing.T) {
+	index := symbols.NewFile("/project/index.php")
+	bootstrap := symbols.NewFile("/project/bootstrap.php")
+	lib := symbols.NewFile("/project/lib.php")
+
+	index.AddRequiredRootFile(bootstrap)
+	bootstrap.AddRequiredRootFile(lib)
+
+	render := symbols.NewFunction(symbols.NewFuncKey(`\render`), meta.ElementPosition{Filename: lib.Path})
+	lib.AddFunc(render)
+	index.Called.Add(render)
+
+	rel := GetFile2FileRelation(index, lib)
+
+	if len(rel.TargetRequiresRelated) != 3 || rel.TargetRequiresRelated[1] != bootstrap {
+		t.Errorf("expected require chain index.php -> bootstrap.php -> lib.php, got %d files", len(rel.TargetRequiresRelated))
+	}
+	if rel.RelatedRequiresTarget != nil {
+		t.Errorf("lib.php must not require index.php")
+	}
+
+	if len(rel.RelatedUsedInTarget) != 1 || rel.RelatedUsedInTarget[0].Name != `\render` || rel.RelatedUsedInTarget[0].Where != index.Path {
+		t.Errorf("expected \\render to be used in the top-level code of index.php, got %v", rel.RelatedUsedInTarget)
+	}
+	if len(rel.TargetUsedInRelated) != 0 {
+		t.Errorf("expected no symbols of index.php used in lib.php, got %v", rel.TargetUsedInRelated)
+	}
+}
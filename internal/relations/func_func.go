@@ -2,6 +2,7 @@ package relations
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/i582/cfmt"
 
@@ -19,6 +20,10 @@ type Func2FuncRelation struct {
 	TargetUsedInRelated bool
 	RelatedUsedInTarget bool
 
+	// TargetCallsInRelated and RelatedCallsInTarget store the number of calls by kind.
+	TargetCallsInRelated *symbols.Dependency
+	RelatedCallsInTarget *symbols.Dependency
+
 	TargetReachableFromRelated      bool
 	TargetReachableFromRelatedPaths [][]*symbols.Function
 
@@ -43,30 +48,82 @@ func (r *Func2FuncRelation) String() string {
 		res += cfmt.Sprintf("\n")
 	}
 
-	res += cfmt.Sprintf("    Function {{%s}}::yellow is used in function {{%s}}::green:          %t\n", r.RelatedFunction.Name, r.TargetFunction.Name, r.RelatedUsedInTarget)
-	res += cfmt.Sprintf("    Function {{%s}}::green is used in function {{%s}}::yellow:          %t\n", r.TargetFunction.Name, r.RelatedFunction.Name, r.RelatedUsedInTarget)
+	res += cfmt.Sprintf("    Function {{%s}}::yellow is used in function {{%s}}::green:          %t%s\n", r.RelatedFunction.Name, r.TargetFunction.Name, r.RelatedUsedInTarget, stringCalls(r.RelatedCallsInTarget))
+	res += cfmt.Sprintf("    Function {{%s}}::green is used in function {{%s}}::yellow:          %t%s\n", r.TargetFunction.Name, r.RelatedFunction.Name, r.TargetUsedInRelated, stringCalls(r.TargetCallsInRelated))
 
 	res += cfmt.Sprintf("    Is function {{%s}}::yellow reachable from function {{%s}}::green:   %t\n", r.RelatedFunction.Name, r.TargetFunction.Name, r.RelatedReachableFromTarget)
 	if r.RelatedReachableFromTarget {
-		res += cfmt.Sprintf("       To get the graph, enter command\n       {{ graph func-reachability --web --parent %s --child %s --depth 10 }}::blue\n", r.TargetFunction.Name, r.RelatedFunction.Name)
-		res += cfmt.Sprintf("       To get text output of paths, enter the command\n       {{ relation func-reachability --parent %s --child %s --show --depth 10 }}::blue\n\n", r.TargetFunction.Name, r.RelatedFunction.Name)
+		res += cfmt.Sprintf("       To get the graph, enter command\n       {{ graph func-reachability --web --parent %s --child %s }}::blue\n", r.TargetFunction.Name, r.RelatedFunction.Name)
+		res += cfmt.Sprintf("       To get text output of paths, enter the command\n       {{ relation func-reachability --parent %s --child %s --show }}::blue\n\n", r.TargetFunction.Name, r.RelatedFunction.Name)
 	}
 
 	res += cfmt.Sprintf("    Is function {{%s}}::green reachable from function {{%s}}::yellow:   %t\n", r.TargetFunction.Name, r.RelatedFunction.Name, r.TargetReachableFromRelated)
 	if r.TargetReachableFromRelated {
-		res += cfmt.Sprintf("       To get the graph, enter command\n       {{ graph func-reachability --web --parent %s --child %s --depth 10 }}::blue\n", r.RelatedFunction.Name, r.TargetFunction.Name)
-		res += cfmt.Sprintf("       To get text output of paths, enter the command\n       {{ relation func-reachability --parent %s --child %s --show --depth 10 }}::blue\n\n", r.RelatedFunction.Name, r.TargetFunction.Name)
+		res += cfmt.Sprintf("       To get the graph, enter command\n       {{ graph func-reachability --web --parent %s --child %s }}::blue\n", r.RelatedFunction.Name, r.TargetFunction.Name)
+		res += cfmt.Sprintf("       To get text output of paths, enter the command\n       {{ relation func-reachability --parent %s --child %s --show }}::blue\n\n", r.RelatedFunction.Name, r.TargetFunction.Name)
 	}
 
 	return res
 }
 
+func stringCalls(calls *symbols.Dependency) string {
+	if calls == nil {
+		return ""
+	}
+
+	sites := calls.SortedSites()
+	locations := make([]string, 0, len(sites))
+	for _, site := range sites {
+		locations = append(locations, site.String())
+	}
+
+	if len(locations) == 0 {
+		return cfmt.Sprintf(" {{(%s)}}::gray", calls)
+	}
+	return cfmt.Sprintf(" {{(%s at %s)}}::gray", calls, strings.Join(locations, ", "))
+}
+
+// stringCallSites returns the places where the caller calls the callee,
+// for example, "a.php:10, a.php:15".
+func stringCallSites(caller, callee *symbols.Function) string {
+	sites := caller.CallSites(callee)
+	parts := make([]string, 0, len(sites))
+	for _, site := range sites {
+		parts = append(parts, site.String())
+	}
+	return strings.Join(parts, ", ")
+}
+
+// callstackLocations returns the place of each call in the callstack.
+func callstackLocations(callstack []*symbols.Function) []string {
+	locations := make([]string, 0, len(callstack))
+	for i := 0; i < len(callstack)-1; i++ {
+		sites := callstack[i].CallSites(callstack[i+1])
+
+		var location string
+		if len(sites) != 0 {
+			location = sites[0].String()
+		}
+		if callstack[i].IsVirtualCall(callstack[i+1]) {
+			location = strings.TrimSpace("virtual " + location)
+		}
+
+		locations = append(locations, location)
+	}
+	return locations
+}
+
 func stringCallstack(callstack []*symbols.Function) string {
+	locations := callstackLocations(callstack)
+
 	var res string
 	res += fmt.Sprintf("[(%d calls)\n    ", len(callstack)-1)
 	for i, f := range callstack {
 		res += fmt.Sprint(f.Name)
 		if i != len(callstack)-1 {
+			if locations[i] != "" {
+				res += cfmt.Sprintf(" {{(%s)}}::gray", locations[i])
+			}
 			res += fmt.Sprintf("\n    %s -> ", utils.GenIndent(i))
 		}
 	}
@@ -93,78 +150,20 @@ func GetFunc2FuncRelation(targetFunction *symbols.Function, relatedFunction *sym
 	}
 
 	for _, calledInRelated := range rel.RelatedFunction.Called.Funcs {
-		if calledInRelated == relatedFunction {
+		if calledInRelated == targetFunction {
 			rel.TargetUsedInRelated = true
 			break
 		}
 	}
 
-	rel.RelatedReachableFromTarget, rel.RelatedReachableFromTargetPaths = calledInCallstack(targetFunction, relatedFunction, nil, map[*symbols.Function]struct{}{}, 0, 10)
-	rel.TargetReachableFromRelated, rel.TargetReachableFromRelatedPaths = calledInCallstack(relatedFunction, targetFunction, nil, map[*symbols.Function]struct{}{}, 0, 10)
+	rel.RelatedCallsInTarget, _ = targetFunction.CallEdges.Get(relatedFunction.Name.String())
+	rel.TargetCallsInRelated, _ = relatedFunction.CallEdges.Get(targetFunction.Name.String())
 
-	return rel
-}
-
-func calledInCallstack(parent, child *symbols.Function, callstack []*symbols.Function, visited map[*symbols.Function]struct{}, currentLevel, maxLevel int64) (bool, [][]*symbols.Function) {
-	if parent.Called.Len() == 0 {
-		return false, nil
-	}
-
-	if callstack == nil {
-		callstack = []*symbols.Function{parent}
-	}
+	search := newPathSearch(symbols.DirectCalls, 0, nil)
+	rel.RelatedReachableFromTargetPaths, _ = search.kShortest(targetFunction, relatedFunction, 10)
+	rel.TargetReachableFromRelatedPaths, _ = search.kShortest(relatedFunction, targetFunction, 10)
+	rel.RelatedReachableFromTarget = len(rel.RelatedReachableFromTargetPaths) != 0
+	rel.TargetReachableFromRelated = len(rel.TargetReachableFromRelatedPaths) != 0
 
-	if parent == child {
-		return true, [][]*symbols.Function{callstack}
-	}
-
-	if currentLevel >= maxLevel {
-		return false, [][]*symbols.Function{}
-	}
-
-	var callstacks [][]*symbols.Function
-
-	for _, called := range parent.Called.Funcs {
-		newCallstack := copyCallstack(callstack)
-		newVisited := copyVisited(visited)
-
-		newCallstack = append(newCallstack, called)
-
-		if _, ok := newVisited[called]; ok {
-			continue
-		}
-		if called == parent {
-			continue
-		}
-
-		newVisited[called] = struct{}{}
-
-		if called == child {
-			callstacks = append(callstacks, newCallstack)
-			continue
-		}
-
-		call, callstack := calledInCallstack(called, child, newCallstack, newVisited, currentLevel+1, maxLevel)
-		if call {
-			callstacks = append(callstacks, callstack...)
-		}
-	}
-
-	return len(callstacks) != 0, callstacks
-}
-
-func copyCallstack(callstack []*symbols.Function) []*symbols.Function {
-	tmp := make([]*symbols.Function, len(callstack))
-	copy(tmp, callstack)
-	return tmp
-}
-
-func copyVisited(visited map[*symbols.Function]struct{}) map[*symbols.Function]struct{} {
-	targetMap := make(map[*symbols.Function]struct{})
-
-	for key, value := range visited {
-		targetMap[key] = value
-	}
-
-	return targetMap
+	return rel
 }
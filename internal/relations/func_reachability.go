@@ -4,21 +4,35 @@ import (
 	"encoding/json"
 	"fmt"
 	"sort"
+	"strings"
 
+	"github.com/alexeyco/simpletable"
 	"github.com/i582/cfmt"
 
+	"github.com/i582/phpstats/internal/representator"
 	"github.com/i582/phpstats/internal/stats/symbols"
 )
 
 type ReachabilityExcludedMap map[*symbols.Function]*symbols.Function
 
 type ReachabilityFunctionJsonResult struct {
-	ParentFunction    string     `json:"parentFunctions"`
-	ChildFunction     string     `json:"childFunctions"`
-	ExcludedFunctions []string   `json:"excluded"`
-	Count             int64      `json:"count"`
-	Offset            int64      `json:"offset"`
-	Paths             [][]string `json:"paths"`
+	ParentFunction     string     `json:"parentFunctions"`
+	ChildFunction      string     `json:"childFunctions"`
+	ExcludedFunctions  []string   `json:"excluded"`
+	ExcludedNamespaces []string   `json:"excludedNamespaces"`
+	ExcludedRegexp     string     `json:"excludedRegexp"`
+	Mode               string     `json:"mode"`
+	Reachable          bool       `json:"reachable"`
+	MaxPaths           int64      `json:"maxPaths"`
+	LimitReached       bool       `json:"limitReached"`
+	Count              int64      `json:"count"`
+	Offset             int64      `json:"offset"`
+	Paths              [][]string `json:"paths"`
+
+	// Locations contains for each path the places where
+	// each function of the path calls the next one,
+	// the virtual calls are prefixed with "virtual".
+	Locations [][]string `json:"locations"`
 }
 
 type ReachabilityFunctionResult struct {
@@ -26,8 +40,18 @@ type ReachabilityFunctionResult struct {
 	ChildFunction  *symbols.Function
 	Reachable      bool
 
-	Paths             [][]*symbols.Function
-	ExcludedFunctions ReachabilityExcludedMap
+	// Paths stores the shortest paths sorted by length.
+	Paths      [][]*symbols.Function
+	Exclusions *ReachabilityExclusions
+
+	// Mode determines whether the virtual calls through
+	// the overriding methods are considered.
+	Mode symbols.CallGraphMode
+
+	// MaxPaths is the maximum number of paths to find, LimitReached
+	// is true if there are more paths than found.
+	MaxPaths     int64
+	LimitReached bool
 
 	PrintPaths  bool
 	PrintCount  int64
@@ -54,6 +78,16 @@ func functionsPathsToStringPaths(paths [][]*symbols.Function) [][]string {
 	return res
 }
 
+func functionsPathsToLocations(paths [][]*symbols.Function) [][]string {
+	res := make([][]string, 0, len(paths))
+
+	for _, path := range paths {
+		res = append(res, callstackLocations(path))
+	}
+
+	return res
+}
+
 func excludedMapToString(excluded ReachabilityExcludedMap) []string {
 	var res []string
 
@@ -61,61 +95,129 @@ func excludedMapToString(excluded ReachabilityExcludedMap) []string {
 		res = append(res, function.Name.String())
 	}
 
+	sort.Strings(res)
+
 	return res
 }
 
 func (r *ReachabilityFunctionResult) Json() ([]byte, error) {
-	return json.MarshalIndent(&ReachabilityFunctionJsonResult{
-		ParentFunction:    r.ParentFunction.Name.String(),
-		ChildFunction:     r.ChildFunction.Name.String(),
-		ExcludedFunctions: excludedMapToString(r.ExcludedFunctions),
-		Paths:             functionsPathsToStringPaths(r.Paths),
-		Count:             int64(len(r.Paths)),
-		Offset:            r.PrintOffset,
-	}, "", "\t")
+	data := &ReachabilityFunctionJsonResult{
+		ParentFunction: r.ParentFunction.Name.String(),
+		ChildFunction:  r.ChildFunction.Name.String(),
+		Mode:           r.Mode.String(),
+		Reachable:      r.Reachable,
+		MaxPaths:       r.MaxPaths,
+		LimitReached:   r.LimitReached,
+		Paths:          functionsPathsToStringPaths(r.Paths),
+		Locations:      functionsPathsToLocations(r.Paths),
+		Count:          int64(len(r.Paths)),
+		Offset:         r.PrintOffset,
+	}
+
+	if r.Exclusions != nil {
+		data.ExcludedFunctions = excludedMapToString(r.Exclusions.Functions)
+		data.ExcludedNamespaces = r.Exclusions.Namespaces
+		if r.Exclusions.Regexp != nil {
+			data.ExcludedRegexp = r.Exclusions.Regexp.String()
+		}
+	}
+
+	return json.MarshalIndent(data, "", "\t")
 }
 
 func (r *ReachabilityFunctionResult) String() string {
 	var res string
 
 	res += cfmt.Sprintf("Is function {{%s}}::green reachable from function {{%s}}::yellow: %t", r.ChildFunction.Name, r.ParentFunction.Name, r.Reachable)
-	if r.Reachable {
-		res += fmt.Sprintf(" (%d paths)\n", len(r.Paths))
+	if len(r.Paths) != 0 {
+		res += fmt.Sprintf(" (shortest path: %d calls)\n", len(r.Paths[0])-1)
 	} else {
 		res += "\n"
 	}
 
 	if r.Reachable && r.PrintPaths {
-		sort.Slice(r.Paths, func(i, j int) bool {
-			return len(r.Paths[i]) < len(r.Paths[j])
-		})
-
-		neededPaths := r.Paths
-		if r.PrintCount+r.PrintOffset < int64(len(neededPaths)) {
-			neededPaths = neededPaths[:r.PrintCount+r.PrintOffset]
-		}
-
-		if r.PrintOffset < int64(len(neededPaths)) {
-			neededPaths = neededPaths[r.PrintOffset:]
-		}
+		neededPaths := r.printedPaths()
 
-		res += fmt.Sprintf("Showing %d paths out of %d starting from %d:\n\n", len(neededPaths), len(r.Paths), r.PrintOffset+1)
+		res += fmt.Sprintf("Showing %d shortest paths out of %d found starting from %d:\n\n", len(neededPaths), len(r.Paths), r.PrintOffset+1)
 
 		for _, path := range neededPaths {
 			res += "    " + stringCallstack(path) + "\n"
 		}
 	}
 
+	if r.LimitReached {
+		res += cfmt.Sprintf("{{The limit of %d paths was reached, there are more paths, use --paths to find more}}::yellow\n", r.MaxPaths)
+	}
+
 	return res
 }
 
-func GetReachabilityFunction(parentFunction *symbols.Function, childFunction *symbols.Function, excludedFunctions ReachabilityExcludedMap, maxDepth int64) *ReachabilityFunctionResult {
+// printedPaths returns the paths limited by PrintCount and PrintOffset.
+func (r *ReachabilityFunctionResult) printedPaths() [][]*symbols.Function {
+	neededPaths := r.Paths
+	if r.PrintCount+r.PrintOffset < int64(len(neededPaths)) {
+		neededPaths = neededPaths[:r.PrintCount+r.PrintOffset]
+	}
+
+	if r.PrintOffset < int64(len(neededPaths)) {
+		neededPaths = neededPaths[r.PrintOffset:]
+	}
+
+	return neededPaths
+}
+
+// Table returns the found paths limited by PrintCount and PrintOffset.
+func (r *ReachabilityFunctionResult) Table() *representator.Table {
+	table := representator.NewTable(
+		representator.TableColumn{Header: "#", Align: simpletable.AlignRight},
+		representator.TableColumn{Header: "Calls", Align: simpletable.AlignRight},
+		representator.TableColumn{Header: "Path"},
+		representator.TableColumn{Header: "Locations"},
+	)
+	table.Title = fmt.Sprintf("Paths from %s to %s", r.ParentFunction.Name, r.ChildFunction.Name)
+
+	for index, path := range r.printedPaths() {
+		names := make([]string, 0, len(path))
+		for _, fn := range path {
+			names = append(names, fn.Name.String())
+		}
+
+		table.AddRow(
+			fmt.Sprint(int64(index+1)+r.PrintOffset),
+			fmt.Sprint(len(path)-1),
+			strings.Join(names, "\n"),
+			strings.Join(callstackLocations(path), "\n"),
+		)
+	}
+
+	return table
+}
+
+// ReachabilityOptions describes the search of paths between functions.
+type ReachabilityOptions struct {
+	Exclusions *ReachabilityExclusions
+	// MaxDepth is the maximum number of calls in a path, 0 means no limit.
+	MaxDepth int64
+	// MaxPaths is the maximum number of the shortest paths to find.
+	MaxPaths int64
+	Mode     symbols.CallGraphMode
+}
+
+// GetReachabilityFunction finds up to opt.MaxPaths shortest paths
+// from the parent function to the child one.
+func GetReachabilityFunction(parentFunction *symbols.Function, childFunction *symbols.Function, opt ReachabilityOptions) *ReachabilityFunctionResult {
 	rel := NewReachabilityFunctionResult()
 	rel.ParentFunction = parentFunction
 	rel.ChildFunction = childFunction
-	rel.ExcludedFunctions = excludedFunctions
-
-	rel.Reachable, rel.Paths = calledInCallstack(parentFunction, childFunction, nil, map[*symbols.Function]struct{}{}, 0, maxDepth)
+	rel.Exclusions = opt.Exclusions
+	rel.Mode = opt.Mode
+	rel.MaxPaths = opt.MaxPaths
+
+	search := newPathSearch(opt.Mode, opt.MaxDepth, opt.Exclusions)
+	rel.Paths, rel.LimitReached = search.kShortest(parentFunction, childFunction, opt.MaxPaths)
+	// No paths are listed if MaxPaths is 0, but the limit is
+	// reached as soon as the shortest path exists.
+	rel.Reachable = len(rel.Paths) != 0 || rel.LimitReached
 
 	return rel
 }
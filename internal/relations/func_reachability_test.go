@@ -0,0 +1,81 @@
+package relations
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/i582/phpstats/internal/stats/symbols/symbolstest"
+)
+
+func TestGetReachabilityFunction(t *testing.T) {
+	g := symbolstest.NewGraph("foo.php")
+	fn := g.Func
+
+	// main -> a -> target
+	// main -> \Lib\b -> c -> target
+	// main -> d -> e -> f -> target
+	// a -> a (recursion)
+	g.Call(fn(`\main`), fn(`\a`))
+	g.Call(fn(`\a`), fn(`\target`))
+	g.Call(fn(`\a`), fn(`\a`))
+	g.Call(fn(`\main`), fn(`\Lib\b`))
+	g.Call(fn(`\Lib\b`), fn(`\c`))
+	g.Call(fn(`\c`), fn(`\target`))
+	g.Call(fn(`\main`), fn(`\d`))
+	g.Call(fn(`\d`), fn(`\e`))
+	g.Call(fn(`\e`), fn(`\f`))
+	g.Call(fn(`\f`), fn(`\target`))
+
+	pathLens := func(rel *ReachabilityFunctionResult) []int {
+		var lens []int
+		for _, path := range rel.Paths {
+			lens = append(lens, len(path)-1)
+		}
+		return lens
+	}
+
+	rel := GetReachabilityFunction(fn(`\main`), fn(`\target`), ReachabilityOptions{MaxPaths: 10})
+	if !rel.Reachable || rel.LimitReached || len(rel.Paths) != 3 {
+		t.Fatalf("expected 3 paths without the limit, got %v, limit %t", pathLens(rel), rel.LimitReached)
+	}
+	if got := pathLens(rel); got[0] != 2 || got[1] != 3 || got[2] != 4 {
+		t.Errorf("expected paths sorted by length, got %v", got)
+	}
+
+	rel = GetReachabilityFunction(fn(`\main`), fn(`\target`), ReachabilityOptions{MaxPaths: 2})
+	if len(rel.Paths) != 2 || !rel.LimitReached {
+		t.Errorf("expected 2 paths with the limit reached, got %v, limit %t", pathLens(rel), rel.LimitReached)
+	}
+
+	rel = GetReachabilityFunction(fn(`\main`), fn(`\target`), ReachabilityOptions{MaxPaths: 0})
+	if !rel.Reachable || !rel.LimitReached || len(rel.Paths) != 0 {
+		t.Errorf("expected reachable without paths, got %v, limit %t", pathLens(rel), rel.LimitReached)
+	}
+	if !strings.Contains(rel.String(), "true") {
+		t.Errorf("expected reachable in the output, got %s", rel.String())
+	}
+
+	rel = GetReachabilityFunction(fn(`\main`), fn(`\target`), ReachabilityOptions{MaxPaths: 10, MaxDepth: 3})
+	if len(rel.Paths) != 2 {
+		t.Errorf("expected 2 paths not longer than 3 calls, got %v", pathLens(rel))
+	}
+
+	rel = GetReachabilityFunction(fn(`\main`), fn(`\target`), ReachabilityOptions{
+		MaxPaths: 10,
+		Exclusions: &ReachabilityExclusions{
+			Namespaces: []string{`Lib`},
+			Regexp:     regexp.MustCompile(`^\\[de]$`),
+		},
+	})
+	if len(rel.Paths) != 1 || rel.Paths[0][1] != fn(`\a`) {
+		t.Errorf("expected the only path through \\a, got %v", pathLens(rel))
+	}
+
+	for _, maxPaths := range []int64{0, 10} {
+		rel = GetReachabilityFunction(fn(`\target`), fn(`\main`), ReachabilityOptions{MaxPaths: maxPaths})
+		if rel.Reachable || rel.LimitReached {
+			t.Errorf("expected \\main to be unreachable from \\target with %d paths", maxPaths)
+		}
+	}
+}
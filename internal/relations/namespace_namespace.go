@@ -0,0 +1,229 @@
+package relations
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/i582/cfmt"
+
+	"github.com/i582/phpstats/internal/representator"
+	"github.com/i582/phpstats/internal/stats/symbols"
+)
+
+// ClassEdge is a dependency of one class on another.
+type ClassEdge struct {
+	From *symbols.Class
+	To   *symbols.Class
+	Dep  *symbols.Dependency
+}
+
+// FuncEdge is a call of one function by another.
+type FuncEdge struct {
+	From *symbols.Function
+	To   *symbols.Function
+	Dep  *symbols.Dependency
+}
+
+// NamespaceEdges are the dependencies of the symbols of one namespace
+// on the symbols of another namespace.
+type NamespaceEdges struct {
+	Classes []ClassEdge
+	Funcs   []FuncEdge
+}
+
+func (e NamespaceEdges) Len() int {
+	return len(e.Classes) + len(e.Funcs)
+}
+
+type Namespace2NamespaceRelation struct {
+	TargetNamespace  *symbols.Namespace
+	RelatedNamespace *symbols.Namespace
+
+	// TargetToRelated stores the dependencies of the target namespace
+	// on the related namespace, RelatedToTarget stores the reverse ones.
+	TargetToRelated NamespaceEdges
+	RelatedToTarget NamespaceEdges
+}
+
+func (r *Namespace2NamespaceRelation) String() string {
+	var res string
+
+	res += cfmt.Sprintf("Namespace {{%s}}::green connection with namespace {{%s}}::yellow\n\n", r.TargetNamespace.FullName, r.RelatedNamespace.FullName)
+
+	res += stringNamespaceEdges(r.TargetToRelated, r.TargetNamespace, r.RelatedNamespace, "green", "yellow")
+	res += cfmt.Sprintln()
+	res += stringNamespaceEdges(r.RelatedToTarget, r.RelatedNamespace, r.TargetNamespace, "yellow", "green")
+
+	return res
+}
+
+// Table returns the edges between the namespaces in both directions.
+func (r *Namespace2NamespaceRelation) Table() *representator.Table {
+	table := representator.NewTable(
+		representator.TableColumn{Header: "From", Wrap: true},
+		representator.TableColumn{Header: "To", Wrap: true},
+		representator.TableColumn{Header: "Kind"},
+		representator.TableColumn{Header: "Details", Wrap: true},
+	)
+	table.Title = fmt.Sprintf("Edges between namespaces %s and %s", r.TargetNamespace.FullName, r.RelatedNamespace.FullName)
+
+	for _, edges := range []NamespaceEdges{r.TargetToRelated, r.RelatedToTarget} {
+		for _, edge := range edges.Classes {
+			var details string
+			if edge.Dep != nil {
+				details = edge.Dep.String()
+			}
+			table.AddRow(edge.From.Name, edge.To.Name, "class", details)
+		}
+		for _, edge := range edges.Funcs {
+			table.AddRow(edge.From.Name.String(), edge.To.Name.String(), "function", stringCallSites(edge.From, edge.To))
+		}
+	}
+
+	return table
+}
+
+func stringNamespaceEdges(edges NamespaceEdges, from, to *symbols.Namespace, fromColor, toColor string) string {
+	var res string
+
+	res += cfmt.Sprintf("    Namespace {{%s}}::%s depends on namespace {{%s}}::%s:   %d edges\n", from.FullName, fromColor, to.FullName, toColor, edges.Len())
+
+	if len(edges.Classes) != 0 {
+		res += cfmt.Sprintf("       Classes:\n")
+		for _, edge := range edges.Classes {
+			res += cfmt.Sprintf("        {{%s}}::%s -> {{%s}}::%s", edge.From.Name, fromColor, edge.To.Name, toColor)
+			if edge.Dep != nil {
+				res += cfmt.Sprintf(" {{(%s)}}::gray", edge.Dep)
+			}
+			res += "\n"
+		}
+	}
+
+	if len(edges.Funcs) != 0 {
+		res += cfmt.Sprintf("       Functions:\n")
+		for _, edge := range edges.Funcs {
+			res += cfmt.Sprintf("        {{%s}}::%s -> {{%s}}::%s%s\n", edge.From.Name, fromColor, edge.To.Name, toColor, stringSitesSuffix(edge.From, edge.To))
+		}
+	}
+
+	return res
+}
+
+// GetNamespace2NamespaceRelation returns all class and function edges between
+// two namespaces in both directions.
+//
+// The child namespaces are included in the namespace, however, if one of the
+// namespaces contains the other one, the symbols of the nested namespace
+// are considered only as part of the nested namespace.
+func GetNamespace2NamespaceRelation(targetNamespace, relatedNamespace *symbols.Namespace) *Namespace2NamespaceRelation {
+	if targetNamespace == relatedNamespace {
+		return nil
+	}
+
+	rel := &Namespace2NamespaceRelation{
+		TargetNamespace:  targetNamespace,
+		RelatedNamespace: relatedNamespace,
+	}
+
+	target := collectNamespaceSymbols(targetNamespace)
+	related := collectNamespaceSymbols(relatedNamespace)
+
+	switch {
+	case isSubNamespace(relatedNamespace, targetNamespace):
+		target.exclude(related)
+	case isSubNamespace(targetNamespace, relatedNamespace):
+		related.exclude(target)
+	}
+
+	rel.TargetToRelated = namespaceEdges(target, related)
+	rel.RelatedToTarget = namespaceEdges(related, target)
+
+	return rel
+}
+
+// isSubNamespace checks if the namespace is nested in the parent one.
+func isSubNamespace(ns, parent *symbols.Namespace) bool {
+	return strings.HasPrefix(ns.FullName, parent.FullName+`\`)
+}
+
+type namespaceSymbols struct {
+	classes map[*symbols.Class]struct{}
+	funcs   map[*symbols.Function]struct{}
+}
+
+func collectNamespaceSymbols(ns *symbols.Namespace) *namespaceSymbols {
+	res := &namespaceSymbols{
+		classes: map[*symbols.Class]struct{}{},
+		funcs:   map[*symbols.Function]struct{}{},
+	}
+
+	var collect func(ns *symbols.Namespace)
+	collect = func(ns *symbols.Namespace) {
+		for _, class := range ns.Classes.Classes {
+			res.classes[class] = struct{}{}
+			for _, method := range classFunctions(class) {
+				res.funcs[method] = struct{}{}
+			}
+		}
+		for _, fn := range ns.Functions.Funcs {
+			res.funcs[fn] = struct{}{}
+		}
+		for _, child := range ns.Childs.Namespaces {
+			collect(child)
+		}
+	}
+	collect(ns)
+
+	return res
+}
+
+func (s *namespaceSymbols) exclude(other *namespaceSymbols) {
+	for class := range other.classes {
+		delete(s.classes, class)
+	}
+	for fn := range other.funcs {
+		delete(s.funcs, fn)
+	}
+}
+
+func namespaceEdges(from, to *namespaceSymbols) NamespaceEdges {
+	var edges NamespaceEdges
+
+	for class := range from.classes {
+		for _, dep := range class.Deps.Classes {
+			if _, ok := to.classes[dep]; !ok {
+				continue
+			}
+
+			edge, _ := class.DepEdges.Get(dep.Name)
+			edges.Classes = append(edges.Classes, ClassEdge{From: class, To: dep, Dep: edge})
+		}
+	}
+
+	for fn := range from.funcs {
+		for _, called := range fn.Called.Funcs {
+			if _, ok := to.funcs[called]; !ok {
+				continue
+			}
+
+			edge, _ := fn.CallEdges.Get(called.Name.String())
+			edges.Funcs = append(edges.Funcs, FuncEdge{From: fn, To: called, Dep: edge})
+		}
+	}
+
+	sort.Slice(edges.Classes, func(i, j int) bool {
+		if edges.Classes[i].From.Name != edges.Classes[j].From.Name {
+			return edges.Classes[i].From.Name < edges.Classes[j].From.Name
+		}
+		return edges.Classes[i].To.Name < edges.Classes[j].To.Name
+	})
+	sort.Slice(edges.Funcs, func(i, j int) bool {
+		if edges.Funcs[i].From.Name.String() != edges.Funcs[j].From.Name.String() {
+			return edges.Funcs[i].From.Name.String() < edges.Funcs[j].From.Name.String()
+		}
+		return edges.Funcs[i].To.Name.String() < edges.Funcs[j].To.Name.String()
+	})
+
+	return edges
+}
@@ -0,0 +1,237 @@
+package relations
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/i582/phpstats/internal/stats/symbols"
+)
+
+// ReachabilityExclusions describes the functions that
+// are not traversed when searching for paths.
+type ReachabilityExclusions struct {
+	Functions  ReachabilityExcludedMap
+	Namespaces []string
+	Regexp     *regexp.Regexp
+}
+
+// IsExcluded checks if the function is excluded by name, namespace or regexp.
+func (e *ReachabilityExclusions) IsExcluded(fn *symbols.Function) bool {
+	if e == nil {
+		return false
+	}
+
+	if _, ok := e.Functions[fn]; ok {
+		return true
+	}
+
+	name := fn.Name.String()
+	for _, namespace := range e.Namespaces {
+		prefix := `\` + strings.Trim(namespace, `\`) + `\`
+		if len(name) > len(prefix) && strings.EqualFold(name[:len(prefix)], prefix) {
+			return true
+		}
+	}
+
+	return e.Regexp != nil && e.Regexp.MatchString(name)
+}
+
+// pathSearch finds the shortest paths in the call graph with the
+// breadth-first search, the k shortest paths are found with Yen's algorithm.
+type pathSearch struct {
+	mode      symbols.CallGraphMode
+	maxDepth  int64
+	exclusion *ReachabilityExclusions
+
+	callees map[*symbols.Function][]*symbols.Function
+}
+
+type callEdge struct {
+	from, to *symbols.Function
+}
+
+func newPathSearch(mode symbols.CallGraphMode, maxDepth int64, exclusion *ReachabilityExclusions) *pathSearch {
+	return &pathSearch{
+		mode:      mode,
+		maxDepth:  maxDepth,
+		exclusion: exclusion,
+		callees:   map[*symbols.Function][]*symbols.Function{},
+	}
+}
+
+// calleesOf returns the callees of the function sorted by name, so
+// that the found paths do not depend on the order of the map iteration.
+func (s *pathSearch) calleesOf(fn *symbols.Function) []*symbols.Function {
+	if callees, ok := s.callees[fn]; ok {
+		return callees
+	}
+
+	funcs := fn.Callees(s.mode)
+	callees := make([]*symbols.Function, 0, funcs.Len())
+	for _, callee := range funcs.Funcs {
+		callees = append(callees, callee)
+	}
+
+	sort.Slice(callees, func(i, j int) bool {
+		return callees[i].Name.String() < callees[j].Name.String()
+	})
+
+	s.callees[fn] = callees
+	return callees
+}
+
+// shortest returns the shortest path from one function to another with
+// at most maxLen calls, skipping the removed functions and calls.
+func (s *pathSearch) shortest(from, to *symbols.Function, maxLen int64, removedFuncs map[*symbols.Function]struct{}, removedEdges map[callEdge]struct{}) []*symbols.Function {
+	if from == to {
+		return []*symbols.Function{from}
+	}
+
+	prev := map[*symbols.Function]*symbols.Function{from: nil}
+	depth := map[*symbols.Function]int64{from: 0}
+	queue := []*symbols.Function{from}
+
+	for len(queue) != 0 {
+		cur := queue[0]
+		queue = queue[1:]
+
+		if maxLen > 0 && depth[cur] >= maxLen {
+			continue
+		}
+
+		for _, callee := range s.calleesOf(cur) {
+			if _, ok := prev[callee]; ok {
+				continue
+			}
+			if _, ok := removedFuncs[callee]; ok {
+				continue
+			}
+			if _, ok := removedEdges[callEdge{cur, callee}]; ok {
+				continue
+			}
+			if callee != to && s.exclusion.IsExcluded(callee) {
+				continue
+			}
+
+			prev[callee] = cur
+			depth[callee] = depth[cur] + 1
+
+			if callee == to {
+				return buildPath(prev, to)
+			}
+
+			queue = append(queue, callee)
+		}
+	}
+
+	return nil
+}
+
+func buildPath(prev map[*symbols.Function]*symbols.Function, to *symbols.Function) []*symbols.Function {
+	var path []*symbols.Function
+	for cur := to; cur != nil; cur = prev[cur] {
+		path = append(path, cur)
+	}
+
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+
+	return path
+}
+
+// kShortest returns up to k shortest paths without cycles, the
+// second result is true if there are more paths than k.
+func (s *pathSearch) kShortest(from, to *symbols.Function, k int64) ([][]*symbols.Function, bool) {
+	first := s.shortest(from, to, s.maxDepth, nil, nil)
+	if first == nil || k <= 0 {
+		return nil, first != nil
+	}
+
+	paths := [][]*symbols.Function{first}
+	seen := map[string]struct{}{pathKey(first): {}}
+	var candidates [][]*symbols.Function
+
+	// One more path is searched to know if the limit was reached.
+	for int64(len(paths)) <= k {
+		last := paths[len(paths)-1]
+
+		for i := 0; i < len(last)-1; i++ {
+			spur := last[i]
+			root := last[:i+1]
+
+			removedEdges := map[callEdge]struct{}{}
+			for _, path := range paths {
+				if len(path) > i+1 && samePrefix(path, root) {
+					removedEdges[callEdge{path[i], path[i+1]}] = struct{}{}
+				}
+			}
+
+			removedFuncs := map[*symbols.Function]struct{}{}
+			for _, fn := range root[:i] {
+				removedFuncs[fn] = struct{}{}
+			}
+
+			maxLen := int64(0)
+			if s.maxDepth > 0 {
+				maxLen = s.maxDepth - int64(i)
+				if maxLen <= 0 {
+					continue
+				}
+			}
+
+			spurPath := s.shortest(spur, to, maxLen, removedFuncs, removedEdges)
+			if spurPath == nil {
+				continue
+			}
+
+			candidate := make([]*symbols.Function, 0, len(root)+len(spurPath)-1)
+			candidate = append(candidate, root[:i]...)
+			candidate = append(candidate, spurPath...)
+
+			key := pathKey(candidate)
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			seen[key] = struct{}{}
+
+			candidates = append(candidates, candidate)
+		}
+
+		if len(candidates) == 0 {
+			break
+		}
+
+		sort.SliceStable(candidates, func(i, j int) bool {
+			return len(candidates[i]) < len(candidates[j])
+		})
+
+		paths = append(paths, candidates[0])
+		candidates = candidates[1:]
+	}
+
+	if int64(len(paths)) > k {
+		return paths[:k], true
+	}
+
+	return paths, false
+}
+
+func samePrefix(path, prefix []*symbols.Function) bool {
+	for i := range prefix {
+		if path[i] != prefix[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func pathKey(path []*symbols.Function) string {
+	var key strings.Builder
+	for _, fn := range path {
+		key.WriteString(fn.Name.String())
+		key.WriteByte(' ')
+	}
+	return key.String()
+}
@@ -2,11 +2,16 @@ package representator
 
 import (
 	"encoding/json"
+	"fmt"
+	"strings"
 
+	"github.com/alexeyco/simpletable"
+	"github.com/gookit/color"
 	"github.com/i582/cfmt"
 
 	"github.com/i582/phpstats/internal/stats/metrics"
 	"github.com/i582/phpstats/internal/stats/symbols"
+	"github.com/i582/phpstats/internal/utils"
 )
 
 type ClassData struct {
@@ -14,6 +19,8 @@ type ClassData struct {
 	File string `json:"file"`
 	Type string `json:"type"`
 
+	Owner string `json:"owner,omitempty"`
+
 	Afferent    float64 `json:"aff"`
 	Efferent    float64 `json:"eff"`
 	Instability float64 `json:"instab"`
@@ -26,6 +33,21 @@ type ClassData struct {
 
 	CountFullyTypedMethods int64 `json:"countFullyTypedMethods"`
 
+	CountDuplicatedLines int64 `json:"countDuplicatedLines"`
+	CountMethodsLines    int64 `json:"countMethodsLines"`
+
+	IsTest     bool  `json:"isTest"`
+	CountTests int64 `json:"countTests"`
+
+	UsedTraits       []string `json:"usedTraits"`
+	UsedBy           []string `json:"usedBy"`
+	TraitAdaptations []string `json:"traitAdaptations"`
+
+	Dependencies     []*DependencyData `json:"dependencies"`
+	DependentClasses []*DependencyData `json:"dependentClasses"`
+
+	Lcom4Components []*Lcom4ComponentData `json:"lcom4Components,omitempty"`
+
 	implements *symbols.Classes
 	extends    *symbols.Classes
 
@@ -46,6 +68,8 @@ func ClassToData(c *symbols.Class) *ClassData {
 		tp = "Abstract"
 	} else if c.IsTrait {
 		tp = "Trait"
+	} else if c.IsAnonymous {
+		tp = "Anonymous"
 	} else {
 		tp = "Class"
 	}
@@ -54,11 +78,23 @@ func ClassToData(c *symbols.Class) *ClassData {
 	lcom, _ := metrics.LackOfCohesionInMethods(c)
 	lcom4 := metrics.LackOfCohesionInMethods4(c)
 	countFullyTypedFunctions := c.CountFullyTypedMethods()
+	countDuplicatedLines, countMethodsLines := c.CountDuplicatedLines()
+
+	traitAdaptations := make([]string, 0, len(c.TraitAdaptations))
+	for _, adaptation := range c.TraitAdaptations {
+		traitAdaptations = append(traitAdaptations, adaptation.String())
+	}
+
+	var owner string
+	if c.IsAnonymous {
+		owner = c.OwnerName.String()
+	}
 
 	return &ClassData{
 		Name:        c.Name,
 		File:        c.File.Path,
 		Type:        tp,
+		Owner:       owner,
 		Afferent:    aff,
 		Efferent:    eff,
 		Instability: instab,
@@ -69,6 +105,19 @@ func ClassToData(c *symbols.Class) *ClassData {
 
 		CountFullyTypedMethods: countFullyTypedFunctions,
 
+		CountDuplicatedLines: countDuplicatedLines,
+		CountMethodsLines:    countMethodsLines,
+
+		IsTest:     c.IsTest,
+		CountTests: c.CountTests(),
+
+		UsedTraits:       sortedClassNames(c.Uses),
+		UsedBy:           sortedClassNames(c.UsedBy),
+		TraitAdaptations: traitAdaptations,
+
+		Dependencies:     dependencyEdgesToData(c.DepEdges),
+		DependentClasses: dependentClassesToData(c),
+
 		implements: c.Implements,
 		extends:    c.Extends,
 
@@ -78,6 +127,52 @@ func ClassToData(c *symbols.Class) *ClassData {
 	}
 }
 
+type Lcom4ComponentData struct {
+	Methods []string `json:"methods"`
+	Fields  []string `json:"fields"`
+}
+
+func lcom4ComponentsToData(c *symbols.Class) []*Lcom4ComponentData {
+	components := metrics.LackOfCohesionInMethods4Components(c)
+	data := make([]*Lcom4ComponentData, 0, len(components))
+	methods := c.MethodsWithTraits()
+
+	for _, component := range components {
+		componentData := &Lcom4ComponentData{
+			Methods: make([]string, 0, len(component.Methods)),
+			Fields:  make([]string, 0, len(component.Fields)),
+		}
+
+		for _, method := range component.Methods {
+			// The functions outside the class are shown by full name.
+			if _, ok := methods.Get(method.Name); !ok {
+				componentData.Methods = append(componentData.Methods, method.Name.String())
+				continue
+			}
+			componentData.Methods = append(componentData.Methods, method.Name.Name)
+		}
+		for _, field := range component.Fields {
+			componentData.Fields = append(componentData.Fields, field.Name)
+		}
+
+		data = append(data, componentData)
+	}
+
+	return data
+}
+
+// ClassToDataWithCohesion is the same as ClassToData, but also
+// fills in the connected components of the LCOM4 graph.
+func ClassToDataWithCohesion(c *symbols.Class) *ClassData {
+	data := ClassToData(c)
+	if data == nil {
+		return nil
+	}
+
+	data.Lcom4Components = lcom4ComponentsToData(c)
+	return data
+}
+
 func GetStringClassRepr(c *symbols.Class) string {
 	if c == nil {
 		return ""
@@ -87,6 +182,9 @@ func GetStringClassRepr(c *symbols.Class) string {
 
 	var res string
 
+	if data.Owner != "" {
+		res += cfmt.Sprintf("   {{Anonymous class in}}::green:            {{%s}}::yellow\n", data.Owner)
+	}
 	res += cfmt.Sprintf("   {{Afferent coupling}}::green:             %s\n", ColorOutputFloatZeroableValue(data.Afferent))
 	res += cfmt.Sprintf("   {{Efferent coupling}}::green:             %s\n", ColorOutputFloatZeroableValue(data.Efferent))
 	res += cfmt.Sprintf("   {{Instability}}::green:                   %s\n", ColorOutputFloatZeroableValue(data.Instability))
@@ -95,10 +193,90 @@ func GetStringClassRepr(c *symbols.Class) string {
 	res += cfmt.Sprintf("   {{Count class dependencies}}::green:      %s\n", ColorOutputIntZeroableValue(data.CountDeps))
 	res += cfmt.Sprintf("   {{Count dependent classes}}::green:       %s\n", ColorOutputIntZeroableValue(data.CountDepsBy))
 	res += cfmt.Sprintf("   {{Count fully typed methods}}::green:     %s{{(%d)}}::gray\n", ColorOutputIntZeroableValue(data.CountFullyTypedMethods), data.methods.Len())
+	res += cfmt.Sprintf("   {{Duplicated lines in methods}}::green:   %s %s\n", ColorOutputIntZeroableValue(data.CountDuplicatedLines), ColorOutputFloatZeroablePercentValue(utils.Percent(data.CountDuplicatedLines, data.CountMethodsLines)))
+	if data.IsTest {
+		res += cfmt.Sprintf("   {{Test class}}::green:                    %s\n", ColorOutputBoolZeroableValue(data.IsTest))
+	} else {
+		res += cfmt.Sprintf("   {{Referenced by tests}}::green:           %s\n", ColorOutputIntZeroableValue(data.CountTests))
+	}
+
+	if len(data.UsedTraits) != 0 {
+		res += cfmt.Sprintf("   {{Used traits}}::green:                   %s\n", ColorOutputIntZeroableValue(int64(len(data.UsedTraits))))
+		for _, trait := range data.UsedTraits {
+			res += fmt.Sprintf("      %s\n", trait)
+		}
+		for _, adaptation := range data.TraitAdaptations {
+			res += cfmt.Sprintf("      {{%s}}::gray\n", adaptation)
+		}
+	}
+
+	if c.IsTrait {
+		res += cfmt.Sprintf("   {{Used by classes}}::green:               %s\n", ColorOutputIntZeroableValue(int64(len(data.UsedBy))))
+		for _, class := range data.UsedBy {
+			res += fmt.Sprintf("      %s\n", class)
+		}
+	}
 
 	return res
 }
 
+func GetStringClassCohesionRepr(c *symbols.Class) string {
+	if c == nil {
+		return ""
+	}
+
+	data := ClassToDataWithCohesion(c)
+
+	var res string
+
+	res += cfmt.Sprintf("   {{Connected components}}::green {{(LCOM4 = %d)}}::gray\n", data.Lcom4)
+
+	for index, component := range data.Lcom4Components {
+		res += cfmt.Sprintf("     {{#%d}}::yellow {{(%d methods, %d fields)}}::gray\n", index+1, len(component.Methods), len(component.Fields))
+		res += cfmt.Sprintf("        {{Methods}}::green: %s\n", strings.Join(component.Methods, ", "))
+
+		if len(component.Fields) != 0 {
+			res += cfmt.Sprintf("        {{Fields}}::green:  $%s\n", strings.Join(component.Fields, ", $"))
+		} else {
+			res += cfmt.Sprintf("        {{Fields}}::green:  {{none}}::gray\n")
+		}
+	}
+
+	if len(data.Lcom4Components) > 1 {
+		res += cfmt.Sprintf("\n   {{Each component can be extracted into a separate class,}}::gray\n")
+		res += cfmt.Sprintf("   {{use the 'graph lcom4 %s --split' command to see the suggested split.}}::gray\n", data.Name)
+	}
+
+	return res
+}
+
+// ClassCohesionToTable returns the table of the connected components of the LCOM4 graph.
+func ClassCohesionToTable(c *symbols.Class) *Table {
+	data := ClassToDataWithCohesion(c)
+
+	table := NewTable(
+		TableColumn{Header: "#", Align: simpletable.AlignRight},
+		TableColumn{Header: "Methods", Wrap: true},
+		TableColumn{Header: "Fields", Wrap: true},
+	)
+	table.Title = "Connected components of " + data.Name
+
+	for index, component := range data.Lcom4Components {
+		fields := make([]string, 0, len(component.Fields))
+		for _, field := range component.Fields {
+			fields = append(fields, "$"+field)
+		}
+
+		table.AddRow(
+			color.Gray.Sprint(index+1),
+			strings.Join(component.Methods, ", "),
+			strings.Join(fields, ", "),
+		)
+	}
+
+	return table
+}
+
 func GetJsonClassRepr(c *symbols.Class) (string, error) {
 	data := ClassToData(c)
 
@@ -110,6 +288,17 @@ func GetJsonClassRepr(c *symbols.Class) (string, error) {
 	return string(res), nil
 }
 
+func GetPrettifyJsonClassWithCohesionRepr(c *symbols.Class) (string, error) {
+	data := ClassToDataWithCohesion(c)
+
+	res, err := json.MarshalIndent(data, "", "\t")
+	if err != nil {
+		return "", err
+	}
+
+	return string(res), nil
+}
+
 func GetPrettifyJsonClassesRepr(c []*symbols.Class) (string, error) {
 	data := make([]*ClassData, 0, len(c))
 
@@ -0,0 +1,65 @@
+package representator
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/i582/phpstats/internal/stats/clones"
+)
+
+type CloneFragmentData struct {
+	Path      string `json:"path"`
+	StartLine int32  `json:"startLine"`
+	EndLine   int32  `json:"endLine"`
+}
+
+type CloneGroupData struct {
+	CountTokens int64  `json:"countTokens"`
+	CountLines  int64  `json:"countLines"`
+	Type        string `json:"type"`
+
+	Fragments []*CloneFragmentData `json:"fragments"`
+}
+
+func cloneGroupToData(g *clones.Group) *CloneGroupData {
+	tp := "renamed"
+	if g.Exact {
+		tp = "exact"
+	}
+
+	data := &CloneGroupData{
+		CountTokens: g.CountTokens,
+		CountLines:  g.CountLines(),
+		Type:        tp,
+		Fragments:   make([]*CloneFragmentData, 0, len(g.Fragments)),
+	}
+
+	for _, fragment := range g.Fragments {
+		data.Fragments = append(data.Fragments, &CloneFragmentData{
+			Path:      fragment.File.Path,
+			StartLine: fragment.StartLine,
+			EndLine:   fragment.EndLine,
+		})
+	}
+
+	return data
+}
+
+func (f *CloneFragmentData) String() string {
+	return fmt.Sprintf("%s:%d-%d", f.Path, f.StartLine, f.EndLine)
+}
+
+func GetPrettifyJsonClonesRepr(g []*clones.Group) (string, error) {
+	data := make([]*CloneGroupData, 0, len(g))
+
+	for _, group := range g {
+		data = append(data, cloneGroupToData(group))
+	}
+
+	res, err := json.MarshalIndent(data, "", "\t")
+	if err != nil {
+		return "", err
+	}
+
+	return string(res), nil
+}
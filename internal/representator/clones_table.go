@@ -0,0 +1,49 @@
+package representator
+
+import (
+	"strings"
+
+	"github.com/alexeyco/simpletable"
+	"github.com/gookit/color"
+
+	"github.com/i582/phpstats/internal/stats/clones"
+)
+
+func GetTableClonesRepr(g []*clones.Group, offset int64) string {
+	if g == nil {
+		return ""
+	}
+
+	return ClonesToTable(g, offset).String()
+}
+
+func ClonesToTable(g []*clones.Group, offset int64) *Table {
+	table := NewTable(
+		TableColumn{Header: "#", Align: simpletable.AlignRight},
+		TableColumn{Header: "Tokens", Align: simpletable.AlignRight},
+		TableColumn{Header: "Lines", Align: simpletable.AlignRight},
+		TableColumn{Header: "Type"},
+		TableColumn{Header: "Frag\nments", Name: "Fragments", Align: simpletable.AlignRight},
+		TableColumn{Header: "Locations"},
+	)
+
+	for index, group := range g {
+		data := cloneGroupToData(group)
+
+		locations := make([]string, 0, len(data.Fragments))
+		for _, fragment := range data.Fragments {
+			locations = append(locations, fragment.String())
+		}
+
+		table.AddRow(
+			color.Gray.Sprint(int64(index+1)+offset),
+			ColorOutputIntZeroableValue(data.CountTokens),
+			ColorOutputIntZeroableValue(data.CountLines),
+			data.Type,
+			ColorOutputIntZeroableValue(int64(len(data.Fragments))),
+			strings.Join(locations, "\n"),
+		)
+	}
+
+	return table
+}
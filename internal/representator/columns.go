@@ -0,0 +1,274 @@
+package representator
+
+import (
+	"strings"
+
+	"github.com/i582/phpstats/internal/filter"
+	"github.com/i582/phpstats/internal/stats/symbols"
+)
+
+// Column is a column of the list that can be used in the
+// --where expressions and for sorting by name.
+type Column struct {
+	Name    string
+	Aliases []string
+	Type    filter.Type
+
+	// Sort is the number of the column for sorting, 0 if sorting by the column is not supported.
+	Sort int64
+}
+
+type Columns []Column
+
+// Find returns the column by its name or alias, case-insensitive.
+func (c Columns) Find(name string) (Column, bool) {
+	for _, column := range c {
+		if strings.EqualFold(column.Name, name) {
+			return column, true
+		}
+		for _, alias := range column.Aliases {
+			if strings.EqualFold(alias, name) {
+				return column, true
+			}
+		}
+	}
+	return Column{}, false
+}
+
+// Resolve implements filter.Resolver.
+func (c Columns) Resolve(name string) (string, filter.Type, bool) {
+	column, ok := c.Find(name)
+	return column.Name, column.Type, ok
+}
+
+// Names returns the comma-separated names of the columns.
+func (c Columns) Names() string {
+	names := make([]string, 0, len(c))
+	for _, column := range c {
+		names = append(names, column.Name)
+	}
+	return strings.Join(names, ", ")
+}
+
+var ClassColumns = Columns{
+	{Name: "name", Type: filter.String, Sort: 1},
+	{Name: "aff", Aliases: []string{"afferent"}, Type: filter.Number, Sort: 2},
+	{Name: "eff", Aliases: []string{"efferent"}, Type: filter.Number, Sort: 3},
+	{Name: "instab", Aliases: []string{"instability"}, Type: filter.Number, Sort: 4},
+	{Name: "lcom", Type: filter.Number, Sort: 5},
+	{Name: "lcom4", Type: filter.Number, Sort: 6},
+	{Name: "deps", Aliases: []string{"countDeps"}, Type: filter.Number, Sort: 7},
+	{Name: "depsBy", Aliases: []string{"countDepsBy"}, Type: filter.Number, Sort: 8},
+	{Name: "typed", Aliases: []string{"countFullyTypedMethods"}, Type: filter.Number, Sort: 9},
+	{Name: "tests", Aliases: []string{"countTests"}, Type: filter.Number, Sort: 10},
+	{Name: "methods", Type: filter.Number},
+	{Name: "dupl", Aliases: []string{"countDuplicatedLines"}, Type: filter.Number},
+	{Name: "namespace", Aliases: []string{"ns"}, Type: filter.String},
+	{Name: "path", Aliases: []string{"file"}, Type: filter.String},
+	{Name: "type", Type: filter.String},
+	{Name: "isTest", Type: filter.Bool},
+}
+
+var FunctionColumns = Columns{
+	{Name: "name", Type: filter.String, Sort: 1},
+	{Name: "uses", Aliases: []string{"usesCount"}, Type: filter.Number, Sort: 2},
+	{Name: "deps", Aliases: []string{"countDeps"}, Type: filter.Number, Sort: 3},
+	{Name: "depsBy", Aliases: []string{"countDepsBy"}, Type: filter.Number, Sort: 4},
+	{Name: "called", Aliases: []string{"countCalled"}, Type: filter.Number, Sort: 5},
+	{Name: "calledBy", Aliases: []string{"countCalledBy"}, Type: filter.Number, Sort: 6},
+	{Name: "cc", Aliases: []string{"cyclo", "cyclomaticComplexity"}, Type: filter.Number, Sort: 7},
+	{Name: "magic", Aliases: []string{"countMagicNumbers"}, Type: filter.Number, Sort: 8},
+	{Name: "tests", Aliases: []string{"countTests"}, Type: filter.Number, Sort: 9},
+	{Name: "coverage", Aliases: []string{"cover"}, Type: filter.Number, Sort: 10},
+	{Name: "crap", Type: filter.Number, Sort: 11},
+	{Name: "namespace", Aliases: []string{"ns"}, Type: filter.String},
+	{Name: "class", Aliases: []string{"className"}, Type: filter.String},
+	{Name: "path", Aliases: []string{"file"}, Type: filter.String},
+	{Name: "type", Type: filter.String},
+	{Name: "fullyTyped", Type: filter.Bool},
+	{Name: "isTest", Type: filter.Bool},
+	{Name: "pure", Type: filter.Bool},
+}
+
+var FileColumns = Columns{
+	{Name: "name", Type: filter.String, Sort: 1},
+	{Name: "root", Aliases: []string{"countRequiredInRoot"}, Type: filter.Number, Sort: 2},
+	{Name: "block", Aliases: []string{"countRequiredInBlock"}, Type: filter.Number, Sort: 3},
+	{Name: "requiredBy", Aliases: []string{"countRequiredBy"}, Type: filter.Number, Sort: 4},
+	{Name: "lines", Aliases: []string{"countLines"}, Type: filter.Number},
+	{Name: "dupl", Aliases: []string{"countDuplicatedLines"}, Type: filter.Number},
+	{Name: "path", Type: filter.String},
+}
+
+var NamespaceColumns = Columns{
+	{Name: "name", Type: filter.String, Sort: 1},
+	{Name: "files", Type: filter.Number, Sort: 2},
+	{Name: "classes", Aliases: []string{"allClasses"}, Type: filter.Number, Sort: 3},
+	{Name: "ownClasses", Type: filter.Number, Sort: 4},
+	{Name: "aff", Aliases: []string{"afferent"}, Type: filter.Number, Sort: 5},
+	{Name: "eff", Aliases: []string{"efferent"}, Type: filter.Number, Sort: 6},
+	{Name: "instab", Aliases: []string{"instability"}, Type: filter.Number, Sort: 7},
+	{Name: "abstract", Aliases: []string{"abstractness"}, Type: filter.Number, Sort: 8},
+	{Name: "childs", Type: filter.Number, Sort: 9},
+	{Name: "namespace", Aliases: []string{"ns", "fullName"}, Type: filter.String},
+}
+
+func (d *ClassData) Value(column string) interface{} {
+	switch column {
+	case "name":
+		return d.Name
+	case "aff":
+		return d.Afferent
+	case "eff":
+		return d.Efferent
+	case "instab":
+		return d.Instability
+	case "lcom":
+		return d.Lcom
+	case "lcom4":
+		return d.Lcom4
+	case "deps":
+		return d.CountDeps
+	case "depsBy":
+		return d.CountDepsBy
+	case "typed":
+		return d.CountFullyTypedMethods
+	case "tests":
+		return d.CountTests
+	case "methods":
+		return int64(d.methods.Len())
+	case "dupl":
+		return d.CountDuplicatedLines
+	case "namespace":
+		return NamespaceOfName(d.Name)
+	case "path":
+		return d.File
+	case "type":
+		return d.Type
+	case "isTest":
+		return d.IsTest
+	}
+	return nil
+}
+
+func (d *FunctionData) Value(column string) interface{} {
+	switch column {
+	case "name":
+		return d.Name
+	case "uses":
+		return d.UsesCount
+	case "deps":
+		return d.CountDeps
+	case "depsBy":
+		return d.CountDepsBy
+	case "called":
+		return d.CountCalled
+	case "calledBy":
+		return d.CountCalledBy
+	case "cc":
+		return d.CyclomaticComplexity
+	case "magic":
+		return d.CountMagicNumbers
+	case "tests":
+		return d.CountTests
+	case "coverage":
+		return d.Coverage
+	case "crap":
+		return d.Crap
+	case "namespace":
+		if d.Class != "" {
+			return NamespaceOfName(d.Class)
+		}
+		return NamespaceOfName(d.Name)
+	case "class":
+		return d.Class
+	case "path":
+		return d.path
+	case "type":
+		return d.Type
+	case "fullyTyped":
+		return d.FullyTyped
+	case "isTest":
+		return d.IsTest
+	case "pure":
+		return len(d.Effects) == 0
+	}
+	return nil
+}
+
+func (d *FileData) Value(column string) interface{} {
+	switch column {
+	case "name":
+		return d.Name
+	case "root":
+		return d.CountRequiredRoot
+	case "block":
+		return d.CountRequiredBlock
+	case "requiredBy":
+		return d.CountRequiredBy
+	case "lines":
+		return d.CountLines
+	case "dupl":
+		return d.CountDuplicatedLines
+	case "path":
+		return d.Path
+	}
+	return nil
+}
+
+func (d *NamespaceData) Value(column string) interface{} {
+	switch column {
+	case "name":
+		return d.Name
+	case "files":
+		return d.Files
+	case "classes":
+		return d.Classes
+	case "ownClasses":
+		return d.OwnClasses
+	case "aff":
+		return d.Afferent
+	case "eff":
+		return d.Efferent
+	case "instab":
+		return d.Instability
+	case "abstract":
+		return d.Abstractness
+	case "childs":
+		return d.Childs
+	case "namespace":
+		return d.FullName
+	}
+	return nil
+}
+
+// FunctionRow returns the function data used to check the --where expression.
+func FunctionRow(f *symbols.Function) filter.Row {
+	return funcToData(f)
+}
+
+// FileRow returns the file data used to check the --where expression.
+func FileRow(f *symbols.File) filter.Row {
+	return fileToData(f)
+}
+
+// NamespaceOfName returns the namespace of the class or function
+// with the passed full name, for example, \App for \App\Foo.
+func NamespaceOfName(name string) string {
+	index := strings.LastIndex(name, `\`)
+	if index <= 0 {
+		return `\`
+	}
+	return name[:index]
+}
+
+// InNamespace checks if the namespace equals to the passed one or is nested in it.
+func InNamespace(namespace, parent string) bool {
+	namespace = strings.ToLower(strings.Trim(namespace, `\`))
+	parent = strings.ToLower(strings.Trim(parent, `\`))
+
+	if parent == "" {
+		return true
+	}
+	return namespace == parent || strings.HasPrefix(namespace, parent+`\`)
+}
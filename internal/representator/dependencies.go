@@ -0,0 +1,120 @@
+package representator
+
+import (
+	"sort"
+
+	"github.com/alexeyco/simpletable"
+	"github.com/i582/cfmt"
+
+	"github.com/i582/phpstats/internal/stats/symbols"
+)
+
+type DependencyData struct {
+	Name  string           `json:"name"`
+	Count int64            `json:"count"`
+	Kinds map[string]int64 `json:"kinds"`
+	Sites []string         `json:"sites,omitempty"`
+
+	dep *symbols.Dependency
+}
+
+func dependencyToData(name string, dep *symbols.Dependency) *DependencyData {
+	data := &DependencyData{
+		Name:  name,
+		Count: dep.Count(),
+		Kinds: dep.CountsByKind(),
+		dep:   dep,
+	}
+
+	for _, site := range dep.SortedSites() {
+		data.Sites = append(data.Sites, site.String())
+	}
+
+	return data
+}
+
+func dependencyEdgesToData(edges *symbols.DependencyEdges) []*DependencyData {
+	names := edges.Names()
+	data := make([]*DependencyData, 0, len(names))
+
+	for _, name := range names {
+		dep, _ := edges.Get(name)
+		data = append(data, dependencyToData(name, dep))
+	}
+
+	return data
+}
+
+// dependentClassesToData returns the dependencies of other classes on the passed class.
+func dependentClassesToData(c *symbols.Class) []*DependencyData {
+	data := make([]*DependencyData, 0, c.DepsBy.Len())
+
+	for _, class := range c.DepsBy.Classes {
+		dep, ok := class.DepEdges.Get(c.Name)
+		if !ok {
+			continue
+		}
+
+		data = append(data, dependencyToData(class.Name, dep))
+	}
+
+	sort.Slice(data, func(i, j int) bool {
+		if data[i].Count == data[j].Count {
+			return data[i].Name < data[j].Name
+		}
+		return data[i].Count > data[j].Count
+	})
+
+	return data
+}
+
+func GetStringClassDependenciesRepr(c *symbols.Class) string {
+	if c == nil {
+		return ""
+	}
+
+	data := ClassToData(c)
+
+	var res string
+
+	res += cfmt.Sprintf("   {{Dependencies}}::green:                  %s\n", ColorOutputIntZeroableValue(int64(len(data.Dependencies))))
+	res += stringDependencies(data.Dependencies)
+
+	res += cfmt.Sprintf("   {{Dependent classes}}::green:             %s\n", ColorOutputIntZeroableValue(int64(len(data.DependentClasses))))
+	res += stringDependencies(data.DependentClasses)
+
+	return res
+}
+
+func stringDependencies(deps []*DependencyData) string {
+	var res string
+
+	for _, dep := range deps {
+		res += cfmt.Sprintf("      %s {{%d}}::yellow {{(%s)}}::gray\n", dep.Name, dep.Count, dep.dep)
+	}
+
+	return res
+}
+
+// ClassDependenciesToTable returns the table of the dependencies of
+// the class and the classes that depend on it.
+func ClassDependenciesToTable(c *symbols.Class) *Table {
+	data := ClassToData(c)
+
+	table := NewTable(
+		TableColumn{Header: "Name", Wrap: true},
+		TableColumn{Header: "Direction"},
+		TableColumn{Header: "Count", Align: simpletable.AlignRight},
+		TableColumn{Header: "Kinds", Wrap: true},
+	)
+	table.Title = "Dependencies of " + data.Name
+
+	for _, dep := range data.Dependencies {
+		table.AddRow(dep.Name, "depends on", ColorOutputIntZeroableValue(dep.Count), dep.dep.String())
+	}
+	for _, dep := range data.DependentClasses {
+		table.AddRow(dep.Name, "dependent", ColorOutputIntZeroableValue(dep.Count), dep.dep.String())
+	}
+
+	return table
+}
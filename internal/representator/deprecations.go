@@ -0,0 +1,131 @@
+package representator
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/i582/cfmt"
+
+	"github.com/i582/phpstats/internal/stats/deprecations"
+	"github.com/i582/phpstats/internal/stats/symbols"
+)
+
+type DeprecationUserData struct {
+	Name      string   `json:"name"`
+	Kind      string   `json:"kind"`
+	Distance  int      `json:"distance"`
+	Via       string   `json:"via"`
+	Locations []string `json:"locations"`
+}
+
+type DeprecationData struct {
+	Name     string                 `json:"name"`
+	Kind     string                 `json:"kind"`
+	Message  string                 `json:"message"`
+	Location string                 `json:"location"`
+	IsVendor bool                   `json:"isVendor"`
+	Users    []*DeprecationUserData `json:"users"`
+}
+
+func DeprecationToData(d *deprecations.Deprecation) *DeprecationData {
+	if d == nil {
+		return nil
+	}
+
+	data := &DeprecationData{
+		Name:     d.Name,
+		Kind:     d.Kind,
+		Message:  d.Message,
+		Location: locationString(d.Pos),
+		IsVendor: d.IsVendor,
+		Users:    make([]*DeprecationUserData, 0, len(d.Users)),
+	}
+
+	for _, user := range d.Users {
+		userData := &DeprecationUserData{
+			Name:     user.Name(),
+			Kind:     "class",
+			Distance: user.Distance,
+			Via:      user.Via,
+		}
+
+		if user.Func != nil {
+			userData.Kind = "function"
+			if user.Func.IsMethod() {
+				userData.Kind = "method"
+			}
+		}
+
+		for _, site := range user.Sites {
+			userData.Locations = append(userData.Locations, site.String())
+		}
+
+		data.Users = append(data.Users, userData)
+	}
+
+	return data
+}
+
+// locationString returns the file and the line, if the line is unknown, only the file.
+func locationString(l symbols.Location) string {
+	if l.Line == 0 {
+		return l.File
+	}
+	return l.String()
+}
+
+func GetStringDeprecationRepr(d *deprecations.Deprecation) string {
+	if d == nil {
+		return ""
+	}
+
+	data := DeprecationToData(d)
+
+	var res string
+
+	res += cfmt.Sprintf("{{%s}}::yellow {{(%s)}}::gray", data.Name, data.Kind)
+	if data.IsVendor {
+		res += cfmt.Sprintf(" {{vendor}}::red")
+	}
+	res += "\n"
+
+	if data.Location != "" {
+		res += cfmt.Sprintf("  {{Declared in}}::green: %s\n", data.Location)
+	}
+	if data.Message != "" {
+		res += cfmt.Sprintf("  {{Message}}::green: %s\n", data.Message)
+	}
+
+	res += cfmt.Sprintf("  {{Users}}::green: %d {{(%d direct)}}::gray\n", len(data.Users), d.CountDirectUsers())
+	for _, user := range data.Users {
+		indent := strings.Repeat("  ", user.Distance)
+
+		if user.Distance == 1 {
+			res += cfmt.Sprintf("   %s%s", indent, user.Name)
+		} else {
+			res += cfmt.Sprintf("   %s%s {{via %s}}::gray", indent, user.Name, user.Via)
+		}
+
+		if len(user.Locations) != 0 {
+			res += cfmt.Sprintf(" {{(%s)}}::gray", strings.Join(user.Locations, ", "))
+		}
+		res += "\n"
+	}
+
+	return res
+}
+
+func GetPrettifyJsonDeprecationsRepr(d []*deprecations.Deprecation) (string, error) {
+	data := make([]*DeprecationData, 0, len(d))
+
+	for _, deprecation := range d {
+		data = append(data, DeprecationToData(deprecation))
+	}
+
+	res, err := json.MarshalIndent(data, "", "\t")
+	if err != nil {
+		return "", err
+	}
+
+	return string(res), nil
+}
@@ -0,0 +1,244 @@
+package representator
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/alexeyco/simpletable"
+	"github.com/gookit/color"
+	"github.com/i582/cfmt"
+
+	"github.com/i582/phpstats/internal/stats/symbols"
+)
+
+type ExceptionSiteData struct {
+	Exception string `json:"exception"`
+	Function  string `json:"function"`
+	File      string `json:"file"`
+	Line      int32  `json:"line"`
+}
+
+func (s *ExceptionSiteData) String() string {
+	return fmt.Sprintf("%s:%d", s.File, s.Line)
+}
+
+type ExceptionData struct {
+	Name string `json:"name"`
+
+	ThrowSites []*ExceptionSiteData `json:"throwSites"`
+	CatchSites []*ExceptionSiteData `json:"catchSites"`
+
+	UncaughtAtEntryPoints []string `json:"uncaughtAtEntryPoints"`
+}
+
+type FunctionExceptionsData struct {
+	Throws           []*ExceptionSiteData `json:"throws"`
+	ThrowsTransitive []string             `json:"throwsTransitive"`
+	Catches          []*ExceptionSiteData `json:"catches"`
+}
+
+func exceptionSitesToData(s *symbols.ExceptionSites) []*ExceptionSiteData {
+	data := make([]*ExceptionSiteData, 0, s.Len())
+
+	for _, site := range s.Sites {
+		data = append(data, &ExceptionSiteData{
+			Exception: site.Exception.Name,
+			Function:  site.Func.Name.String(),
+			File:      site.Func.Pos.Filename,
+			Line:      site.Line,
+		})
+	}
+
+	sort.Slice(data, func(i, j int) bool {
+		if data[i].File != data[j].File {
+			return data[i].File < data[j].File
+		}
+		if data[i].Line != data[j].Line {
+			return data[i].Line < data[j].Line
+		}
+		return data[i].Exception < data[j].Exception
+	})
+
+	return data
+}
+
+func sortedClassNames(c *symbols.Classes) []string {
+	names := make([]string, 0, c.Len())
+	for _, class := range c.Classes {
+		names = append(names, class.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func sortedFunctionNames(f *symbols.Functions) []string {
+	names := make([]string, 0, f.Len())
+	for _, fn := range f.Funcs {
+		names = append(names, fn.Name.String())
+	}
+	sort.Strings(names)
+	return names
+}
+
+func ExceptionToData(c *symbols.Class) *ExceptionData {
+	if c == nil {
+		return nil
+	}
+
+	return &ExceptionData{
+		Name:                  c.Name,
+		ThrowSites:            exceptionSitesToData(c.ThrownIn),
+		CatchSites:            exceptionSitesToData(c.CaughtIn),
+		UncaughtAtEntryPoints: sortedFunctionNames(c.UncaughtIn),
+	}
+}
+
+func FunctionExceptionsToData(f *symbols.Function) *FunctionExceptionsData {
+	if f == nil {
+		return nil
+	}
+
+	return &FunctionExceptionsData{
+		Throws:           exceptionSitesToData(f.Throws),
+		ThrowsTransitive: sortedClassNames(f.ThrowsTransitive),
+		Catches:          exceptionSitesToData(f.Catches),
+	}
+}
+
+func GetStringFunctionExceptionsRepr(f *symbols.Function) string {
+	if f == nil {
+		return ""
+	}
+
+	data := FunctionExceptionsToData(f)
+
+	var res string
+
+	res += cfmt.Sprintf("  {{Throws}}::green:\n")
+	if len(data.Throws) == 0 {
+		res += cfmt.Sprintf("     {{none}}::gray\n")
+	}
+	for _, site := range data.Throws {
+		res += cfmt.Sprintf("     %s {{(line %d)}}::gray\n", site.Exception, site.Line)
+	}
+
+	res += cfmt.Sprintf("  {{Can throw out}}::green {{(including called functions)}}::gray:\n")
+	if len(data.ThrowsTransitive) == 0 {
+		res += cfmt.Sprintf("     {{none}}::gray\n")
+	}
+	for _, name := range data.ThrowsTransitive {
+		res += fmt.Sprintf("     %s\n", name)
+	}
+
+	res += cfmt.Sprintf("  {{Catches}}::green:\n")
+	if len(data.Catches) == 0 {
+		res += cfmt.Sprintf("     {{none}}::gray\n")
+	}
+	for _, site := range data.Catches {
+		res += cfmt.Sprintf("     %s {{(line %d)}}::gray\n", site.Exception, site.Line)
+	}
+
+	return res
+}
+
+// FunctionExceptionsToTable returns the table of the exceptions
+// thrown, thrown out and caught by the function.
+func FunctionExceptionsToTable(f *symbols.Function) *Table {
+	data := FunctionExceptionsToData(f)
+
+	table := NewTable(
+		TableColumn{Header: "Exception", Wrap: true},
+		TableColumn{Header: "Kind"},
+		TableColumn{Header: "Line", Align: simpletable.AlignRight},
+	)
+	table.Title = "Exceptions of " + f.Name.String()
+
+	for _, site := range data.Throws {
+		table.AddRow(site.Exception, "throws", fmt.Sprint(site.Line))
+	}
+	for _, name := range data.ThrowsTransitive {
+		table.AddRow(name, "can throw out", "")
+	}
+	for _, site := range data.Catches {
+		table.AddRow(site.Exception, "catches", fmt.Sprint(site.Line))
+	}
+
+	return table
+}
+
+func GetTableExceptionsRepr(e []*symbols.Class, offset int64) string {
+	if e == nil {
+		return ""
+	}
+
+	return ExceptionsToTable(e, offset).String()
+}
+
+func ExceptionsToTable(e []*symbols.Class, offset int64) *Table {
+	table := NewTable(
+		TableColumn{Header: "#", Align: simpletable.AlignRight},
+		TableColumn{Header: "Name", Wrap: true},
+		TableColumn{Header: "Throw\nsites", Align: simpletable.AlignRight},
+		TableColumn{Header: "Catch\nsites", Align: simpletable.AlignRight},
+		TableColumn{Header: "Uncaught at\nentry points", Align: simpletable.AlignRight},
+	)
+
+	for index, exception := range e {
+		data := ExceptionToData(exception)
+
+		table.AddRow(
+			color.Gray.Sprint(int64(index+1)+offset),
+			data.Name,
+			ColorOutputIntZeroableValue(int64(len(data.ThrowSites))),
+			ColorOutputIntZeroableValue(int64(len(data.CatchSites))),
+			ColorOutputIntZeroableValue(int64(len(data.UncaughtAtEntryPoints))),
+		)
+	}
+
+	return table
+}
+
+func GetStringExceptionSitesRepr(e *symbols.Class) string {
+	if e == nil {
+		return ""
+	}
+
+	data := ExceptionToData(e)
+
+	var res string
+
+	res += cfmt.Sprintf("{{%s}}::yellow\n", data.Name)
+
+	res += cfmt.Sprintf("  {{Throw sites}}::green:\n")
+	for _, site := range data.ThrowSites {
+		res += cfmt.Sprintf("     %s {{%s}}::gray\n", site.Function, site.String())
+	}
+
+	res += cfmt.Sprintf("  {{Catch sites}}::green:\n")
+	for _, site := range data.CatchSites {
+		res += cfmt.Sprintf("     %s {{%s}}::gray\n", site.Function, site.String())
+	}
+
+	res += cfmt.Sprintf("  {{Uncaught at entry points}}::green:\n")
+	for _, name := range data.UncaughtAtEntryPoints {
+		res += fmt.Sprintf("     %s\n", name)
+	}
+
+	return res
+}
+
+func GetPrettifyJsonExceptionsRepr(e []*symbols.Class) (string, error) {
+	data := make([]*ExceptionData, 0, len(e))
+
+	for _, exception := range e {
+		data = append(data, ExceptionToData(exception))
+	}
+
+	res, err := json.MarshalIndent(data, "", "\t")
+	if err != nil {
+		return "", err
+	}
+
+	return string(res), nil
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/i582/cfmt"
 
 	"github.com/i582/phpstats/internal/stats/symbols"
+	"github.com/i582/phpstats/internal/utils"
 )
 
 type FileData struct {
@@ -18,6 +19,9 @@ type FileData struct {
 	CountRequiredBlock int64 `json:"countRequiredInBlock"`
 	CountRequiredBy    int64 `json:"countRequiredBy"`
 
+	CountLines           int64 `json:"countLines"`
+	CountDuplicatedLines int64 `json:"countDuplicatedLines"`
+
 	requiredRoot  *symbols.Files
 	requiredBlock *symbols.Files
 	requiredBy    *symbols.Files
@@ -32,6 +36,9 @@ func fileToData(f *symbols.File) *FileData {
 		CountRequiredRoot:  int64(f.RequiredRoot.Len()),
 		CountRequiredBy:    int64(f.RequiredBy.Len()),
 
+		CountLines:           f.CountLines,
+		CountDuplicatedLines: f.CountDuplicatedLines(),
+
 		requiredBlock: f.RequiredBlock,
 		requiredRoot:  f.RequiredRoot,
 		requiredBy:    f.RequiredBy,
@@ -62,6 +69,8 @@ func GetStringFileRepr(f *symbols.File) string {
 	res += cfmt.Sprintf("  {{Include files at the root}}::green:      %s\n", ColorOutputIntZeroableValue(data.CountRequiredRoot))
 	res += cfmt.Sprintf("  {{Include files in the functions}}::green: %s\n", ColorOutputIntZeroableValue(data.CountRequiredBlock))
 	res += cfmt.Sprintf("  {{Count of required}}::green:              %s\n", ColorOutputIntZeroableValue(data.CountRequiredBy))
+	res += cfmt.Sprintf("  {{Lines of code}}::green:                  %s\n", ColorOutputIntZeroableValue(data.CountLines))
+	res += cfmt.Sprintf("  {{Duplicated lines}}::green:               %s %s\n", ColorOutputIntZeroableValue(data.CountDuplicatedLines), ColorOutputFloatZeroablePercentValue(utils.Percent(data.CountDuplicatedLines, data.CountLines)))
 
 	return res
 }
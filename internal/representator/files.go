@@ -3,9 +3,9 @@ package representator
 import (
 	"github.com/alexeyco/simpletable"
 	"github.com/gookit/color"
-	"github.com/i582/cfmt"
 
 	"github.com/i582/phpstats/internal/stats/symbols"
+	"github.com/i582/phpstats/internal/utils"
 )
 
 func GetTableFilesRepr(f []*symbols.File, offset int64) string {
@@ -13,34 +13,31 @@ func GetTableFilesRepr(f []*symbols.File, offset int64) string {
 		return ""
 	}
 
-	table := simpletable.New()
-	table.SetStyle(simpletable.StyleCompactLite)
-	table.Header = &simpletable.Header{
-		Cells: []*simpletable.Cell{
-			{Align: simpletable.AlignCenter, Text: color.Green.Sprint("#")},
-			{Align: simpletable.AlignCenter, Text: color.Green.Sprint("Name")},
-			{Align: simpletable.AlignCenter, Text: cfmt.Sprint("{{Root}}::green\n{{inclusions}}::green")},
-			{Align: simpletable.AlignCenter, Text: cfmt.Sprint("{{Block}}::green\n{{inclusions}}::green")},
-			{Align: simpletable.AlignCenter, Text: cfmt.Sprint("{{Count}}::green\n{{required by}}::green")},
-		},
-	}
+	return FilesToTable(f, offset).String()
+}
+
+func FilesToTable(f []*symbols.File, offset int64) *Table {
+	table := NewTable(
+		TableColumn{Header: "#", Align: simpletable.AlignRight},
+		TableColumn{Header: "Name", Wrap: true},
+		TableColumn{Header: "Root\ninclusions", Align: simpletable.AlignRight},
+		TableColumn{Header: "Block\ninclusions", Align: simpletable.AlignRight},
+		TableColumn{Header: "Count\nrequired by", Align: simpletable.AlignRight},
+		TableColumn{Header: "Dupl\nlines", Align: simpletable.AlignRight},
+	)
 
 	for index, file := range f {
 		data := fileToData(file)
 
-		name := data.Name
-		name = splitText(name)
-
-		r := []*simpletable.Cell{
-			{Align: simpletable.AlignRight, Text: color.Gray.Sprint(int64(index+1) + offset)},
-			{Text: name},
-			{Align: simpletable.AlignRight, Text: ColorOutputIntZeroableValue(data.CountRequiredRoot)},
-			{Align: simpletable.AlignRight, Text: ColorOutputIntZeroableValue(data.CountRequiredBlock)},
-			{Align: simpletable.AlignRight, Text: ColorOutputIntZeroableValue(data.CountRequiredBy)},
-		}
-
-		table.Body.Cells = append(table.Body.Cells, r)
+		table.AddRow(
+			color.Gray.Sprint(int64(index+1)+offset),
+			data.Name,
+			ColorOutputIntZeroableValue(data.CountRequiredRoot),
+			ColorOutputIntZeroableValue(data.CountRequiredBlock),
+			ColorOutputIntZeroableValue(data.CountRequiredBy),
+			ColorOutputIntZeroableValue(data.CountDuplicatedLines)+" "+ColorOutputFloatZeroablePercentValue(utils.Percent(data.CountDuplicatedLines, data.CountLines)),
+		)
 	}
 
-	return table.String()
+	return table
 }
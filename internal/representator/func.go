@@ -3,6 +3,8 @@ package representator
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/i582/cfmt"
 
@@ -15,6 +17,18 @@ type FunctionData struct {
 
 	Class string `json:"className"`
 
+	AnonymousKind string   `json:"anonymousKind,omitempty"`
+	Owner         string   `json:"owner,omitempty"`
+	Anonymous     []string `json:"anonymous,omitempty"`
+
+	Calls []*DependencyData `json:"calls"`
+
+	ReadsGlobals  []string `json:"readsGlobals"`
+	WritesGlobals []string `json:"writesGlobals"`
+
+	Effects       []string `json:"effects"`
+	DirectEffects []string `json:"directEffects"`
+
 	UsesCount int64 `json:"usesCount"`
 
 	CountCalled   int64 `json:"countCalled"`
@@ -27,6 +41,17 @@ type FunctionData struct {
 	CountMagicNumbers    int64 `json:"countMagicNumbers"`
 
 	FullyTyped bool `json:"fullyTyped"`
+
+	IsTest     bool  `json:"isTest"`
+	CountTests int64 `json:"countTests"`
+
+	HasCoverage            bool    `json:"hasCoverage"`
+	CountStatements        int64   `json:"countStatements,omitempty"`
+	CountCoveredStatements int64   `json:"countCoveredStatements,omitempty"`
+	Coverage               float64 `json:"coverage,omitempty"`
+	Crap                   float64 `json:"crap,omitempty"`
+
+	path string
 }
 
 func funcToData(f *symbols.Function) *FunctionData {
@@ -35,13 +60,18 @@ func funcToData(f *symbols.Function) *FunctionData {
 	}
 
 	var tp string
-	if f.Name.IsMethod() {
+	switch {
+	case f.AnonymousKind == symbols.AnonymousClosure:
+		tp = "Closure"
+	case f.AnonymousKind == symbols.AnonymousArrowFunction:
+		tp = "Arrow function"
+	case f.Name.IsMethod():
 		tp = "Method"
-	} else {
+	default:
 		tp = "Function"
 	}
 
-	return &FunctionData{
+	data := &FunctionData{
 		Name:                 f.Name.String(),
 		Type:                 tp,
 		Class:                f.Name.ClassName,
@@ -53,7 +83,35 @@ func funcToData(f *symbols.Function) *FunctionData {
 		CyclomaticComplexity: f.CyclomaticComplexity,
 		CountMagicNumbers:    f.CountMagicNumbers,
 		FullyTyped:           f.FullyTyped,
+		IsTest:               f.IsTest(),
+		CountTests:           f.CountTests(),
+		HasCoverage:          f.HasCoverage,
+		AnonymousKind:        f.AnonymousKind,
+		Calls:                dependencyEdgesToData(f.CallEdges),
+		ReadsGlobals:         sortedGlobalNames(f.ReadsGlobals),
+		WritesGlobals:        sortedGlobalNames(f.WritesGlobals),
+		Effects:              f.Effects.Sorted(),
+		DirectEffects:        f.DirectEffects.Sorted(),
+		path:                 f.Pos.Filename,
+	}
+
+	if f.IsAnonymous() {
+		data.Owner = f.OwnerName.String()
+	}
+
+	for _, anonymous := range f.Anonymous.Funcs {
+		data.Anonymous = append(data.Anonymous, anonymous.Name.String())
 	}
+	sort.Strings(data.Anonymous)
+
+	if f.HasCoverage {
+		data.CountStatements = f.CountStatements
+		data.CountCoveredStatements = f.CountCoveredStatements
+		data.Coverage = f.Coverage()
+		data.Crap = f.Crap()
+	}
+
+	return data
 }
 
 func GetShortStringFunctionRepr(f *symbols.Function) string {
@@ -78,6 +136,9 @@ func GetStringFunctionRepr(f *symbols.Function) string {
 	if data.Class != "" {
 		res += cfmt.Sprintf("  {{Class}}::green:                 %s\n", data.Class)
 	}
+	if data.AnonymousKind != "" {
+		res += cfmt.Sprintf("  {{Anonymous}}::green:             %s in {{%s}}::yellow\n", data.AnonymousKind, data.Owner)
+	}
 	res += cfmt.Sprintf("  {{Number of uses}}::green:        %s\n", ColorOutputIntZeroableValue(data.UsesCount))
 	res += cfmt.Sprintf("  {{Depends of classes}}::green:    %s\n", ColorOutputIntZeroableValue(data.CountDeps))
 	res += cfmt.Sprintf("  {{Classes depends}}::green:       %s\n", ColorOutputIntZeroableValue(data.CountDepsBy))
@@ -86,6 +147,36 @@ func GetStringFunctionRepr(f *symbols.Function) string {
 	res += cfmt.Sprintf("  {{Cyclomatic complexity}}::green: %s {{(>15 hard to understand, >30 extremely complex)}}::gray\n", ColorOutputIntZeroableValue(data.CyclomaticComplexity))
 	res += cfmt.Sprintf("  {{Count magic numbers}}::green:   %s\n", ColorOutputIntZeroableValue(data.CountMagicNumbers))
 	res += cfmt.Sprintf("  {{Fully typed}}::green:           %s\n", ColorOutputBoolZeroableValue(data.FullyTyped))
+	if data.IsTest {
+		res += cfmt.Sprintf("  {{Test}}::green:                  %s\n", ColorOutputBoolZeroableValue(data.IsTest))
+	} else {
+		res += cfmt.Sprintf("  {{Referenced by tests}}::green:   %s\n", ColorOutputIntZeroableValue(data.CountTests))
+	}
+	if data.HasCoverage {
+		res += cfmt.Sprintf("  {{Coverage}}::green:              %s {{(%d of %d statements)}}::gray\n", colorCoverage(data.Coverage), data.CountCoveredStatements, data.CountStatements)
+		res += cfmt.Sprintf("  {{CRAP score}}::green:            %s {{(>30 complex and poorly tested)}}::gray\n", colorCrap(data.Crap))
+	}
+	res += cfmt.Sprintf("  {{Reads global state}}::green:    %s\n", ColorOutputIntZeroableValue(int64(len(data.ReadsGlobals))))
+	for _, name := range data.ReadsGlobals {
+		res += cfmt.Sprintf("     %s\n", name)
+	}
+	res += cfmt.Sprintf("  {{Writes global state}}::green:   %s\n", ColorOutputIntZeroableValue(int64(len(data.WritesGlobals))))
+	for _, name := range data.WritesGlobals {
+		res += cfmt.Sprintf("     %s\n", name)
+	}
+	if len(data.Effects) == 0 {
+		res += cfmt.Sprintf("  {{Side effects}}::green:          {{none (pure)}}::gray\n")
+	} else if len(data.DirectEffects) == 0 {
+		res += cfmt.Sprintf("  {{Side effects}}::green:          %s {{(through called functions)}}::gray\n", strings.Join(data.Effects, ", "))
+	} else {
+		res += cfmt.Sprintf("  {{Side effects}}::green:          %s {{(direct: %s)}}::gray\n", strings.Join(data.Effects, ", "), strings.Join(data.DirectEffects, ", "))
+	}
+	if len(data.Anonymous) != 0 {
+		res += cfmt.Sprintf("  {{Anonymous functions}}::green:   %s\n", ColorOutputIntZeroableValue(int64(len(data.Anonymous))))
+		for _, anonymous := range data.Anonymous {
+			res += cfmt.Sprintf("     %s\n", anonymous)
+		}
+	}
 
 	return res
 }
@@ -3,7 +3,6 @@ package representator
 import (
 	"github.com/alexeyco/simpletable"
 	"github.com/gookit/color"
-	"github.com/i582/cfmt"
 
 	"github.com/i582/phpstats/internal/stats/symbols"
 )
@@ -13,42 +12,50 @@ func GetTableFunctionsRepr(f []*symbols.Function, offset int64) string {
 		return ""
 	}
 
-	table := simpletable.New()
-	table.SetStyle(simpletable.StyleCompactLite)
-	table.Header = &simpletable.Header{
-		Cells: []*simpletable.Cell{
-			{Align: simpletable.AlignCenter, Text: color.Green.Sprint("#")},
-			{Align: simpletable.AlignCenter, Text: color.Green.Sprint("Name")},
-			{Align: simpletable.AlignCenter, Text: cfmt.Sprint("{{Number}}::green\n{{of uses}}::green")},
-			{Align: simpletable.AlignCenter, Text: cfmt.Sprint("{{Deps}}::green\n{{classes}}::green")},
-			{Align: simpletable.AlignCenter, Text: cfmt.Sprint("{{Classes}}::green\n{{depends}}::green")},
-			{Align: simpletable.AlignCenter, Text: cfmt.Sprint("{{Called}}::green\n{{funcs}}::green")},
-			{Align: simpletable.AlignCenter, Text: cfmt.Sprint("{{Called}}::green\n{{by funcs}}::green")},
-			{Align: simpletable.AlignCenter, Text: cfmt.Sprint("{{Cyclo}}::green\n{{compl}}::green")},
-			{Align: simpletable.AlignCenter, Text: cfmt.Sprint("{{Magic}}::green\n{{nums}}::green")},
-		},
-	}
+	return FunctionsToTable(f, offset).String()
+}
+
+func FunctionsToTable(f []*symbols.Function, offset int64) *Table {
+	table := NewTable(
+		TableColumn{Header: "#", Align: simpletable.AlignRight},
+		TableColumn{Header: "Name", Wrap: true},
+		TableColumn{Header: "Number\nof uses", Align: simpletable.AlignRight},
+		TableColumn{Header: "Deps\nclasses", Align: simpletable.AlignRight},
+		TableColumn{Header: "Classes\ndepends", Align: simpletable.AlignRight},
+		TableColumn{Header: "Called\nfuncs", Align: simpletable.AlignRight},
+		TableColumn{Header: "Called\nby funcs", Align: simpletable.AlignRight},
+		TableColumn{Header: "Cyclo\ncompl", Align: simpletable.AlignRight},
+		TableColumn{Header: "Magic\nnums", Align: simpletable.AlignRight},
+		TableColumn{Header: "Tests", Align: simpletable.AlignRight},
+		TableColumn{Header: "Cover", Align: simpletable.AlignRight},
+		TableColumn{Header: "CRAP", Align: simpletable.AlignRight},
+	)
 
 	for index, fun := range f {
 		data := funcToData(fun)
 
-		name := data.Name
-		name = splitText(name)
-
-		r := []*simpletable.Cell{
-			{Align: simpletable.AlignRight, Text: color.Gray.Sprint(int64(index+1) + offset)},
-			{Text: name},
-			{Align: simpletable.AlignRight, Text: ColorOutputIntZeroableValue(data.UsesCount)},
-			{Align: simpletable.AlignRight, Text: ColorOutputIntZeroableValue(data.CountDeps)},
-			{Align: simpletable.AlignRight, Text: ColorOutputIntZeroableValue(data.CountDepsBy)},
-			{Align: simpletable.AlignRight, Text: ColorOutputIntZeroableValue(data.CountCalled)},
-			{Align: simpletable.AlignRight, Text: ColorOutputIntZeroableValue(data.CountCalledBy)},
-			{Align: simpletable.AlignRight, Text: ColorOutputIntZeroableValue(data.CyclomaticComplexity)},
-			{Align: simpletable.AlignRight, Text: ColorOutputIntZeroableValue(data.CountMagicNumbers)},
+		coverage := color.Gray.Sprint("-")
+		crap := color.Gray.Sprint("-")
+		if data.HasCoverage {
+			coverage = colorCoverage(data.Coverage)
+			crap = colorCrap(data.Crap)
 		}
 
-		table.Body.Cells = append(table.Body.Cells, r)
+		table.AddRow(
+			color.Gray.Sprint(int64(index+1)+offset),
+			data.Name,
+			ColorOutputIntZeroableValue(data.UsesCount),
+			ColorOutputIntZeroableValue(data.CountDeps),
+			ColorOutputIntZeroableValue(data.CountDepsBy),
+			ColorOutputIntZeroableValue(data.CountCalled),
+			ColorOutputIntZeroableValue(data.CountCalledBy),
+			ColorOutputIntZeroableValue(data.CyclomaticComplexity),
+			ColorOutputIntZeroableValue(data.CountMagicNumbers),
+			ColorOutputIntZeroableValue(data.CountTests),
+			coverage,
+			crap,
+		)
 	}
 
-	return table.String()
+	return table
 }
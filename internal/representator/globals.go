@@ -0,0 +1,119 @@
+package representator
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/alexeyco/simpletable"
+	"github.com/gookit/color"
+	"github.com/i582/cfmt"
+
+	"github.com/i582/phpstats/internal/stats/symbols"
+)
+
+type GlobalData struct {
+	Name string `json:"name"`
+	Kind string `json:"kind"`
+
+	Readers []string `json:"readers"`
+	Writers []string `json:"writers"`
+}
+
+func GlobalToData(g *symbols.Global) *GlobalData {
+	if g == nil {
+		return nil
+	}
+
+	return &GlobalData{
+		Name:    g.Name,
+		Kind:    g.Kind.String(),
+		Readers: sortedFunctionNames(g.Readers),
+		Writers: sortedFunctionNames(g.Writers),
+	}
+}
+
+func sortedGlobalNames(g *symbols.Globals) []string {
+	globals := g.Sorted()
+	names := make([]string, 0, len(globals))
+	for _, global := range globals {
+		names = append(names, global.Name)
+	}
+	return names
+}
+
+func GetTableGlobalsRepr(g []*symbols.Global, offset int64) string {
+	if g == nil {
+		return ""
+	}
+
+	return GlobalsToTable(g, offset).String()
+}
+
+func GlobalsToTable(g []*symbols.Global, offset int64) *Table {
+	table := NewTable(
+		TableColumn{Header: "#", Align: simpletable.AlignRight},
+		TableColumn{Header: "Name", Wrap: true},
+		TableColumn{Header: "Kind"},
+		TableColumn{Header: "Readers", Align: simpletable.AlignRight},
+		TableColumn{Header: "Writers", Align: simpletable.AlignRight},
+	)
+
+	for index, global := range g {
+		data := GlobalToData(global)
+
+		table.AddRow(
+			color.Gray.Sprint(int64(index+1)+offset),
+			data.Name,
+			data.Kind,
+			ColorOutputIntZeroableValue(int64(len(data.Readers))),
+			ColorOutputIntZeroableValue(int64(len(data.Writers))),
+		)
+	}
+
+	return table
+}
+
+func GetStringGlobalUsersRepr(g *symbols.Global) string {
+	if g == nil {
+		return ""
+	}
+
+	data := GlobalToData(g)
+
+	var res string
+
+	res += cfmt.Sprintf("{{%s}}::yellow {{(%s)}}::gray\n", data.Name, data.Kind)
+
+	res += cfmt.Sprintf("  {{Readers}}::green:\n")
+	if len(data.Readers) == 0 {
+		res += cfmt.Sprintf("     {{none}}::gray\n")
+	}
+	for _, name := range data.Readers {
+		res += fmt.Sprintf("     %s\n", name)
+	}
+
+	res += cfmt.Sprintf("  {{Writers}}::green:\n")
+	if len(data.Writers) == 0 {
+		res += cfmt.Sprintf("     {{none}}::gray\n")
+	}
+	for _, name := range data.Writers {
+		res += fmt.Sprintf("     %s\n", name)
+	}
+
+	return res
+}
+
+func GetPrettifyJsonGlobalsRepr(g []*symbols.Global) (string, error) {
+	data := make([]*GlobalData, 0, len(g))
+
+	for _, global := range g {
+		data = append(data, GlobalToData(global))
+	}
+
+	res, err := json.MarshalIndent(data, "", "\t")
+	if err != nil {
+		return "", err
+	}
+
+	return string(res), nil
+}
@@ -0,0 +1,207 @@
+package representator
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/alexeyco/simpletable"
+	"github.com/gookit/color"
+	"github.com/i582/cfmt"
+
+	"github.com/i582/phpstats/internal/stats/impact"
+)
+
+type ImpactNodeData struct {
+	Name     string `json:"name"`
+	Kind     string `json:"kind"`
+	Group    string `json:"group"`
+	Distance int    `json:"distance"`
+	Via      string `json:"via"`
+	IsTest   bool   `json:"isTest"`
+}
+
+type ImpactData struct {
+	Symbol       string            `json:"symbol"`
+	Kind         string            `json:"kind"`
+	CountByKind  map[string]int64  `json:"countByKind"`
+	CountByGroup map[string]int64  `json:"countByGroup"`
+	MaxDistance  int               `json:"maxDistance"`
+	Affected     []*ImpactNodeData `json:"affected"`
+	Tests        []*ImpactNodeData `json:"tests,omitempty"`
+}
+
+func ImpactNodeToData(n *impact.Node) *ImpactNodeData {
+	if n == nil {
+		return nil
+	}
+
+	data := &ImpactNodeData{
+		Name:     n.Name(),
+		Kind:     n.Kind(),
+		Group:    n.Group,
+		Distance: n.Distance,
+		IsTest:   n.IsTest(),
+	}
+
+	if n.Via != nil {
+		data.Via = n.Via.Name()
+	}
+
+	return data
+}
+
+// ImpactToData converts the result, the tests are added only if withTests is true.
+func ImpactToData(r *impact.Result, withTests bool) *ImpactData {
+	data := &ImpactData{
+		CountByKind:  r.CountByKind(),
+		CountByGroup: r.CountByGroup(),
+		MaxDistance:  r.MaxDistance(),
+		Affected:     []*ImpactNodeData{},
+	}
+
+	if len(r.Roots) != 0 {
+		data.Symbol = r.Roots[0].Name()
+		data.Kind = r.Roots[0].Kind()
+	}
+
+	for _, node := range r.Affected {
+		if node.IsTest() {
+			if withTests {
+				data.Tests = append(data.Tests, ImpactNodeToData(node))
+			}
+			continue
+		}
+		data.Affected = append(data.Affected, ImpactNodeToData(node))
+	}
+
+	sort.SliceStable(data.Affected, func(i, j int) bool {
+		a, b := data.Affected[i], data.Affected[j]
+		if a.Distance != b.Distance {
+			return a.Distance < b.Distance
+		}
+		return a.Group < b.Group
+	})
+
+	return data
+}
+
+func GetStringImpactRepr(data *ImpactData) string {
+	var res string
+
+	res += cfmt.Sprintf("Impact of the change of %s {{%s}}::green\n", data.Kind, data.Symbol)
+	res += cfmt.Sprintf("  {{Affected}}::green: %d symbols", len(data.Affected))
+	if len(data.Affected) != 0 {
+		res += cfmt.Sprintf(" (%s), max distance %d", countsString(data.CountByKind, []string{
+			impact.KindClass, impact.KindMethod, impact.KindFunction, impact.KindConstant, impact.KindField, impact.KindFile,
+		}), data.MaxDistance)
+	}
+	res += "\n"
+
+	if len(data.CountByGroup) != 0 {
+		res += cfmt.Sprintf("  {{By namespace or package}}::green:\n")
+		for _, group := range sortedGroups(data.CountByGroup) {
+			res += cfmt.Sprintf("     %-40s %d\n", groupName(group), data.CountByGroup[group])
+		}
+	}
+
+	distance := 0
+	group := "-"
+	for _, node := range data.Affected {
+		if node.Distance != distance {
+			distance = node.Distance
+			group = "-"
+			res += cfmt.Sprintf("\n  {{Distance %d}}::yellow\n", distance)
+		}
+		if node.Group != group {
+			group = node.Group
+			res += cfmt.Sprintf("    {{%s}}::blue\n", groupName(group))
+		}
+
+		res += cfmt.Sprintf("      %s {{(%s, via %s)}}::gray\n", node.Name, node.Kind, node.Via)
+	}
+
+	if data.Tests != nil {
+		res += cfmt.Sprintf("\n  {{Tests}}::green: %d\n", len(data.Tests))
+		for _, node := range data.Tests {
+			res += cfmt.Sprintf("      %s {{(distance %d, via %s)}}::gray\n", node.Name, node.Distance, node.Via)
+		}
+	}
+
+	return res
+}
+
+func GetTableImpactRepr(nodes []*ImpactNodeData, offset int64) string {
+	return ImpactToTable(nodes, offset).String()
+}
+
+func ImpactToTable(nodes []*ImpactNodeData, offset int64) *Table {
+	table := NewTable(
+		TableColumn{Header: "#", Align: simpletable.AlignRight},
+		TableColumn{Header: "Name", Wrap: true},
+		TableColumn{Header: "Kind"},
+		TableColumn{Header: "Namespace\nor package"},
+		TableColumn{Header: "Distance", Align: simpletable.AlignRight},
+		TableColumn{Header: "Via", Wrap: true},
+	)
+
+	for index, node := range nodes {
+		table.AddRow(
+			color.Gray.Sprint(int64(index+1)+offset),
+			node.Name,
+			node.Kind,
+			groupName(node.Group),
+			cfmt.Sprint(node.Distance),
+			node.Via,
+		)
+	}
+
+	return table
+}
+
+func GetPrettifyJsonImpactRepr(data *ImpactData) (string, error) {
+	res, err := json.MarshalIndent(data, "", "\t")
+	if err != nil {
+		return "", err
+	}
+
+	return string(res), nil
+}
+
+// countsString returns the counts in the passed order of keys, for example, "2 class, 5 method".
+func countsString(counts map[string]int64, order []string) string {
+	var res string
+	for _, key := range order {
+		count, ok := counts[key]
+		if !ok {
+			continue
+		}
+		if res != "" {
+			res += ", "
+		}
+		res += cfmt.Sprintf("%s: %d", key, count)
+	}
+	return res
+}
+
+func sortedGroups(counts map[string]int64) []string {
+	groups := make([]string, 0, len(counts))
+	for group := range counts {
+		groups = append(groups, group)
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		if counts[groups[i]] != counts[groups[j]] {
+			return counts[groups[i]] > counts[groups[j]]
+		}
+		return groups[i] < groups[j]
+	})
+
+	return groups
+}
+
+func groupName(group string) string {
+	if group == "" {
+		return "files"
+	}
+	return group
+}
@@ -0,0 +1,140 @@
+package representator
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/alexeyco/simpletable"
+	"github.com/gookit/color"
+	"github.com/i582/cfmt"
+
+	"github.com/i582/phpstats/internal/stats/overrides"
+)
+
+type OverrideData struct {
+	Method          string   `json:"method"`
+	Parent          string   `json:"parent"`
+	Kind            string   `json:"kind"`
+	Signature       string   `json:"signature"`
+	ParentSignature string   `json:"parentSignature"`
+	CallsParent     bool     `json:"callsParent"`
+	RefusedBequest  bool     `json:"refusedBequest"`
+	Problems        []string `json:"problems"`
+}
+
+func OverrideToData(o *overrides.Override) *OverrideData {
+	if o == nil {
+		return nil
+	}
+
+	data := &OverrideData{
+		Method:         o.Method.Name.String(),
+		Parent:         o.Parent.Name.String(),
+		Kind:           o.Kind,
+		CallsParent:    o.CallsParent,
+		RefusedBequest: o.RefusedBequest,
+		Problems:       o.Problems,
+	}
+
+	if o.Method.Signature != nil {
+		data.Signature = o.Method.Signature.String()
+	}
+	if o.Parent.Signature != nil {
+		data.ParentSignature = o.Parent.Signature.String()
+	}
+
+	return data
+}
+
+// overrideNotes returns the problems of the override, including the refused bequest.
+func overrideNotes(data *OverrideData) string {
+	notes := make([]string, 0, len(data.Problems)+1)
+	if data.RefusedBequest {
+		notes = append(notes, color.Yellow.Sprint("refused bequest"))
+	}
+	for _, problem := range data.Problems {
+		notes = append(notes, color.Red.Sprint(problem))
+	}
+
+	if len(notes) == 0 {
+		return color.Gray.Sprint("none")
+	}
+
+	return strings.Join(notes, ", ")
+}
+
+func GetTableOverridesRepr(o []*overrides.Override, offset int64) string {
+	if o == nil {
+		return ""
+	}
+
+	return OverridesToTable(o, offset).String()
+}
+
+func OverridesToTable(o []*overrides.Override, offset int64) *Table {
+	table := NewTable(
+		TableColumn{Header: "#", Align: simpletable.AlignRight},
+		TableColumn{Header: "Method", Wrap: true},
+		TableColumn{Header: "Parent", Wrap: true},
+		TableColumn{Header: "Kind"},
+		TableColumn{Header: "Calls\nparent", Align: simpletable.AlignCenter},
+		TableColumn{Header: "Problems"},
+	)
+
+	for index, override := range o {
+		data := OverrideToData(override)
+
+		callsParent := color.Gray.Sprint("no")
+		if data.CallsParent {
+			callsParent = "yes"
+		}
+
+		table.AddRow(
+			color.Gray.Sprint(int64(index+1)+offset),
+			data.Method,
+			data.Parent,
+			data.Kind,
+			callsParent,
+			overrideNotes(data),
+		)
+	}
+
+	return table
+}
+
+func GetStringClassOverridesRepr(o []*overrides.Override) string {
+	var res string
+
+	res += cfmt.Sprintf("{{Overridden and implemented methods}}::green: %d\n", len(o))
+	if len(o) == 0 {
+		res += cfmt.Sprintf("     {{none}}::gray\n")
+	}
+
+	for _, override := range o {
+		data := OverrideToData(override)
+
+		res += cfmt.Sprintf("  {{%s}}::yellow %s {{(%s of %s)}}::gray\n", override.Method.Name.Name, data.Signature, data.Kind, data.Parent)
+		res += cfmt.Sprintf("     {{Parent signature}}::green: %s\n", data.ParentSignature)
+		if data.Kind == overrides.KindOverride {
+			res += cfmt.Sprintf("     {{Calls parent}}::green:     %t\n", data.CallsParent)
+		}
+		res += cfmt.Sprintf("     {{Problems}}::green:         %s\n", overrideNotes(data))
+	}
+
+	return res
+}
+
+func GetPrettifyJsonOverridesRepr(o []*overrides.Override) (string, error) {
+	data := make([]*OverrideData, 0, len(o))
+
+	for _, override := range o {
+		data = append(data, OverrideToData(override))
+	}
+
+	res, err := json.MarshalIndent(data, "", "\t")
+	if err != nil {
+		return "", err
+	}
+
+	return string(res), nil
+}
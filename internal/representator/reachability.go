@@ -0,0 +1,139 @@
+package representator
+
+import (
+	"encoding/json"
+
+	"github.com/alexeyco/simpletable"
+	"github.com/gookit/color"
+	"github.com/i582/cfmt"
+
+	"github.com/i582/phpstats/internal/stats/reachability"
+)
+
+type EntryPointData struct {
+	Kind             string `json:"kind"`
+	Name             string `json:"name"`
+	CountRoots       int64  `json:"countRoots"`
+	ReachableFuncs   int64  `json:"reachableFuncs"`
+	ReachableClasses int64  `json:"reachableClasses"`
+	ReachableFiles   int64  `json:"reachableFiles"`
+}
+
+type ReachabilitySummaryData struct {
+	Mode               string            `json:"mode"`
+	CountEntryPoints   map[string]int64  `json:"countEntryPoints"`
+	ReachableFuncs     int64             `json:"reachableFuncs"`
+	ReachableClasses   int64             `json:"reachableClasses"`
+	ReachableFiles     int64             `json:"reachableFiles"`
+	UnreachableFuncs   int64             `json:"unreachableFuncs"`
+	UnreachableClasses int64             `json:"unreachableClasses"`
+	UnreachableFiles   int64             `json:"unreachableFiles"`
+	EntryPoints        []*EntryPointData `json:"entryPoints"`
+}
+
+func EntryPointToData(e *reachability.EntryPoint) *EntryPointData {
+	if e == nil {
+		return nil
+	}
+
+	data := &EntryPointData{
+		Kind:       e.Kind,
+		Name:       e.Name,
+		CountRoots: int64(len(e.Files) + len(e.Funcs)),
+	}
+
+	if e.Reachable != nil {
+		data.ReachableFuncs, data.ReachableClasses, data.ReachableFiles = e.Reachable.CountProject()
+	}
+
+	return data
+}
+
+func ReachabilitySummaryToData(r *reachability.Result, unreachable *reachability.Set, entries []*reachability.EntryPoint) *ReachabilitySummaryData {
+	data := &ReachabilitySummaryData{
+		Mode:             r.Mode.String(),
+		CountEntryPoints: r.CountByKind(),
+		EntryPoints:      make([]*EntryPointData, 0, len(entries)),
+	}
+
+	data.ReachableFuncs, data.ReachableClasses, data.ReachableFiles = r.Reachable.CountProject()
+	data.UnreachableFuncs, data.UnreachableClasses, data.UnreachableFiles = unreachable.CountProject()
+
+	for _, entry := range entries {
+		data.EntryPoints = append(data.EntryPoints, EntryPointToData(entry))
+	}
+
+	return data
+}
+
+func GetStringReachabilitySummaryRepr(data *ReachabilitySummaryData) string {
+	var res string
+
+	res += cfmt.Sprintf("{{Reachability from entry points}}::green {{(%s mode)}}::gray\n", data.Mode)
+
+	res += cfmt.Sprintf("  {{Entry points}}::green:\n")
+	if len(data.CountEntryPoints) == 0 {
+		res += cfmt.Sprintf("     {{none, see the 'entryPoints' section of the config}}::gray\n")
+	}
+	for _, kind := range []string{reachability.KindScript, reachability.KindController, reachability.KindCommand, reachability.KindTagged} {
+		if count, ok := data.CountEntryPoints[kind]; ok {
+			res += cfmt.Sprintf("     %-20s %d\n", kind+":", count)
+		}
+	}
+
+	res += cfmt.Sprintf("  {{Functions}}::green: %d reachable, %s unreachable\n", data.ReachableFuncs, colorUnreachable(data.UnreachableFuncs))
+	res += cfmt.Sprintf("  {{Classes}}::green:   %d reachable, %s unreachable\n", data.ReachableClasses, colorUnreachable(data.UnreachableClasses))
+	res += cfmt.Sprintf("  {{Files}}::green:     %d reachable, %s unreachable\n", data.ReachableFiles, colorUnreachable(data.UnreachableFiles))
+
+	return res
+}
+
+func colorUnreachable(count int64) string {
+	if count == 0 {
+		return color.Green.Sprint(count)
+	}
+	return color.Red.Sprint(count)
+}
+
+func GetTableEntryPointsRepr(e []*reachability.EntryPoint, offset int64) string {
+	if e == nil {
+		return ""
+	}
+
+	return EntryPointsToTable(e, offset).String()
+}
+
+func EntryPointsToTable(e []*reachability.EntryPoint, offset int64) *Table {
+	table := NewTable(
+		TableColumn{Header: "#", Align: simpletable.AlignRight},
+		TableColumn{Header: "Entry point", Wrap: true},
+		TableColumn{Header: "Kind"},
+		TableColumn{Header: "Reachable\nfuncs", Align: simpletable.AlignRight},
+		TableColumn{Header: "Reachable\nclasses", Align: simpletable.AlignRight},
+		TableColumn{Header: "Reachable\nfiles", Align: simpletable.AlignRight},
+	)
+
+	for index, entry := range e {
+		data := EntryPointToData(entry)
+
+		table.AddRow(
+			color.Gray.Sprint(int64(index+1)+offset),
+			data.Name,
+			data.Kind,
+			cfmt.Sprint(data.ReachableFuncs),
+			cfmt.Sprint(data.ReachableClasses),
+			cfmt.Sprint(data.ReachableFiles),
+		)
+	}
+
+	return table
+}
+
+func GetPrettifyJsonReachabilitySummaryRepr(data *ReachabilitySummaryData) (string, error) {
+	res, err := json.MarshalIndent(data, "", "\t")
+	if err != nil {
+		return "", err
+	}
+
+	return string(res), nil
+}
@@ -0,0 +1,252 @@
+package representator
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+
+	"github.com/alexeyco/simpletable"
+	"github.com/gookit/color"
+)
+
+// Output formats of the tables.
+const (
+	FormatTable    = "table"
+	FormatCSV      = "csv"
+	FormatTSV      = "tsv"
+	FormatMarkdown = "md"
+	FormatHTML     = "html"
+)
+
+// TableFormats is the list of the supported table formats.
+var TableFormats = []string{FormatTable, FormatCSV, FormatTSV, FormatMarkdown, FormatHTML}
+
+// TableColumn describes the column of the table.
+type TableColumn struct {
+	// Header may consist of several lines separated by "\n",
+	// on export the lines are joined by a space.
+	Header string
+	// Name is the name of the column on export, by default the lines
+	// of the header joined by a space.
+	Name string
+	// Align is the alignment of the values in the column.
+	Align int
+	// Wrap splits long values into several lines in the ASCII table.
+	Wrap bool
+}
+
+// Table is the common model of the tables printed in the shell.
+//
+// It is printed as an ASCII table and can be exported to CSV, TSV,
+// Markdown and HTML. The values of the cells may be colored,
+// the colors are removed on export.
+type Table struct {
+	Title   string
+	Columns []TableColumn
+	Rows    [][]string
+}
+
+func NewTable(columns ...TableColumn) *Table {
+	return &Table{
+		Columns: columns,
+	}
+}
+
+func (t *Table) AddRow(cells ...string) {
+	t.Rows = append(t.Rows, cells)
+}
+
+// String returns the ASCII representation of the table.
+func (t *Table) String() string {
+	table := simpletable.New()
+	table.SetStyle(simpletable.StyleCompactLite)
+
+	header := &simpletable.Header{}
+	for _, column := range t.Columns {
+		lines := strings.Split(column.Header, "\n")
+		for i := range lines {
+			lines[i] = color.Green.Sprint(lines[i])
+		}
+
+		header.Cells = append(header.Cells, &simpletable.Cell{
+			Align: simpletable.AlignCenter,
+			Text:  strings.Join(lines, "\n"),
+		})
+	}
+	table.Header = header
+
+	for _, row := range t.Rows {
+		cells := make([]*simpletable.Cell, 0, len(row))
+		for i, value := range row {
+			column := t.Columns[i]
+			if column.Wrap {
+				value = splitText(value)
+			}
+
+			cells = append(cells, &simpletable.Cell{Align: column.Align, Text: value})
+		}
+
+		table.Body.Cells = append(table.Body.Cells, cells)
+	}
+
+	return table.String()
+}
+
+// Format returns the table in the specified format.
+func (t *Table) Format(format string) (string, error) {
+	switch format {
+	case "", FormatTable:
+		return t.String(), nil
+	case FormatCSV:
+		return t.separated(','), nil
+	case FormatTSV:
+		return t.separated('\t'), nil
+	case FormatMarkdown:
+		return t.markdown(), nil
+	case FormatHTML:
+		return t.html(), nil
+	}
+
+	return "", fmt.Errorf("unknown format '%s', available formats: %s", format, strings.Join(TableFormats, ", "))
+}
+
+func (t *Table) headers() []string {
+	headers := make([]string, 0, len(t.Columns))
+	for _, column := range t.Columns {
+		if column.Name != "" {
+			headers = append(headers, column.Name)
+			continue
+		}
+		headers = append(headers, strings.ReplaceAll(column.Header, "\n", " "))
+	}
+	return headers
+}
+
+var colorRegexp = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+// StripColors removes the terminal colors from the string.
+func StripColors(s string) string {
+	return colorRegexp.ReplaceAllString(s, "")
+}
+
+// plainCells returns the cells of the row without colors.
+func plainCells(row []string) []string {
+	cells := make([]string, 0, len(row))
+	for _, cell := range row {
+		cells = append(cells, StripColors(cell))
+	}
+	return cells
+}
+
+func (t *Table) separated(sep rune) string {
+	var buf bytes.Buffer
+
+	w := csv.NewWriter(&buf)
+	w.Comma = sep
+
+	records := make([][]string, 0, len(t.Rows)+1)
+	records = append(records, t.headers())
+	for _, row := range t.Rows {
+		cells := plainCells(row)
+		if sep == '\t' {
+			for i := range cells {
+				cells[i] = strings.ReplaceAll(cells[i], "\n", ", ")
+			}
+		}
+		records = append(records, cells)
+	}
+
+	// The error can only occur when writing to the buffer fails.
+	_ = w.WriteAll(records)
+
+	return buf.String()
+}
+
+var markdownReplacer = strings.NewReplacer("|", `\|`, "\n", "<br>")
+
+func (t *Table) markdown() string {
+	var res string
+
+	if t.Title != "" {
+		res += "### " + t.Title + "\n\n"
+	}
+
+	headers := t.headers()
+	for i := range headers {
+		headers[i] = markdownReplacer.Replace(headers[i])
+	}
+	res += "| " + strings.Join(headers, " | ") + " |\n"
+
+	aligns := make([]string, 0, len(t.Columns))
+	for _, column := range t.Columns {
+		switch column.Align {
+		case simpletable.AlignRight:
+			aligns = append(aligns, "---:")
+		case simpletable.AlignCenter:
+			aligns = append(aligns, ":---:")
+		default:
+			aligns = append(aligns, "---")
+		}
+	}
+	res += "| " + strings.Join(aligns, " | ") + " |\n"
+
+	for _, row := range t.Rows {
+		cells := plainCells(row)
+		for i := range cells {
+			cells[i] = markdownReplacer.Replace(cells[i])
+		}
+		res += "| " + strings.Join(cells, " | ") + " |\n"
+	}
+
+	return res
+}
+
+func (t *Table) html() string {
+	return t.HTML(nil)
+}
+
+// HTML returns the table in HTML, link returns the address for the cell,
+// the cells for which it returns an empty string are not linked.
+func (t *Table) HTML(link func(row, column int) string) string {
+	var res string
+
+	res += "<table>\n"
+	if t.Title != "" {
+		res += fmt.Sprintf("  <caption>%s</caption>\n", html.EscapeString(t.Title))
+	}
+
+	res += "  <thead>\n    <tr>"
+	for _, header := range t.headers() {
+		res += fmt.Sprintf("<th>%s</th>", html.EscapeString(header))
+	}
+	res += "</tr>\n  </thead>\n"
+
+	res += "  <tbody>\n"
+	for rowIndex, row := range t.Rows {
+		res += "    <tr>"
+		for i, cell := range plainCells(row) {
+			value := strings.ReplaceAll(html.EscapeString(cell), "\n", "<br>")
+			if link != nil {
+				if href := link(rowIndex, i); href != "" {
+					value = fmt.Sprintf(`<a href="%s">%s</a>`, html.EscapeString(href), value)
+				}
+			}
+
+			switch t.Columns[i].Align {
+			case simpletable.AlignRight:
+				res += fmt.Sprintf(`<td align="right">%s</td>`, value)
+			case simpletable.AlignCenter:
+				res += fmt.Sprintf(`<td align="center">%s</td>`, value)
+			default:
+				res += fmt.Sprintf("<td>%s</td>", value)
+			}
+		}
+		res += "</tr>\n"
+	}
+	res += "  </tbody>\n</table>\n"
+
+	return res
+}
@@ -0,0 +1,72 @@
+package representator
+
+import (
+	"testing"
+
+	"github.com/alexeyco/simpletable"
+	"github.com/gookit/color"
+)
+
+func testTable() *Table {
+	table := NewTable(
+		TableColumn{Header: "Name"},
+		TableColumn{Header: "Count\nof uses", Align: simpletable.AlignRight},
+		TableColumn{Header: "LCOM\n4", Name: "LCOM4", Align: simpletable.AlignCenter},
+	)
+	table.Title = "Classes"
+	table.AddRow(`\App\Foo`, color.Gray.Sprint(0), "1")
+	table.AddRow(`\App\Bar|Baz`, "10", "line 1\nline 2")
+	return table
+}
+
+func TestTableFormat(t *testing.T) {
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{
+			format: FormatCSV,
+			want: "Name,Count of uses,LCOM4\n" +
+				"\\App\\Foo,0,1\n" +
+				"\\App\\Bar|Baz,10,\"line 1\nline 2\"\n",
+		},
+		{
+			format: FormatTSV,
+			want: "Name\tCount of uses\tLCOM4\n" +
+				"\\App\\Foo\t0\t1\n" +
+				"\\App\\Bar|Baz\t10\tline 1, line 2\n",
+		},
+		{
+			format: FormatMarkdown,
+			want: "### Classes\n\n" +
+				"| Name | Count of uses | LCOM4 |\n" +
+				"| --- | ---: | :---: |\n" +
+				"| \\App\\Foo | 0 | 1 |\n" +
+				"| \\App\\Bar\\|Baz | 10 | line 1<br>line 2 |\n",
+		},
+		{
+			format: FormatHTML,
+			want: "<table>\n" +
+				"  <caption>Classes</caption>\n" +
+				"  <thead>\n    <tr><th>Name</th><th>Count of uses</th><th>LCOM4</th></tr>\n  </thead>\n" +
+				"  <tbody>\n" +
+				"    <tr><td>\\App\\Foo</td><td align=\"right\">0</td><td align=\"center\">1</td></tr>\n" +
+				"    <tr><td>\\App\\Bar|Baz</td><td align=\"right\">10</td><td align=\"center\">line 1<br>line 2</td></tr>\n" +
+				"  </tbody>\n</table>\n",
+		},
+	}
+
+	for _, test := range tests {
+		got, err := testTable().Format(test.format)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", test.format, err)
+		}
+		if got != test.want {
+			t.Errorf("%s: mismatch\ngot:\n%s\nwant:\n%s", test.format, got, test.want)
+		}
+	}
+
+	if _, err := testTable().Format("xml"); err == nil {
+		t.Errorf("expected error for unknown format")
+	}
+}
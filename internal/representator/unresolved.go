@@ -0,0 +1,86 @@
+package representator
+
+import (
+	"encoding/json"
+
+	"github.com/i582/cfmt"
+
+	"github.com/i582/phpstats/internal/stats/symbols"
+)
+
+type UnresolvedCallData struct {
+	Kind string `json:"kind"`
+	Expr string `json:"expr"`
+	Site string `json:"site"`
+}
+
+type FunctionUnresolvedData struct {
+	Name  string                `json:"name"`
+	Calls []*UnresolvedCallData `json:"calls"`
+}
+
+func FunctionUnresolvedToData(f *symbols.Function, kind string) *FunctionUnresolvedData {
+	if f == nil {
+		return nil
+	}
+
+	calls := f.Unresolved.Sorted(kind)
+
+	data := &FunctionUnresolvedData{
+		Name:  f.Name.String(),
+		Calls: make([]*UnresolvedCallData, 0, len(calls)),
+	}
+
+	for _, call := range calls {
+		data.Calls = append(data.Calls, &UnresolvedCallData{
+			Kind: call.Kind.String(),
+			Expr: call.Expr,
+			Site: call.Site.String(),
+		})
+	}
+
+	return data
+}
+
+func GetStringFunctionUnresolvedRepr(f *symbols.Function, kind string) string {
+	if f == nil {
+		return ""
+	}
+
+	data := FunctionUnresolvedToData(f, kind)
+
+	var res string
+
+	res += cfmt.Sprintf("{{%s}}::yellow {{(%d)}}::gray\n", data.Name, len(data.Calls))
+	for _, call := range data.Calls {
+		res += cfmt.Sprintf("     {{%s}}::gray %s {{(%s)}}::gray\n", call.Site, shortExpr(call.Expr), call.Kind)
+	}
+
+	return res
+}
+
+func GetPrettifyJsonFunctionsUnresolvedRepr(f []*symbols.Function, kind string) (string, error) {
+	data := make([]*FunctionUnresolvedData, 0, len(f))
+
+	for _, fn := range f {
+		data = append(data, FunctionUnresolvedToData(fn, kind))
+	}
+
+	res, err := json.MarshalIndent(data, "", "\t")
+	if err != nil {
+		return "", err
+	}
+
+	return string(res), nil
+}
+
+// shortExpr cuts the expression to fit in one line.
+func shortExpr(expr string) string {
+	const maxLen = 60
+
+	if len(expr) <= maxLen {
+		return expr
+	}
+
+	return expr[:maxLen-3] + "..."
+}
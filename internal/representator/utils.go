@@ -75,3 +75,20 @@ func ColorWidthOutputFloatZeroablePercentValue(data float64, width int64) string
 	}
 	return fmt.Sprintf("(%*.2f%%)", width, data)
 }
+
+// crapThreshold is the CRAP score above which a function is considered crappy.
+const crapThreshold = 30
+
+func colorCrap(data float64) string {
+	if data > crapThreshold {
+		return color.Red.Sprintf("%.2f", data)
+	}
+	return fmt.Sprintf("%.2f", data)
+}
+
+func colorCoverage(data float64) string {
+	if data == 0 {
+		return color.Red.Sprintf("%.2f%%", data)
+	}
+	return fmt.Sprintf("%.2f%%", data)
+}
@@ -2,7 +2,9 @@ package commands
 
 import (
 	"fmt"
+	"strings"
 
+	"github.com/alexeyco/simpletable"
 	"github.com/i582/cfmt"
 
 	"github.com/i582/phpstats/internal/representator"
@@ -24,78 +26,240 @@ func colorPercent(value float64) string {
 	return representator.ColorOutputFloatZeroablePercentValue(value)
 }
 
-func Brief() *shell.Executor {
-	briefExecutor := &shell.Executor{
-		Name:  "brief",
-		Help:  "shows brief information about the project",
-		Flags: flags.NewFlags(),
-		Func: func(c *shell.Context) {
-			countLines := walkers.GlobalCtx.Files.CountLines()
+// briefMetric is a line of the brief project statistics.
+type briefMetric struct {
+	section string
+	group   string
+	name    string
+	level   int
 
-			maxMethodCMN, minMethodCMN, avgMethodCMN := walkers.GlobalCtx.Functions.MaxMinAvgMethodCountMagicNumbers()
-			maxFunctionCMN, minFunctionCMN, avgFunctionCMN := walkers.GlobalCtx.Functions.MaxMinAvgFunctionsCountMagicNumbers()
-			maxClassCMN, minClassCMN, avgClassCMN := walkers.GlobalCtx.Classes.MaxMinAvgCountMagicNumbers()
+	// value is int64 or float64, nil for the headers of the groups and the rates.
+	value       interface{}
+	percent     float64
+	withPercent bool
+}
 
-			maxMethodCC, minMethodCC, avgMethodCC := walkers.GlobalCtx.Functions.MaxMinAvgMethodCyclomaticComplexity()
-			maxFunctionCC, minFunctionCC, avgFunctionCC := walkers.GlobalCtx.Functions.MaxMinAvgFunctionsCyclomaticComplexity()
-			maxClassCC, minClassCC, avgClassCC := walkers.GlobalCtx.Classes.MaxMinAvgCyclomaticComplexity()
+// briefMetrics collects the brief statistics so that they can be
+// printed as is or exported as a table.
+type briefMetrics struct {
+	section string
+	group   string
+	list    []briefMetric
+}
 
-			cfmt.Printf("General '%s' project statistics\n\n", walkers.GlobalCtx.ProjectName)
+func (m *briefMetrics) Section(name string) {
+	m.section = name
+	m.group = ""
+}
+
+func (m *briefMetrics) Group(level int, name string) {
+	m.group = name
+	m.list = append(m.list, briefMetric{section: m.section, name: name, level: level})
+}
+
+func (m *briefMetrics) Add(level int, name string, value interface{}) {
+	m.list = append(m.list, briefMetric{section: m.section, group: m.group, name: name, level: level, value: value})
+}
 
-			cfmt.Println("Size")
+func (m *briefMetrics) AddPercent(level int, name string, value int64, percent float64) {
+	m.list = append(m.list, briefMetric{section: m.section, group: m.group, name: name, level: level, value: value, percent: percent, withPercent: true})
+}
+
+func (m *briefMetrics) AddRate(level int, name string, percent float64) {
+	m.list = append(m.list, briefMetric{section: m.section, group: m.group, name: name, level: level, percent: percent, withPercent: true})
+}
 
-			cfmt.Printf("    {{Lines of Code (LOC)}}::green:                           %s\n", colorInt(countLines))
-			cfmt.Printf("    {{Comment Lines of Code (CLOC)}}::green:                  %s %s\n", colorInt(walkers.GlobalCtx.CountCommentLine), colorPercent(utils.Percent(walkers.GlobalCtx.CountCommentLine, countLines)))
-			cfmt.Printf("    {{Non-Comment Lines of Code (NCLOC)}}::green:             %s %s\n", colorInt(countLines-walkers.GlobalCtx.CountCommentLine), colorPercent(100-utils.Percent(walkers.GlobalCtx.CountCommentLine, countLines)))
-			cfmt.Println()
+func (m briefMetric) isGroup() bool {
+	return m.value == nil && !m.withPercent
+}
 
-			cfmt.Println("Metrics")
+// String returns the statistics in the form of the aligned list.
+func (m *briefMetrics) String() string {
+	const valueColumn = 51
 
-			cfmt.Printf("    {{Cyclomatic Complexity}}::green\n")
+	var res string
+	for index, metric := range m.list {
+		if index == 0 || m.list[index-1].section != metric.section {
+			if index != 0 {
+				res += "\n"
+			}
+			res += metric.section + "\n"
+		}
 
-			cfmt.Printf("        {{Average Complexity per Class}}::green:              %s\n", colorFloat(avgClassCC))
-			cfmt.Printf("            {{Maximum Class Complexity}}::green:              %s\n", colorFloat(maxClassCC))
-			cfmt.Printf("            {{Minimum Class Complexity}}::green:              %s\n", colorFloat(minClassCC))
+		indent := strings.Repeat("    ", metric.level)
 
-			cfmt.Printf("        {{Average Complexity per Method}}::green:             %s\n", colorFloat(avgMethodCC))
-			cfmt.Printf("            {{Maximum Method Complexity}}::green:             %s\n", colorFloat(maxMethodCC))
-			cfmt.Printf("            {{Minimum Method Complexity}}::green:             %s\n", colorFloat(minMethodCC))
+		if metric.isGroup() {
+			if index != 0 && m.list[index-1].section == metric.section && m.list[index-1].level != metric.level {
+				res += "\n"
+			}
+			res += cfmt.Sprintf("%s{{%s}}::green\n", indent, metric.name)
+			continue
+		}
 
-			cfmt.Printf("        {{Average Complexity per Functions}}::green:          %s\n", colorFloat(avgFunctionCC))
-			cfmt.Printf("            {{Maximum Functions Complexity}}::green:          %s\n", colorFloat(maxFunctionCC))
-			cfmt.Printf("            {{Minimum Functions Complexity}}::green:          %s\n", colorFloat(minFunctionCC))
-			cfmt.Println()
+		var value string
+		switch v := metric.value.(type) {
+		case int64:
+			value = colorInt(v)
+		case float64:
+			value = colorFloat(v)
+		}
+		if metric.withPercent {
+			if value != "" {
+				value += " "
+			}
+			value += colorPercent(metric.percent)
+		}
 
-			cfmt.Printf("    {{Count of Magic Numbers}}::green\n")
+		padding := strings.Repeat(" ", valueColumn-len(indent)-len(metric.name)-1)
+		res += cfmt.Sprintf("%s{{%s}}::green:%s%s\n", indent, metric.name, padding, value)
+	}
 
-			cfmt.Printf("        {{Average Class Count}}::green:                       %s\n", colorInt(avgClassCMN))
-			cfmt.Printf("            {{Maximum Class Count}}::green:                   %s\n", colorInt(maxClassCMN))
-			cfmt.Printf("            {{Minimum Class Count}}::green:                   %s\n", colorInt(minClassCMN))
+	return res
+}
 
-			cfmt.Printf("        {{Average Method Count}}::green:                      %s\n", colorInt(avgMethodCMN))
-			cfmt.Printf("            {{Maximum Method Count}}::green:                  %s\n", colorInt(maxMethodCMN))
-			cfmt.Printf("            {{Minimum Method Count}}::green:                  %s\n", colorInt(minMethodCMN))
+// Table returns the statistics in the form of the table.
+func (m *briefMetrics) Table() *representator.Table {
+	table := representator.NewTable(
+		representator.TableColumn{Header: "Section"},
+		representator.TableColumn{Header: "Group"},
+		representator.TableColumn{Header: "Metric"},
+		representator.TableColumn{Header: "Value", Align: simpletable.AlignRight},
+		representator.TableColumn{Header: "Percent", Align: simpletable.AlignRight},
+	)
+	table.Title = fmt.Sprintf("General '%s' project statistics", walkers.GlobalCtx.ProjectName)
 
-			cfmt.Printf("        {{Average Functions Count}}::green:                   %s\n", colorInt(avgFunctionCMN))
-			cfmt.Printf("            {{Maximum Method Count}}::green:                  %s\n", colorInt(maxFunctionCMN))
-			cfmt.Printf("            {{Minimum Method Count}}::green:                  %s\n", colorInt(minFunctionCMN))
-			cfmt.Println()
+	for _, metric := range m.list {
+		if metric.isGroup() {
+			continue
+		}
+
+		var value, percent string
+		switch v := metric.value.(type) {
+		case int64:
+			value = fmt.Sprint(v)
+		case float64:
+			value = fmt.Sprintf("%.2f", v)
+		}
+		if metric.withPercent {
+			percent = fmt.Sprintf("%.2f", metric.percent)
+		}
+
+		table.AddRow(metric.section, metric.group, metric.name, value, percent)
+	}
+
+	return table
+}
 
-			cfmt.Println("Structure")
+// collectBriefMetrics returns the brief statistics of the project.
+func collectBriefMetrics(withTests bool) *briefMetrics {
+	classes := metricsClasses(withTests)
+	funcs := metricsFunctions(withTests)
 
-			cfmt.Printf("    {{Files}}::green:                                         %s\n", colorInt(int64(walkers.GlobalCtx.Files.Len())))
-			cfmt.Printf("    {{Namespaces}}::green:                                    %s\n", colorInt(walkers.GlobalCtx.Namespaces.Count()))
-			cfmt.Printf("    {{Interfaces}}::green:                                    %s\n", colorInt(walkers.GlobalCtx.Classes.CountIfaces()))
-			cfmt.Printf("    {{Traits}}::green:                                        %s\n", colorInt(walkers.GlobalCtx.Classes.CountTraits()))
-			cfmt.Printf("    {{Classes}}::green                                        %s\n", colorInt(walkers.GlobalCtx.Classes.CountClasses()))
-			cfmt.Printf("        {{Abstract Classes}}::green:                          %s %s\n", colorInt(walkers.GlobalCtx.Classes.CountAbstractClasses()), colorPercent(utils.Percent(walkers.GlobalCtx.Classes.CountAbstractClasses(), int64(walkers.GlobalCtx.Classes.Len()))))
-			cfmt.Printf("        {{Concrete Classes}}::green:                          %s %s\n", colorInt(walkers.GlobalCtx.Classes.CountConcreteClasses()), colorPercent(100-utils.Percent(walkers.GlobalCtx.Classes.CountAbstractClasses(), int64(walkers.GlobalCtx.Classes.Len()))))
-			cfmt.Printf("    {{Methods}}::green:                                       %s\n", colorInt(walkers.GlobalCtx.Functions.CountMethods()))
-			cfmt.Printf("    {{Constants}}::green:                                     %s\n", colorInt(int64(walkers.GlobalCtx.Constants.Len())))
-			cfmt.Printf("    {{Functions}}::green:\n")
-			cfmt.Printf("        {{Named Functions}}::green:                           %s %s\n", colorInt(walkers.GlobalCtx.Functions.CountFunctions(false)), colorPercent(utils.Percent(walkers.GlobalCtx.Functions.CountFunctions(false), walkers.GlobalCtx.Functions.CountFunctions(false)+walkers.GlobalCtx.CountAnonymousFunctions)))
-			cfmt.Printf("        {{Anonymous Functions}}::green:                       %s %s\n", colorInt(walkers.GlobalCtx.CountAnonymousFunctions), colorPercent(utils.Percent(walkers.GlobalCtx.CountAnonymousFunctions, walkers.GlobalCtx.Functions.CountFunctions(false)+walkers.GlobalCtx.CountAnonymousFunctions)))
+	countLines := walkers.GlobalCtx.Files.CountLines()
+	countDuplicatedLines := walkers.GlobalCtx.Files.CountDuplicatedLines()
 
+	maxMethodCMN, minMethodCMN, avgMethodCMN := funcs.MaxMinAvgMethodCountMagicNumbers()
+	maxFunctionCMN, minFunctionCMN, avgFunctionCMN := funcs.MaxMinAvgFunctionsCountMagicNumbers()
+	maxClassCMN, minClassCMN, avgClassCMN := classes.MaxMinAvgCountMagicNumbers()
+
+	maxMethodCC, minMethodCC, avgMethodCC := funcs.MaxMinAvgMethodCyclomaticComplexity()
+	maxFunctionCC, minFunctionCC, avgFunctionCC := funcs.MaxMinAvgFunctionsCyclomaticComplexity()
+	maxClassCC, minClassCC, avgClassCC := classes.MaxMinAvgCyclomaticComplexity()
+
+	countAnonymousFunctions := walkers.GlobalCtx.CountAnonymousFunctions
+	if !walkers.GlobalCtx.FoldAnonymous {
+		countAnonymousFunctions = funcs.CountAnonymousFunctions()
+	}
+	countNamedFunctions := funcs.CountFunctions(false)
+
+	resolvedCalls, unresolvedCalls := funcs.CountCallSites()
+
+	countTestableClasses := walkers.GlobalCtx.Classes.CountTestableClasses()
+	countTestedClasses := countTestableClasses - walkers.GlobalCtx.Classes.CountUntestedClasses()
+	countTestableMethods := walkers.GlobalCtx.Functions.CountTestableMethods()
+	countTestedMethods := countTestableMethods - walkers.GlobalCtx.Functions.CountUntestedMethods()
+
+	metrics := &briefMetrics{}
+
+	metrics.Section("Size")
+	metrics.Add(1, "Lines of Code (LOC)", countLines)
+	metrics.AddPercent(1, "Comment Lines of Code (CLOC)", walkers.GlobalCtx.CountCommentLine, utils.Percent(walkers.GlobalCtx.CountCommentLine, countLines))
+	metrics.AddPercent(1, "Non-Comment Lines of Code (NCLOC)", countLines-walkers.GlobalCtx.CountCommentLine, 100-utils.Percent(walkers.GlobalCtx.CountCommentLine, countLines))
+	metrics.AddPercent(1, "Duplicated Lines of Code", countDuplicatedLines, utils.Percent(countDuplicatedLines, countLines))
+
+	metrics.Section("Metrics")
+	metrics.Group(1, "Cyclomatic Complexity")
+	metrics.Add(2, "Average Complexity per Class", avgClassCC)
+	metrics.Add(3, "Maximum Class Complexity", maxClassCC)
+	metrics.Add(3, "Minimum Class Complexity", minClassCC)
+	metrics.Add(2, "Average Complexity per Method", avgMethodCC)
+	metrics.Add(3, "Maximum Method Complexity", maxMethodCC)
+	metrics.Add(3, "Minimum Method Complexity", minMethodCC)
+	metrics.Add(2, "Average Complexity per Functions", avgFunctionCC)
+	metrics.Add(3, "Maximum Functions Complexity", maxFunctionCC)
+	metrics.Add(3, "Minimum Functions Complexity", minFunctionCC)
+
+	metrics.Group(1, "Count of Magic Numbers")
+	metrics.Add(2, "Average Class Count", avgClassCMN)
+	metrics.Add(3, "Maximum Class Count", maxClassCMN)
+	metrics.Add(3, "Minimum Class Count", minClassCMN)
+	metrics.Add(2, "Average Method Count", avgMethodCMN)
+	metrics.Add(3, "Maximum Method Count", maxMethodCMN)
+	metrics.Add(3, "Minimum Method Count", minMethodCMN)
+	metrics.Add(2, "Average Functions Count", avgFunctionCMN)
+	metrics.Add(3, "Maximum Functions Count", maxFunctionCMN)
+	metrics.Add(3, "Minimum Functions Count", minFunctionCMN)
+
+	metrics.Section("Structure")
+	metrics.Add(1, "Files", int64(walkers.GlobalCtx.Files.Len()))
+	metrics.Add(1, "Namespaces", walkers.GlobalCtx.Namespaces.Count())
+	metrics.Add(1, "Interfaces", classes.CountIfaces())
+	metrics.Add(1, "Traits", classes.CountTraits())
+	metrics.Add(1, "Classes", classes.CountClasses())
+	metrics.AddPercent(2, "Abstract Classes", classes.CountAbstractClasses(), utils.Percent(classes.CountAbstractClasses(), int64(classes.Len())))
+	metrics.AddPercent(2, "Concrete Classes", classes.CountConcreteClasses(), 100-utils.Percent(classes.CountAbstractClasses(), int64(classes.Len())))
+	metrics.Add(1, "Methods", funcs.CountMethods())
+	metrics.Add(1, "Constants", int64(walkers.GlobalCtx.Constants.Len()))
+	metrics.Group(1, "Functions")
+	metrics.AddPercent(2, "Named Functions", countNamedFunctions, utils.Percent(countNamedFunctions, countNamedFunctions+countAnonymousFunctions))
+	metrics.AddPercent(2, "Anonymous Functions", countAnonymousFunctions, utils.Percent(countAnonymousFunctions, countNamedFunctions+countAnonymousFunctions))
+
+	metrics.Section("Call Graph")
+	metrics.Add(1, "Resolved Call Sites", resolvedCalls)
+	metrics.Add(1, "Unresolved Call Sites", unresolvedCalls)
+	metrics.AddRate(1, "Resolution Rate", utils.Percent(resolvedCalls, resolvedCalls+unresolvedCalls))
+
+	metrics.Section("Tests")
+	metrics.Add(1, "Test Classes", walkers.GlobalCtx.Classes.CountTestClasses())
+	metrics.Add(1, "Test Methods", walkers.GlobalCtx.Functions.CountTestMethods())
+	metrics.AddPercent(1, "Classes Referenced by Tests", countTestedClasses, utils.Percent(countTestedClasses, countTestableClasses))
+	metrics.AddPercent(1, "Methods Referenced by Tests", countTestedMethods, utils.Percent(countTestedMethods, countTestableMethods))
+
+	return metrics
+}
+
+func Brief() *shell.Executor {
+	briefExecutor := &shell.Executor{
+		Name: "brief",
+		Help: "shows brief information about the project",
+		Flags: flags.NewFlags(
+			&flags.Flag{
+				Name: "--tests",
+				Help: "include tests in metrics",
+			},
+			formatFlag(),
+			formatOutFlag(),
+		),
+		Func: func(c *shell.Context) {
+			metrics := collectBriefMetrics(handleWithTests(c))
+
+			if exportTable(c) {
+				exportTables(c, metrics.Table())
+				return
+			}
+
+			cfmt.Printf("General '%s' project statistics\n\n", walkers.GlobalCtx.ProjectName)
+			fmt.Print(metrics.String())
 			fmt.Println()
 		},
 	}
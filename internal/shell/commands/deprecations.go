@@ -0,0 +1,99 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/i582/cfmt"
+
+	"github.com/i582/phpstats/internal/getter"
+	"github.com/i582/phpstats/internal/representator"
+	"github.com/i582/phpstats/internal/shell"
+	"github.com/i582/phpstats/internal/shell/flags"
+	"github.com/i582/phpstats/internal/stats/walkers"
+)
+
+func Deprecations() *shell.Executor {
+	deprecationsExecutor := &shell.Executor{
+		Name: "deprecations",
+		Help: "shows the symbols marked as @deprecated and the code that uses them directly or transitively",
+		Flags: flags.NewFlags(
+			&flags.Flag{
+				Name:      "-c",
+				WithValue: true,
+				Help:      "count in list",
+				Default:   "10",
+			},
+			&flags.Flag{
+				Name:      "-o",
+				WithValue: true,
+				Help:      "offset in list",
+				Default:   "0",
+			},
+			&flags.Flag{
+				Name: "--direct",
+				Help: "show only direct users",
+			},
+			&flags.Flag{
+				Name: "--used",
+				Help: "show only deprecated symbols that are used",
+			},
+			&flags.Flag{
+				Name: "--vendor",
+				Help: "show only deprecated symbols from vendor and built-in code",
+			},
+			&flags.Flag{
+				Name: "--project",
+				Help: "show only deprecated symbols from the project code",
+			},
+			&flags.Flag{
+				Name: "--all",
+				Help: "also show unused deprecated symbols from vendor and built-in code",
+			},
+			&flags.Flag{
+				Name:      "--json",
+				Help:      "path to the file where the data will be saved in json format",
+				WithValue: true,
+			},
+		),
+		Func: func(c *shell.Context) {
+			count := c.GetIntFlagValue("-c")
+			offset := c.GetIntFlagValue("-o")
+			onlyDirect := c.Flags.Contains("--direct")
+
+			toJson, jsonFile := handleOutputInJson(c)
+
+			list := getter.GetDeprecationsByOptions(walkers.GlobalCtx.Deprecations(onlyDirect), getter.DeprecationsGetOptions{
+				OnlyUsed:         c.Flags.Contains("--used"),
+				OnlyVendor:       c.Flags.Contains("--vendor"),
+				OnlyProject:      c.Flags.Contains("--project"),
+				WithUnusedVendor: c.Flags.Contains("--all"),
+				Count:            count,
+				Offset:           offset,
+			})
+
+			if toJson {
+				data, err := representator.GetPrettifyJsonDeprecationsRepr(list)
+				if err != nil {
+					c.Error(fmt.Errorf("writing list to file: %v", err))
+				}
+				fmt.Fprintln(jsonFile, data)
+				jsonFile.Close()
+				cfmt.Printf("The deprecations list was {{successfully}}::green saved to file {{'%s'}}::blue\n", jsonFile.Name())
+				return
+			}
+
+			if len(list) == 0 {
+				fmt.Println("No deprecated symbols found")
+				return
+			}
+
+			fmt.Printf("Showing %d deprecated symbols starting from %d\n\n", len(list), offset+1)
+
+			for _, d := range list {
+				fmt.Println(representator.GetStringDeprecationRepr(d))
+			}
+		},
+	}
+
+	return deprecationsExecutor
+}
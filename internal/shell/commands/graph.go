@@ -6,7 +6,6 @@ import (
 	"io/ioutil"
 	"log"
 	"path/filepath"
-	"strings"
 
 	"github.com/i582/phpstats/internal/graph"
 	"github.com/i582/phpstats/internal/grapher"
@@ -40,10 +39,26 @@ func Graph() *shell.Executor {
 				Help:      "comma-separated list of functions to be excluded from found paths",
 				WithValue: true,
 			},
+			&flags.Flag{
+				Name:      "--exclude-ns",
+				Help:      "comma-separated list of namespaces whose functions are excluded from found paths",
+				WithValue: true,
+			},
+			&flags.Flag{
+				Name:      "--exclude-regex",
+				Help:      "regular expression for the names of functions to be excluded from found paths",
+				WithValue: true,
+			},
 			&flags.Flag{
 				Name:      "--depth",
 				WithValue: true,
-				Help:      "max search depth",
+				Help:      "max number of calls in a path, 0 for no limit",
+				Default:   "0",
+			},
+			&flags.Flag{
+				Name:      "--paths",
+				WithValue: true,
+				Help:      "max number of the shortest paths to find",
 				Default:   "10",
 			},
 			&flags.Flag{
@@ -55,6 +70,7 @@ func Graph() *shell.Executor {
 				Name: "--web",
 				Help: "show graph in browser",
 			},
+			callGraphModeFlag(),
 		),
 		Func: func(c *shell.Context) {
 			inBrowser := c.Flags.Contains("--web")
@@ -63,21 +79,12 @@ func Graph() *shell.Executor {
 				return
 			}
 
-			maxDepth := c.GetIntFlagValue("--depth")
 			parentFunctionName := c.GetFlagValue("--parent")
 			childFunctionName := c.GetFlagValue("--child")
-			excludedFunctionsSlice := strings.Split(c.GetFlagValue("--exclude"), ",")
-			excludedFunctions := relations.ReachabilityExcludedMap{}
-
-			if c.GetFlagValue("--exclude") != "" {
-				for _, excludedFunctionName := range excludedFunctionsSlice {
-					excludedFunction, err := walkers.GlobalCtx.Functions.GetFunctionByPartOfName(excludedFunctionName)
-					if err != nil {
-						c.Error(err)
-						return
-					}
-					excludedFunctions[excludedFunction] = excludedFunction
-				}
+
+			opts, ok := handleReachabilityOptions(c)
+			if !ok {
+				return
 			}
 
 			parentFunction, err := walkers.GlobalCtx.Functions.GetFunctionByPartOfName(parentFunctionName)
@@ -92,7 +99,7 @@ func Graph() *shell.Executor {
 				return
 			}
 
-			rel := relations.GetReachabilityFunction(parentFunction, childFunction, excludedFunctions, maxDepth)
+			rel := relations.GetReachabilityFunction(parentFunction, childFunction, opts)
 
 			graphData := g.FunctionReachability(rel)
 			handleGraphOutputWithWeb(c, inBrowser, graphData)
@@ -232,6 +239,7 @@ func Graph() *shell.Executor {
 				Name: "--web",
 				Help: "show graph in browser",
 			},
+			callGraphModeFlag(),
 		),
 		Func: func(c *shell.Context) {
 			recursiveLevel := c.GetIntFlagValue("-r")
@@ -241,13 +249,18 @@ func Graph() *shell.Executor {
 				return
 			}
 
+			mode, ok := handleCallGraphMode(c)
+			if !ok {
+				return
+			}
+
 			fun, err := walkers.GlobalCtx.Functions.GetFunctionByPartOfName(c.Args[0])
 			if err != nil {
 				c.Error(err)
 				return
 			}
 
-			graphData := g.FuncDeps(fun, recursiveLevel)
+			graphData := g.FuncDeps(fun, recursiveLevel, mode)
 			handleGraphOutputWithWeb(c, inBrowser, graphData)
 		},
 	}
@@ -257,6 +270,50 @@ func Graph() *shell.Executor {
 		Help:      "building a graph lcom4 of connected class components",
 		WithValue: true,
 		CountArgs: 1,
+		Flags: flags.NewFlags(
+			&flags.Flag{
+				Name:      "-o",
+				WithValue: true,
+				Help:      "output file",
+			},
+			&flags.Flag{
+				Name: "--split",
+				Help: "show the suggested split of the class by connected components",
+			},
+			&flags.Flag{
+				Name: "--web",
+				Help: "show graph in browser",
+			},
+		),
+		Func: func(c *shell.Context) {
+			inBrowser := c.Flags.Contains("--web")
+			split := c.Flags.Contains("--split")
+
+			if !validateOutputPath(c, inBrowser) {
+				return
+			}
+
+			class, err := walkers.GlobalCtx.Classes.GetClassByPartOfName(c.Args[0])
+			if err != nil {
+				c.Error(err)
+				return
+			}
+
+			var graphData string
+			if split {
+				graphData = g.Lcom4Split(class)
+			} else {
+				graphData = g.Lcom4(class)
+			}
+			handleGraphOutputWithWeb(c, inBrowser, graphData)
+		},
+	}
+
+	graphExceptionExecutor := &shell.Executor{
+		Name:      "exception",
+		Help:      "building a graph of exception propagation through the called functions",
+		WithValue: true,
+		CountArgs: 1,
 		Flags: flags.NewFlags(
 			&flags.Flag{
 				Name:      "-o",
@@ -281,7 +338,45 @@ func Graph() *shell.Executor {
 				return
 			}
 
-			graphData := g.Lcom4(class)
+			if class.ThrownIn.Len() == 0 && class.CaughtIn.Len() == 0 {
+				c.Error(fmt.Errorf("class %s is never thrown or caught", class.Name))
+				return
+			}
+
+			graphData := g.ExceptionPropagation(class, walkers.GlobalCtx.Functions)
+			handleGraphOutputWithWeb(c, inBrowser, graphData)
+		},
+	}
+
+	graphGlobalsExecutor := &shell.Executor{
+		Name: "globals",
+		Help: "building a graph of functions sharing global state",
+		Flags: flags.NewFlags(
+			&flags.Flag{
+				Name:      "-o",
+				WithValue: true,
+				Help:      "output file",
+			},
+			&flags.Flag{
+				Name:      "--name",
+				WithValue: true,
+				Help:      "show only globals whose name contains the value",
+			},
+			&flags.Flag{
+				Name: "--web",
+				Help: "show graph in browser",
+			},
+		),
+		Func: func(c *shell.Context) {
+			inBrowser := c.Flags.Contains("--web")
+
+			if !validateOutputPath(c, inBrowser) {
+				return
+			}
+
+			name := c.GetFlagValue("--name")
+
+			graphData := g.SharedGlobals(walkers.GlobalCtx.Globals, name)
 			handleGraphOutputWithWeb(c, inBrowser, graphData)
 		},
 	}
@@ -368,6 +463,51 @@ func Graph() *shell.Executor {
 		},
 	}
 
+	graphImpactExecutor := &shell.Executor{
+		Name:      "impact",
+		Help:      "building a graph of the symbols that may be affected by the change of the symbol",
+		WithValue: true,
+		CountArgs: 1,
+		Flags: flags.NewFlags(
+			&flags.Flag{
+				Name:      "--kind",
+				WithValue: true,
+				Help:      "kind of the symbol: func, class, const, field or file, by default it is detected by the name",
+			},
+			&flags.Flag{
+				Name:      "--depth",
+				WithValue: true,
+				Help:      "max distance of the affected symbols, 0 for no limit",
+				Default:   "0",
+			},
+			&flags.Flag{
+				Name:      "-o",
+				WithValue: true,
+				Help:      "output file",
+			},
+			&flags.Flag{
+				Name: "--web",
+				Help: "show graph in browser",
+			},
+		),
+		Func: func(c *shell.Context) {
+			inBrowser := c.Flags.Contains("--web")
+
+			if !validateOutputPath(c, inBrowser) {
+				return
+			}
+
+			res, err := impactOfSymbol(c.Args[0], c.GetFlagValue("--kind"), int(c.GetIntFlagValue("--depth")))
+			if err != nil {
+				c.Error(err)
+				return
+			}
+
+			graphData := g.Impact(c.Args[0], res)
+			handleGraphOutputWithWeb(c, inBrowser, graphData)
+		},
+	}
+
 	graphExecutor := &shell.Executor{
 		Name: "graph",
 		Help: "building graphs",
@@ -381,8 +521,11 @@ func Graph() *shell.Executor {
 	graphExecutor.AddExecutor(graphClassExecutor)
 	graphExecutor.AddExecutor(graphFuncExecutor)
 	graphExecutor.AddExecutor(graphLcom4Executor)
+	graphExecutor.AddExecutor(graphExceptionExecutor)
+	graphExecutor.AddExecutor(graphGlobalsExecutor)
 	graphExecutor.AddExecutor(graphNamespaceStructureExecutor)
 	graphExecutor.AddExecutor(graphNamespaceExecutor)
+	graphExecutor.AddExecutor(graphImpactExecutor)
 
 	return graphExecutor
 }
@@ -0,0 +1,237 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/i582/cfmt"
+
+	"github.com/i582/phpstats/internal/representator"
+	"github.com/i582/phpstats/internal/shell"
+	"github.com/i582/phpstats/internal/shell/flags"
+	"github.com/i582/phpstats/internal/stats/impact"
+	"github.com/i582/phpstats/internal/stats/symbols"
+	"github.com/i582/phpstats/internal/stats/walkers"
+)
+
+func Impact() *shell.Executor {
+	impactExecutor := &shell.Executor{
+		Name:      "impact",
+		Help:      "shows the functions, classes and files that may be affected by the change of the symbol",
+		WithValue: true,
+		CountArgs: 1,
+		Flags: flags.NewFlags(
+			&flags.Flag{
+				Name:      "--kind",
+				WithValue: true,
+				Help:      "kind of the symbol: func, class, const, field or file, by default it is detected by the name",
+			},
+			&flags.Flag{
+				Name:      "--depth",
+				WithValue: true,
+				Help:      "max distance of the affected symbols, 0 for no limit",
+				Default:   "0",
+			},
+			&flags.Flag{
+				Name: "--tests",
+				Help: "show the affected tests",
+			},
+			&flags.Flag{
+				Name: "--table",
+				Help: "show the affected symbols in a table instead of a tree",
+			},
+			&flags.Flag{
+				Name:      "-c",
+				WithValue: true,
+				Help:      "count in table",
+				Default:   "50",
+			},
+			&flags.Flag{
+				Name:      "-o",
+				WithValue: true,
+				Help:      "offset in table",
+				Default:   "0",
+			},
+			&flags.Flag{
+				Name:      "--json",
+				Help:      "path to the file where the data will be saved in json format",
+				WithValue: true,
+			},
+			formatFlag(),
+			formatOutFlag(),
+		),
+		Func: func(c *shell.Context) {
+			count := c.GetIntFlagValue("-c")
+			offset := c.GetIntFlagValue("-o")
+			withTests := c.Flags.Contains("--tests")
+
+			res, err := impactOfSymbol(c.Args[0], c.GetFlagValue("--kind"), int(c.GetIntFlagValue("--depth")))
+			if err != nil {
+				c.Error(err)
+				return
+			}
+
+			data := representator.ImpactToData(res, withTests)
+
+			toJson, jsonFile := handleOutputInJson(c)
+			if toJson {
+				data, err := representator.GetPrettifyJsonImpactRepr(data)
+				if err != nil {
+					c.Error(fmt.Errorf("writing impact to file: %v", err))
+				}
+				fmt.Fprintln(jsonFile, data)
+				jsonFile.Close()
+				cfmt.Printf("The impact was {{successfully}}::green saved to file {{'%s'}}::blue\n", jsonFile.Name())
+				return
+			}
+
+			if !c.Flags.Contains("--table") && !exportTable(c) {
+				fmt.Println(representator.GetStringImpactRepr(data))
+				return
+			}
+
+			nodes := data.Affected
+			if withTests {
+				nodes = append(nodes, data.Tests...)
+			}
+			total := len(nodes)
+
+			if offset < 0 {
+				offset = 0
+			}
+			if count+offset < int64(len(nodes)) {
+				nodes = nodes[:count+offset]
+			}
+			if offset < int64(len(nodes)) {
+				nodes = nodes[offset:]
+			} else {
+				nodes = nil
+			}
+
+			summary := fmt.Sprintf("Showing %d affected symbols out of %d starting from %d\n\n", len(nodes), total, offset+1)
+			printTable(c, summary, representator.ImpactToTable(nodes, offset))
+		},
+	}
+
+	return impactExecutor
+}
+
+// impactOfSymbol finds the symbol by name and returns the symbols affected by its change.
+//
+// If the kind is not set, the file is detected by the .php extension, the
+// class members by "Class::member" (the fields by "Class::$field"),
+// otherwise the classes are searched before the functions.
+func impactOfSymbol(name, kind string, maxDepth int) (*impact.Result, error) {
+	opt := walkers.GlobalCtx.ImpactOptions(maxDepth)
+
+	if kind == "" {
+		switch {
+		case strings.HasSuffix(strings.ToLower(name), ".php"):
+			kind = "file"
+		case strings.Contains(name, "::$"):
+			kind = "field"
+		}
+	}
+
+	switch kind {
+	case "file":
+		file, err := walkers.GlobalCtx.Files.GetFileByPartOfName(name)
+		if err != nil {
+			return nil, err
+		}
+		return impact.ForFile(file, opt), nil
+
+	case "field":
+		class, member, err := splitMember(name)
+		if err != nil {
+			return nil, err
+		}
+		field, ok := class.Fields.Get(symbols.NewFieldKey(strings.TrimPrefix(member, "$"), class.Name))
+		if !ok {
+			return nil, fmt.Errorf("field %s not found in class %s", member, class.Name)
+		}
+		return impact.ForField(field, opt), nil
+
+	case "const":
+		class, member, err := splitMember(name)
+		if err != nil {
+			return nil, err
+		}
+		constant, ok := class.Constants.Get(symbols.NewConstantKey(member, class))
+		if !ok {
+			return nil, fmt.Errorf("constant %s not found in class %s", member, class.Name)
+		}
+		return impact.ForConstant(constant, opt), nil
+
+	case "func":
+		fn, err := walkers.GlobalCtx.Functions.GetFunctionByPartOfName(name)
+		if err != nil {
+			return nil, err
+		}
+		return impact.ForFunction(fn, opt), nil
+
+	case "class":
+		class, err := findImpactClass(name)
+		if err != nil {
+			return nil, err
+		}
+		return impact.ForClass(class, opt), nil
+
+	case "":
+	default:
+		return nil, fmt.Errorf("unknown kind '%s', available kinds: func, class, const, field, file", kind)
+	}
+
+	if strings.Contains(name, "::") {
+		class, member, err := splitMember(name)
+		if err != nil {
+			return nil, err
+		}
+		if method, ok := class.Methods.Get(symbols.NewMethodKey(member, class.Name)); ok {
+			return impact.ForFunction(method, opt), nil
+		}
+		if constant, ok := class.Constants.Get(symbols.NewConstantKey(member, class)); ok {
+			return impact.ForConstant(constant, opt), nil
+		}
+		return nil, fmt.Errorf("method or constant %s not found in class %s", member, class.Name)
+	}
+
+	if class, ok := walkers.GlobalCtx.Classes.Get(`\` + strings.TrimPrefix(name, `\`)); ok {
+		return impact.ForClass(class, opt), nil
+	}
+	if fn, ok := walkers.GlobalCtx.Functions.Get(symbols.NewFuncKey(`\` + strings.TrimPrefix(name, `\`))); ok {
+		return impact.ForFunction(fn, opt), nil
+	}
+	if class, err := walkers.GlobalCtx.Classes.GetAnyTypeClassByPartOfName(name); err == nil {
+		return impact.ForClass(class, opt), nil
+	}
+	if fn, err := walkers.GlobalCtx.Functions.GetFunctionByPartOfName(name); err == nil {
+		return impact.ForFunction(fn, opt), nil
+	}
+
+	return nil, fmt.Errorf("symbol %s not found", name)
+}
+
+// splitMember returns the class and the member name from the "Class::member" name.
+func splitMember(name string) (*symbols.Class, string, error) {
+	index := strings.LastIndex(name, "::")
+	if index == -1 {
+		return nil, "", fmt.Errorf("expected name in the form Class::member, got %s", name)
+	}
+
+	class, err := findImpactClass(name[:index])
+	if err != nil {
+		return nil, "", err
+	}
+
+	return class, name[index+2:], nil
+}
+
+// findImpactClass returns the class with exactly the passed name,
+// otherwise the first class whose name contains it.
+func findImpactClass(name string) (*symbols.Class, error) {
+	if class, ok := walkers.GlobalCtx.Classes.Get(`\` + strings.TrimPrefix(name, `\`)); ok {
+		return class, nil
+	}
+	return walkers.GlobalCtx.Classes.GetAnyTypeClassByPartOfName(name)
+}
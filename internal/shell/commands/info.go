@@ -3,9 +3,14 @@ package commands
 import (
 	"fmt"
 
+	"github.com/i582/cfmt"
+
+	"github.com/i582/phpstats/internal/getter"
 	"github.com/i582/phpstats/internal/representator"
 	"github.com/i582/phpstats/internal/shell"
 	"github.com/i582/phpstats/internal/shell/flags"
+	"github.com/i582/phpstats/internal/stats/overrides"
+	"github.com/i582/phpstats/internal/stats/symbols"
 	"github.com/i582/phpstats/internal/stats/walkers"
 )
 
@@ -14,18 +19,89 @@ func Info() *shell.Executor {
 		Name:      "class",
 		Help:      "shows info about a specific class",
 		WithValue: true,
-		Flags:     flags.NewFlags(),
+		Flags: flags.NewFlags(
+			&flags.Flag{
+				Name: "--cohesion",
+				Help: "show the connected components of the LCOM4 graph",
+			},
+			&flags.Flag{
+				Name: "--deps",
+				Help: "show the dependencies of the class by kind",
+			},
+			&flags.Flag{
+				Name: "--overrides",
+				Help: "show the overridden and implemented methods and their contract problems",
+			},
+			&flags.Flag{
+				Name:      "--json",
+				Help:      "path to the file where the data will be saved in json format",
+				WithValue: true,
+			},
+			formatFlag(),
+			formatOutFlag(),
+		),
 		CountArgs: 1,
 		Func: func(c *shell.Context) {
+			withCohesion := c.Flags.Contains("--cohesion")
+			withDeps := c.Flags.Contains("--deps")
+			withOverrides := c.Flags.Contains("--overrides")
+
 			class, err := walkers.GlobalCtx.Classes.GetClassByPartOfName(c.Args[0])
 			if err != nil {
 				c.Error(err)
 				return
 			}
+
+			toJson, jsonFile := handleOutputInJson(c)
+			if toJson {
+				data, err := representator.GetPrettifyJsonClassWithCohesionRepr(class)
+				if err != nil {
+					c.Error(fmt.Errorf("writing class info to file: %v", err))
+				}
+				fmt.Fprintln(jsonFile, data)
+				jsonFile.Close()
+				cfmt.Printf("The class info was {{successfully}}::green saved to file {{'%s'}}::blue\n", jsonFile.Name())
+				return
+			}
+
+			if exportTable(c) {
+				if !withCohesion && !withDeps && !withOverrides {
+					withCohesion, withDeps, withOverrides = true, true, true
+				}
+
+				var tables []*representator.Table
+				if withCohesion {
+					tables = append(tables, representator.ClassCohesionToTable(class))
+				}
+				if withDeps {
+					tables = append(tables, representator.ClassDependenciesToTable(class))
+				}
+				if withOverrides {
+					table := representator.OverridesToTable(classOverrides(class), 0)
+					table.Title = "Overridden and implemented methods of " + class.Name
+					tables = append(tables, table)
+				}
+
+				exportTables(c, tables...)
+				return
+			}
+
 			fmt.Printf("Show information about %s class\n\n", class.Name)
 
 			data := representator.GetStringClassRepr(class)
 			fmt.Println(data)
+
+			if withCohesion {
+				fmt.Println(representator.GetStringClassCohesionRepr(class))
+			}
+
+			if withDeps {
+				fmt.Println(representator.GetStringClassDependenciesRepr(class))
+			}
+
+			if withOverrides {
+				fmt.Println(representator.GetStringClassOverridesRepr(classOverrides(class)))
+			}
 		},
 	}
 
@@ -73,7 +149,10 @@ func Info() *shell.Executor {
 		Help:      "shows info about a specific function or method",
 		WithValue: true,
 		Aliases:   []string{"method"},
-		Flags:     flags.NewFlags(),
+		Flags: flags.NewFlags(
+			formatFlag(),
+			formatOutFlag(),
+		),
 		CountArgs: 1,
 		Func: func(c *shell.Context) {
 			fn, err := walkers.GlobalCtx.Functions.GetFunctionByPartOfName(c.Args[0])
@@ -81,10 +160,18 @@ func Info() *shell.Executor {
 				c.Error(err)
 				return
 			}
+
+			if exportTable(c) {
+				exportTables(c, representator.FunctionExceptionsToTable(fn))
+				return
+			}
+
 			fmt.Printf("Show information about %s function\n\n", fn.Name.String())
 
 			data := representator.GetStringFunctionRepr(fn)
 			fmt.Println(data)
+
+			fmt.Println(representator.GetStringFunctionExceptionsRepr(fn))
 		},
 	}
 
@@ -143,3 +230,11 @@ func Info() *shell.Executor {
 
 	return infoExecutor
 }
+
+// classOverrides returns the overridden and implemented methods of the class.
+func classOverrides(class *symbols.Class) []*overrides.Override {
+	return getter.GetOverridesByOptions(walkers.GlobalCtx.Overrides, getter.OverridesGetOptions{
+		Class: class,
+		Count: int64(len(walkers.GlobalCtx.Overrides)),
+	})
+}
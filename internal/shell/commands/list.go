@@ -2,6 +2,7 @@ package commands
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/i582/cfmt"
 
@@ -32,7 +33,7 @@ func List() *shell.Executor {
 			&flags.Flag{
 				Name:      "--sort",
 				WithValue: true,
-				Help:      "column number by which sorting will be performed",
+				Help:      "column number or name by which sorting will be performed",
 				Default:   "2",
 			},
 			&flags.Flag{
@@ -43,19 +44,74 @@ func List() *shell.Executor {
 				Name: "-e",
 				Help: "show embedded functions",
 			},
+			&flags.Flag{
+				Name: "--tests",
+				Help: "include tests in list",
+			},
+			&flags.Flag{
+				Name: "--anonymous",
+				Help: "include closures and arrow functions in list",
+			},
+			&flags.Flag{
+				Name:      "--min-crap",
+				WithValue: true,
+				Help:      "show only functions with a CRAP score not less than the specified one (requires coverage)",
+			},
+			&flags.Flag{
+				Name:      "--effect",
+				WithValue: true,
+				Help:      "show only functions that may perform the side effect (for example, db, filesystem, network, output)",
+			},
+			&flags.Flag{
+				Name: "--pure",
+				Help: "show only functions without known side effects",
+			},
+			&flags.Flag{
+				Name: "--unused",
+				Help: "show only functions that are never called (dead code candidates)",
+			},
+			callGraphModeFlag(),
+			&flags.Flag{
+				Name:      "--max-coverage",
+				WithValue: true,
+				Help:      "show only functions with coverage percentage not greater than the specified one (requires coverage)",
+			},
+			whereFlag(),
+			namespaceFilterFlag(),
+			pathFilterFlag(),
 			&flags.Flag{
 				Name:      "--json",
 				Help:      "path to the file where the data will be saved in json format",
 				WithValue: true,
 			},
+			formatFlag(),
+			formatOutFlag(),
 		),
 		Func: func(c *shell.Context) {
 			count := c.GetIntFlagValue("-c")
 			offset := c.GetIntFlagValue("-o")
-			sortColumn := c.GetIntFlagValue("--sort")
 			reverseSort := c.Flags.Contains("-r")
 
+			sortColumn, where, ok := handleListFilters(c, representator.FunctionColumns)
+			if !ok {
+				return
+			}
+
 			withEmbeddedFuncs := c.Flags.Contains("-e")
+			withAnonymous := c.Flags.Contains("--anonymous")
+			withTests := handleWithTests(c)
+
+			coverageOpts, err := handleCoverageThresholds(c)
+			if err != nil {
+				c.Error(err)
+				return
+			}
+
+			mode, ok := handleCallGraphMode(c)
+			if !ok {
+				return
+			}
+
 			toJson, jsonFile := handleOutputInJson(c)
 
 			funcs := getter.GetFunctionsByOptions(walkers.GlobalCtx.Functions, getter.FunctionsGetOptions{
@@ -63,7 +119,19 @@ func List() *shell.Executor {
 				Count:             count,
 				Offset:            offset,
 				WithEmbeddedFuncs: withEmbeddedFuncs,
+				WithTests:         withTests,
+				WithAnonymous:     withAnonymous,
+				MinCrap:           coverageOpts.MinCrap,
+				UseMaxCoverage:    coverageOpts.UseMaxCoverage,
+				MaxCoverage:       coverageOpts.MaxCoverage,
+				Effect:            c.GetFlagValue("--effect"),
+				OnlyPure:          c.Flags.Contains("--pure"),
+				OnlyUnused:        c.Flags.Contains("--unused"),
+				Mode:              mode,
 				SortColumn:        sortColumn,
+				Where:             where,
+				Namespace:         c.GetFlagValue("--namespace"),
+				Path:              c.GetFlagValue("--path"),
 				ReverseSort:       reverseSort,
 			})
 
@@ -76,9 +144,12 @@ func List() *shell.Executor {
 				jsonFile.Close()
 				cfmt.Printf("The functions list was {{successfully}}::green saved to file {{'%s'}}::blue\n", jsonFile.Name())
 			} else {
-				fmt.Printf("Showing %d functions out of %d starting from %d\n\n", len(funcs), walkers.GlobalCtx.Functions.CountFunctions(withEmbeddedFuncs), offset+1)
-				data := representator.GetTableFunctionsRepr(funcs, offset)
-				fmt.Println(data)
+				total := metricsFunctions(withTests).CountFunctions(withEmbeddedFuncs)
+				if withAnonymous {
+					total += metricsFunctions(withTests).CountAnonymousFunctions()
+				}
+
+				printTable(c, fmt.Sprintf("Showing %d functions out of %d starting from %d\n\n", len(funcs), total, offset+1), representator.FunctionsToTable(funcs, offset))
 			}
 		},
 	}
@@ -102,33 +173,94 @@ func List() *shell.Executor {
 			&flags.Flag{
 				Name:      "--sort",
 				WithValue: true,
-				Help:      "column number by which sorting will be performed",
+				Help:      "column number or name by which sorting will be performed",
 				Default:   "2",
 			},
 			&flags.Flag{
 				Name: "-r",
 				Help: "reverse sort",
 			},
+			&flags.Flag{
+				Name: "--tests",
+				Help: "include tests in list",
+			},
+			&flags.Flag{
+				Name:      "--min-crap",
+				WithValue: true,
+				Help:      "show only functions with a CRAP score not less than the specified one (requires coverage)",
+			},
+			&flags.Flag{
+				Name:      "--effect",
+				WithValue: true,
+				Help:      "show only functions that may perform the side effect (for example, db, filesystem, network, output)",
+			},
+			&flags.Flag{
+				Name: "--pure",
+				Help: "show only functions without known side effects",
+			},
+			&flags.Flag{
+				Name: "--unused",
+				Help: "show only functions that are never called (dead code candidates)",
+			},
+			callGraphModeFlag(),
+			&flags.Flag{
+				Name:      "--max-coverage",
+				WithValue: true,
+				Help:      "show only functions with coverage percentage not greater than the specified one (requires coverage)",
+			},
+			whereFlag(),
+			namespaceFilterFlag(),
+			pathFilterFlag(),
 			&flags.Flag{
 				Name:      "--json",
 				Help:      "path to the file where the data will be saved in json format",
 				WithValue: true,
 			},
+			formatFlag(),
+			formatOutFlag(),
 		),
 		Func: func(c *shell.Context) {
 			count := c.GetIntFlagValue("-c")
 			offset := c.GetIntFlagValue("-o")
-			sortColumn := c.GetIntFlagValue("--sort")
 			reverseSort := c.Flags.Contains("-r")
 
+			sortColumn, where, ok := handleListFilters(c, representator.FunctionColumns)
+			if !ok {
+				return
+			}
+
+			withTests := handleWithTests(c)
+
+			coverageOpts, err := handleCoverageThresholds(c)
+			if err != nil {
+				c.Error(err)
+				return
+			}
+
+			mode, ok := handleCallGraphMode(c)
+			if !ok {
+				return
+			}
+
 			toJson, jsonFile := handleOutputInJson(c)
 
 			methods := getter.GetFunctionsByOptions(walkers.GlobalCtx.Functions, getter.FunctionsGetOptions{
-				OnlyMethods: true,
-				Count:       count,
-				Offset:      offset,
-				SortColumn:  sortColumn,
-				ReverseSort: reverseSort,
+				OnlyMethods:    true,
+				WithTests:      withTests,
+				MinCrap:        coverageOpts.MinCrap,
+				UseMaxCoverage: coverageOpts.UseMaxCoverage,
+				MaxCoverage:    coverageOpts.MaxCoverage,
+				Effect:         c.GetFlagValue("--effect"),
+				OnlyPure:       c.Flags.Contains("--pure"),
+				OnlyUnused:     c.Flags.Contains("--unused"),
+				Mode:           mode,
+				Count:          count,
+				Offset:         offset,
+				SortColumn:     sortColumn,
+				Where:          where,
+				Namespace:      c.GetFlagValue("--namespace"),
+				Path:           c.GetFlagValue("--path"),
+				ReverseSort:    reverseSort,
 			})
 
 			if toJson {
@@ -140,9 +272,7 @@ func List() *shell.Executor {
 				jsonFile.Close()
 				cfmt.Printf("The methods list was {{successfully}}::green saved to file {{'%s'}}::blue\n", jsonFile.Name())
 			} else {
-				fmt.Printf("Showing %d methods out of %d starting from %d\n\n", len(methods), walkers.GlobalCtx.Functions.CountMethods(), offset+1)
-				data := representator.GetTableFunctionsRepr(methods, offset)
-				fmt.Println(data)
+				printTable(c, fmt.Sprintf("Showing %d methods out of %d starting from %d\n\n", len(methods), metricsFunctions(withTests).CountMethods(), offset+1), representator.FunctionsToTable(methods, offset))
 			}
 		},
 	}
@@ -166,31 +296,41 @@ func List() *shell.Executor {
 			&flags.Flag{
 				Name:      "--sort",
 				WithValue: true,
-				Help:      "column number by which sorting will be performed",
+				Help:      "column number or name by which sorting will be performed",
 				Default:   "2",
 			},
 			&flags.Flag{
 				Name: "-r",
 				Help: "reverse sort",
 			},
+			whereFlag(),
+			pathFilterFlag(),
 			&flags.Flag{
 				Name:      "--json",
 				Help:      "path to the file where the data will be saved in json format",
 				WithValue: true,
 			},
+			formatFlag(),
+			formatOutFlag(),
 		),
 		Func: func(c *shell.Context) {
 			count := c.GetIntFlagValue("-c")
 			offset := c.GetIntFlagValue("-o")
-			sortColumn := c.GetIntFlagValue("--sort")
 			reverseSort := c.Flags.Contains("-r")
 
+			sortColumn, where, ok := handleListFilters(c, representator.FileColumns)
+			if !ok {
+				return
+			}
+
 			toJson, jsonFile := handleOutputInJson(c)
 
 			files := getter.GetFilesByOptions(walkers.GlobalCtx.Files, getter.FilesGetOptions{
 				Count:       count,
 				Offset:      offset,
 				SortColumn:  sortColumn,
+				Where:       where,
+				Path:        c.GetFlagValue("--path"),
 				ReverseSort: reverseSort,
 			})
 
@@ -203,9 +343,7 @@ func List() *shell.Executor {
 				jsonFile.Close()
 				cfmt.Printf("The files list was {{successfully}}::green saved to file {{'%s'}}::blue\n", jsonFile.Name())
 			} else {
-				fmt.Printf("Showing %d files out of %d starting from %d\n\n", len(files), walkers.GlobalCtx.Files.Len(), offset+1)
-				data := representator.GetTableFilesRepr(files, offset)
-				fmt.Println(data)
+				printTable(c, fmt.Sprintf("Showing %d files out of %d starting from %d\n\n", len(files), walkers.GlobalCtx.Files.Len(), offset+1), representator.FilesToTable(files, offset))
 			}
 		},
 	}
@@ -229,32 +367,50 @@ func List() *shell.Executor {
 			&flags.Flag{
 				Name:      "--sort",
 				WithValue: true,
-				Help:      "column number by which sorting will be performed",
+				Help:      "column number or name by which sorting will be performed",
 				Default:   "2",
 			},
 			&flags.Flag{
 				Name: "-r",
 				Help: "reverse sort",
 			},
+			&flags.Flag{
+				Name: "--tests",
+				Help: "include tests in list",
+			},
+			whereFlag(),
+			namespaceFilterFlag(),
+			pathFilterFlag(),
 			&flags.Flag{
 				Name:      "--json",
 				Help:      "path to the file where the data will be saved in json format",
 				WithValue: true,
 			},
+			formatFlag(),
+			formatOutFlag(),
 		),
 		Func: func(c *shell.Context) {
 			count := c.GetIntFlagValue("-c")
 			offset := c.GetIntFlagValue("-o")
-			sortColumn := c.GetIntFlagValue("--sort")
 			reverseSort := c.Flags.Contains("-r")
 
+			sortColumn, where, ok := handleListFilters(c, representator.ClassColumns)
+			if !ok {
+				return
+			}
+
+			withTests := handleWithTests(c)
 			toJson, jsonFile := handleOutputInJson(c)
 
 			classes := getter.GetClassesByOption(walkers.GlobalCtx.Classes, getter.ClassesGetOptions{
 				OnlyClasses: true,
+				WithTests:   withTests,
 				Count:       count,
 				Offset:      offset,
 				SortColumn:  sortColumn,
+				Where:       where,
+				Namespace:   c.GetFlagValue("--namespace"),
+				Path:        c.GetFlagValue("--path"),
 				ReverseSort: reverseSort,
 			})
 
@@ -267,9 +423,7 @@ func List() *shell.Executor {
 				jsonFile.Close()
 				cfmt.Printf("The classes list was {{successfully}}::green saved to file {{'%s'}}::blue\n", jsonFile.Name())
 			} else {
-				fmt.Printf("Showing %d classes out of %d starting from %d\n\n", len(classes), walkers.GlobalCtx.Classes.CountClasses(), offset+1)
-				data := representator.GetTableClassesRepr(classes, offset)
-				fmt.Println(data)
+				printTable(c, fmt.Sprintf("Showing %d classes out of %d starting from %d\n\n", len(classes), metricsClasses(withTests).CountClasses(), offset+1), representator.ClassesToTable(classes, offset))
 			}
 		},
 	}
@@ -294,25 +448,34 @@ func List() *shell.Executor {
 			&flags.Flag{
 				Name:      "--sort",
 				WithValue: true,
-				Help:      "column number by which sorting will be performed",
+				Help:      "column number or name by which sorting will be performed",
 				Default:   "2",
 			},
 			&flags.Flag{
 				Name: "-r",
 				Help: "reverse sort",
 			},
+			whereFlag(),
+			namespaceFilterFlag(),
+			pathFilterFlag(),
 			&flags.Flag{
 				Name:      "--json",
 				Help:      "path to the file where the data will be saved in json format",
 				WithValue: true,
 			},
+			formatFlag(),
+			formatOutFlag(),
 		),
 		Func: func(c *shell.Context) {
 			count := c.GetIntFlagValue("-c")
 			offset := c.GetIntFlagValue("-o")
-			sortColumn := c.GetIntFlagValue("--sort")
 			reverseSort := c.Flags.Contains("-r")
 
+			sortColumn, where, ok := handleListFilters(c, representator.ClassColumns)
+			if !ok {
+				return
+			}
+
 			toJson, jsonFile := handleOutputInJson(c)
 
 			ifaces := getter.GetClassesByOption(walkers.GlobalCtx.Classes, getter.ClassesGetOptions{
@@ -320,6 +483,9 @@ func List() *shell.Executor {
 				Count:          count,
 				Offset:         offset,
 				SortColumn:     sortColumn,
+				Where:          where,
+				Namespace:      c.GetFlagValue("--namespace"),
+				Path:           c.GetFlagValue("--path"),
 				ReverseSort:    reverseSort,
 			})
 
@@ -332,9 +498,7 @@ func List() *shell.Executor {
 				jsonFile.Close()
 				cfmt.Printf("The interfaces list was {{successfully}}::green saved to file {{'%s'}}::blue\n", jsonFile.Name())
 			} else {
-				fmt.Printf("Showing %d interfaces out of %d starting from %d\n\n", len(ifaces), walkers.GlobalCtx.Classes.CountIfaces(), offset+1)
-				data := representator.GetTableClassesRepr(ifaces, offset)
-				fmt.Println(data)
+				printTable(c, fmt.Sprintf("Showing %d interfaces out of %d starting from %d\n\n", len(ifaces), walkers.GlobalCtx.Classes.CountIfaces(), offset+1), representator.ClassesToTable(ifaces, offset))
 			}
 		},
 	}
@@ -358,32 +522,50 @@ func List() *shell.Executor {
 			&flags.Flag{
 				Name:      "--sort",
 				WithValue: true,
-				Help:      "column number by which sorting will be performed",
+				Help:      "column number or name by which sorting will be performed",
 				Default:   "2",
 			},
 			&flags.Flag{
 				Name: "-r",
 				Help: "reverse sort",
 			},
+			&flags.Flag{
+				Name: "--tests",
+				Help: "include tests in list",
+			},
+			whereFlag(),
+			namespaceFilterFlag(),
+			pathFilterFlag(),
 			&flags.Flag{
 				Name:      "--json",
 				Help:      "path to the file where the data will be saved in json format",
 				WithValue: true,
 			},
+			formatFlag(),
+			formatOutFlag(),
 		),
 		Func: func(c *shell.Context) {
 			count := c.GetIntFlagValue("-c")
 			offset := c.GetIntFlagValue("-o")
-			sortColumn := c.GetIntFlagValue("--sort")
 			reverseSort := c.Flags.Contains("-r")
 
+			sortColumn, where, ok := handleListFilters(c, representator.ClassColumns)
+			if !ok {
+				return
+			}
+
+			withTests := handleWithTests(c)
 			toJson, jsonFile := handleOutputInJson(c)
 
 			traits := getter.GetClassesByOption(walkers.GlobalCtx.Classes, getter.ClassesGetOptions{
 				OnlyTraits:  true,
+				WithTests:   withTests,
 				Count:       count,
 				Offset:      offset,
 				SortColumn:  sortColumn,
+				Where:       where,
+				Namespace:   c.GetFlagValue("--namespace"),
+				Path:        c.GetFlagValue("--path"),
 				ReverseSort: reverseSort,
 			})
 
@@ -396,9 +578,7 @@ func List() *shell.Executor {
 				jsonFile.Close()
 				cfmt.Printf("The traits list was {{successfully}}::green saved to file {{'%s'}}::blue\n", jsonFile.Name())
 			} else {
-				fmt.Printf("Showing %d traits out of %d starting from %d\n\n", len(traits), walkers.GlobalCtx.Classes.CountTraits(), offset+1)
-				data := representator.GetTableClassesRepr(traits, offset)
-				fmt.Println(data)
+				printTable(c, fmt.Sprintf("Showing %d traits out of %d starting from %d\n\n", len(traits), metricsClasses(withTests).CountTraits(), offset+1), representator.ClassesToTable(traits, offset))
 			}
 		},
 	}
@@ -428,26 +608,34 @@ func List() *shell.Executor {
 			&flags.Flag{
 				Name:      "--sort",
 				WithValue: true,
-				Help:      "column number by which sorting will be performed",
+				Help:      "column number or name by which sorting will be performed",
 				Default:   "2",
 			},
 			&flags.Flag{
 				Name: "-r",
 				Help: "reverse sort",
 			},
+			whereFlag(),
+			namespaceFilterFlag(),
 			&flags.Flag{
 				Name:      "--json",
 				Help:      "path to the file where the data will be saved in json format",
 				WithValue: true,
 			},
+			formatFlag(),
+			formatOutFlag(),
 		),
 		Func: func(c *shell.Context) {
 			count := c.GetIntFlagValue("-c")
 			offset := c.GetIntFlagValue("-o")
 			level := c.GetIntFlagValue("-l")
-			sortColumn := c.GetIntFlagValue("--sort")
 			reverseSort := c.Flags.Contains("-r")
 
+			sortColumn, where, ok := handleListFilters(c, representator.NamespaceColumns)
+			if !ok {
+				return
+			}
+
 			toJson, jsonFile := handleOutputInJson(c)
 
 			nss := getter.GetNamespacesByOptions(walkers.GlobalCtx.Namespaces, getter.NamespacesGetOptions{
@@ -455,6 +643,8 @@ func List() *shell.Executor {
 				Count:       count,
 				Offset:      offset,
 				SortColumn:  sortColumn,
+				Where:       where,
+				Namespace:   c.GetFlagValue("--namespace"),
 				ReverseSort: reverseSort,
 			})
 
@@ -467,10 +657,602 @@ func List() *shell.Executor {
 				jsonFile.Close()
 				cfmt.Printf("The namespaces list was {{successfully}}::green saved to file {{'%s'}}::blue\n", jsonFile.Name())
 			} else {
-				fmt.Printf("Showing %d namespaces (level %d) out of %d starting from %d\n\n", len(nss), level, len(walkers.GlobalCtx.Namespaces.GetNamespacesWithSpecificLevel(level, 100000, 0)), offset+1)
-				data := representator.GetTableNamespacesRepr(nss, offset)
-				fmt.Println(data)
+				printTable(c, fmt.Sprintf("Showing %d namespaces (level %d) out of %d starting from %d\n\n", len(nss), level, len(walkers.GlobalCtx.Namespaces.GetNamespacesWithSpecificLevel(level, 100000, 0)), offset+1), representator.NamespacesToTable(nss, offset))
+			}
+		},
+	}
+
+	listDuplicatesExecutor := &shell.Executor{
+		Name:    "duplicates",
+		Aliases: []string{"clones"},
+		Help:    "shows list of duplicated code fragments",
+		Flags: flags.NewFlags(
+			&flags.Flag{
+				Name:      "-c",
+				WithValue: true,
+				Help:      "count in list",
+				Default:   "10",
+			},
+			&flags.Flag{
+				Name:      "-o",
+				WithValue: true,
+				Help:      "offset in list",
+				Default:   "0",
+			},
+			&flags.Flag{
+				Name:      "--min-tokens",
+				WithValue: true,
+				Help:      "minimum number of tokens in a duplicated fragment",
+			},
+			&flags.Flag{
+				Name: "--exact",
+				Help: "show only fragments without renamed identifiers and literals",
+			},
+			&flags.Flag{
+				Name:      "--json",
+				Help:      "path to the file where the data will be saved in json format",
+				WithValue: true,
+			},
+			formatFlag(),
+			formatOutFlag(),
+		),
+		Func: func(c *shell.Context) {
+			count := c.GetIntFlagValue("-c")
+			offset := c.GetIntFlagValue("-o")
+			onlyExact := c.Flags.Contains("--exact")
+
+			if c.Flags.Contains("--min-tokens") {
+				minTokens := c.GetIntFlagValue("--min-tokens")
+				if minTokens <= 0 {
+					c.Error(fmt.Errorf("--min-tokens must be a positive number"))
+					return
+				}
+
+				if minTokens != walkers.GlobalCtx.MinCloneTokens {
+					walkers.GlobalCtx.DetectClones(minTokens)
+				}
+			}
+
+			toJson, jsonFile := handleOutputInJson(c)
+
+			groups := getter.GetClonesByOptions(walkers.GlobalCtx.Clones, getter.ClonesGetOptions{
+				OnlyExact: onlyExact,
+				Count:     count,
+				Offset:    offset,
+			})
+
+			if toJson {
+				data, err := representator.GetPrettifyJsonClonesRepr(groups)
+				if err != nil {
+					c.Error(fmt.Errorf("writing list to file: %v", err))
+				}
+				fmt.Fprintln(jsonFile, data)
+				jsonFile.Close()
+				cfmt.Printf("The duplicates list was {{successfully}}::green saved to file {{'%s'}}::blue\n", jsonFile.Name())
+			} else {
+				printTable(c, fmt.Sprintf("Showing %d groups of duplicates (at least %d tokens) out of %d starting from %d\n\n", len(groups), walkers.GlobalCtx.MinCloneTokens, len(walkers.GlobalCtx.Clones), offset+1), representator.ClonesToTable(groups, offset))
+			}
+		},
+	}
+
+	listUntestedExecutor := &shell.Executor{
+		Name: "untested",
+		Help: "shows list of classes that are not used by any test",
+		Flags: flags.NewFlags(
+			&flags.Flag{
+				Name:      "-c",
+				WithValue: true,
+				Help:      "count in list",
+				Default:   "10",
+			},
+			&flags.Flag{
+				Name:      "-o",
+				WithValue: true,
+				Help:      "offset in list",
+				Default:   "0",
+			},
+			&flags.Flag{
+				Name:      "--sort",
+				WithValue: true,
+				Help:      "column number by which sorting will be performed",
+				Default:   "2",
+			},
+			&flags.Flag{
+				Name: "-r",
+				Help: "reverse sort",
+			},
+			&flags.Flag{
+				Name: "--methods",
+				Help: "show methods instead of classes",
+			},
+			&flags.Flag{
+				Name:      "--json",
+				Help:      "path to the file where the data will be saved in json format",
+				WithValue: true,
+			},
+			formatFlag(),
+			formatOutFlag(),
+		),
+		Func: func(c *shell.Context) {
+			count := c.GetIntFlagValue("-c")
+			offset := c.GetIntFlagValue("-o")
+			sortColumn := c.GetIntFlagValue("--sort")
+			reverseSort := c.Flags.Contains("-r")
+			showMethods := c.Flags.Contains("--methods")
+
+			toJson, jsonFile := handleOutputInJson(c)
+
+			if showMethods {
+				methods := getter.GetFunctionsByOptions(walkers.GlobalCtx.Functions, getter.FunctionsGetOptions{
+					OnlyMethods:  true,
+					OnlyUntested: true,
+					Count:        count,
+					Offset:       offset,
+					SortColumn:   sortColumn,
+					ReverseSort:  reverseSort,
+				})
+
+				if toJson {
+					data, err := representator.GetPrettifyJsonFunctionsRepr(methods)
+					if err != nil {
+						c.Error(fmt.Errorf("writing list to file: %v", err))
+					}
+					fmt.Fprintln(jsonFile, data)
+					jsonFile.Close()
+					cfmt.Printf("The untested methods list was {{successfully}}::green saved to file {{'%s'}}::blue\n", jsonFile.Name())
+				} else {
+					printTable(c, fmt.Sprintf("Showing %d untested methods out of %d starting from %d\n\n", len(methods), walkers.GlobalCtx.Functions.CountUntestedMethods(), offset+1), representator.FunctionsToTable(methods, offset))
+				}
+				return
+			}
+
+			classes := getter.GetClassesByOption(walkers.GlobalCtx.Classes, getter.ClassesGetOptions{
+				OnlyClasses:  true,
+				OnlyUntested: true,
+				Count:        count,
+				Offset:       offset,
+				SortColumn:   sortColumn,
+				ReverseSort:  reverseSort,
+			})
+
+			if toJson {
+				data, err := representator.GetPrettifyJsonClassesRepr(classes)
+				if err != nil {
+					c.Error(fmt.Errorf("writing list to file: %v", err))
+				}
+				fmt.Fprintln(jsonFile, data)
+				jsonFile.Close()
+				cfmt.Printf("The untested classes list was {{successfully}}::green saved to file {{'%s'}}::blue\n", jsonFile.Name())
+			} else {
+				printTable(c, fmt.Sprintf("Showing %d untested classes out of %d starting from %d\n\n", len(classes), walkers.GlobalCtx.Classes.CountUntestedClasses(), offset+1), representator.ClassesToTable(classes, offset))
+			}
+		},
+	}
+
+	listExceptionsExecutor := &shell.Executor{
+		Name: "exceptions",
+		Help: "shows list of thrown and caught exceptions",
+		Flags: flags.NewFlags(
+			&flags.Flag{
+				Name:      "-c",
+				WithValue: true,
+				Help:      "count in list",
+				Default:   "10",
+			},
+			&flags.Flag{
+				Name:      "-o",
+				WithValue: true,
+				Help:      "offset in list",
+				Default:   "0",
+			},
+			&flags.Flag{
+				Name:      "--sort",
+				WithValue: true,
+				Help:      "column number by which sorting will be performed",
+				Default:   "1",
+			},
+			&flags.Flag{
+				Name: "-r",
+				Help: "reverse sort",
+			},
+			&flags.Flag{
+				Name: "--uncaught",
+				Help: "show only exceptions that are not caught before reaching an entry point",
+			},
+			&flags.Flag{
+				Name: "--sites",
+				Help: "show throw and catch sites of each exception",
+			},
+			&flags.Flag{
+				Name:      "--json",
+				Help:      "path to the file where the data will be saved in json format",
+				WithValue: true,
+			},
+			formatFlag(),
+			formatOutFlag(),
+		),
+		Func: func(c *shell.Context) {
+			count := c.GetIntFlagValue("-c")
+			offset := c.GetIntFlagValue("-o")
+			sortColumn := c.GetIntFlagValue("--sort")
+			reverseSort := c.Flags.Contains("-r")
+			onlyUncaught := c.Flags.Contains("--uncaught")
+			showSites := c.Flags.Contains("--sites")
+
+			toJson, jsonFile := handleOutputInJson(c)
+
+			exceptions := getter.GetExceptionsByOptions(walkers.GlobalCtx.Classes, getter.ExceptionsGetOptions{
+				OnlyUncaught: onlyUncaught,
+				Count:        count,
+				Offset:       offset,
+				SortColumn:   sortColumn,
+				ReverseSort:  reverseSort,
+			})
+
+			if toJson {
+				data, err := representator.GetPrettifyJsonExceptionsRepr(exceptions)
+				if err != nil {
+					c.Error(fmt.Errorf("writing list to file: %v", err))
+				}
+				fmt.Fprintln(jsonFile, data)
+				jsonFile.Close()
+				cfmt.Printf("The exceptions list was {{successfully}}::green saved to file {{'%s'}}::blue\n", jsonFile.Name())
+				return
+			}
+
+			summary := fmt.Sprintf("Showing %d exceptions out of %d starting from %d\n\n", len(exceptions), walkers.GlobalCtx.Classes.CountExceptions(), offset+1)
+
+			if showSites {
+				fmt.Print(summary)
+				for _, exception := range exceptions {
+					fmt.Println(representator.GetStringExceptionSitesRepr(exception))
+				}
+				return
+			}
+
+			printTable(c, summary, representator.ExceptionsToTable(exceptions, offset))
+		},
+	}
+
+	listGlobalsExecutor := &shell.Executor{
+		Name: "globals",
+		Help: "shows list of global variables, superglobals and static properties used in functions",
+		Flags: flags.NewFlags(
+			&flags.Flag{
+				Name:      "-c",
+				WithValue: true,
+				Help:      "count in list",
+				Default:   "10",
+			},
+			&flags.Flag{
+				Name:      "-o",
+				WithValue: true,
+				Help:      "offset in list",
+				Default:   "0",
+			},
+			&flags.Flag{
+				Name:      "--sort",
+				WithValue: true,
+				Help:      "column number by which sorting will be performed",
+				Default:   "1",
+			},
+			&flags.Flag{
+				Name: "-r",
+				Help: "reverse sort",
+			},
+			&flags.Flag{
+				Name: "--shared",
+				Help: "show only globals used by more than one function",
+			},
+			&flags.Flag{
+				Name: "--users",
+				Help: "show readers and writers of each global",
+			},
+			&flags.Flag{
+				Name:      "--json",
+				Help:      "path to the file where the data will be saved in json format",
+				WithValue: true,
+			},
+			formatFlag(),
+			formatOutFlag(),
+		),
+		Func: func(c *shell.Context) {
+			count := c.GetIntFlagValue("-c")
+			offset := c.GetIntFlagValue("-o")
+			sortColumn := c.GetIntFlagValue("--sort")
+			reverseSort := c.Flags.Contains("-r")
+			onlyShared := c.Flags.Contains("--shared")
+			showUsers := c.Flags.Contains("--users")
+
+			toJson, jsonFile := handleOutputInJson(c)
+
+			globals := getter.GetGlobalsByOptions(walkers.GlobalCtx.Globals, getter.GlobalsGetOptions{
+				OnlyShared:  onlyShared,
+				Count:       count,
+				Offset:      offset,
+				SortColumn:  sortColumn,
+				ReverseSort: reverseSort,
+			})
+
+			if toJson {
+				data, err := representator.GetPrettifyJsonGlobalsRepr(globals)
+				if err != nil {
+					c.Error(fmt.Errorf("writing list to file: %v", err))
+				}
+				fmt.Fprintln(jsonFile, data)
+				jsonFile.Close()
+				cfmt.Printf("The globals list was {{successfully}}::green saved to file {{'%s'}}::blue\n", jsonFile.Name())
+				return
+			}
+
+			summary := fmt.Sprintf("Showing %d globals out of %d starting from %d\n\n", len(globals), walkers.GlobalCtx.Globals.Len(), offset+1)
+
+			if showUsers {
+				fmt.Print(summary)
+				for _, global := range globals {
+					fmt.Println(representator.GetStringGlobalUsersRepr(global))
+				}
+				return
+			}
+
+			printTable(c, summary, representator.GlobalsToTable(globals, offset))
+		},
+	}
+
+	listUnresolvedExecutor := &shell.Executor{
+		Name: "unresolved",
+		Help: "shows list of functions with calls whose target could not be determined",
+		Flags: flags.NewFlags(
+			&flags.Flag{
+				Name:      "-c",
+				WithValue: true,
+				Help:      "count in list",
+				Default:   "10",
+			},
+			&flags.Flag{
+				Name:      "-o",
+				WithValue: true,
+				Help:      "offset in list",
+				Default:   "0",
+			},
+			&flags.Flag{
+				Name:      "--sort",
+				WithValue: true,
+				Help:      "column number by which sorting will be performed (1 - name, 2 - count of unresolved calls)",
+				Default:   "2",
+			},
+			&flags.Flag{
+				Name: "-r",
+				Help: "reverse sort",
+			},
+			&flags.Flag{
+				Name:      "--kind",
+				WithValue: true,
+				Help:      "show only calls of the kind (for example, dynamic-method or unknown-receiver)",
+			},
+			&flags.Flag{
+				Name:      "--json",
+				Help:      "path to the file where the data will be saved in json format",
+				WithValue: true,
+			},
+		),
+		Func: func(c *shell.Context) {
+			count := c.GetIntFlagValue("-c")
+			offset := c.GetIntFlagValue("-o")
+			sortColumn := c.GetIntFlagValue("--sort")
+			reverseSort := c.Flags.Contains("-r")
+			kind := strings.ReplaceAll(c.GetFlagValue("--kind"), "-", " ")
+
+			toJson, jsonFile := handleOutputInJson(c)
+
+			funcs := getter.GetFunctionsWithUnresolvedByOptions(walkers.GlobalCtx.Functions, getter.UnresolvedGetOptions{
+				Kind:        kind,
+				Count:       count,
+				Offset:      offset,
+				SortColumn:  sortColumn,
+				ReverseSort: reverseSort,
+			})
+
+			if toJson {
+				data, err := representator.GetPrettifyJsonFunctionsUnresolvedRepr(funcs, kind)
+				if err != nil {
+					c.Error(fmt.Errorf("writing list to file: %v", err))
+				}
+				fmt.Fprintln(jsonFile, data)
+				jsonFile.Close()
+				cfmt.Printf("The unresolved calls list was {{successfully}}::green saved to file {{'%s'}}::blue\n", jsonFile.Name())
+				return
+			}
+
+			fmt.Printf("Showing %d functions with unresolved calls starting from %d\n\n", len(funcs), offset+1)
+
+			for _, fn := range funcs {
+				fmt.Println(representator.GetStringFunctionUnresolvedRepr(fn, kind))
+			}
+		},
+	}
+
+	listOverridesExecutor := &shell.Executor{
+		Name: "overrides",
+		Help: "shows list of methods that override or implement parent methods",
+		Flags: flags.NewFlags(
+			&flags.Flag{
+				Name:      "-c",
+				WithValue: true,
+				Help:      "count in list",
+				Default:   "10",
+			},
+			&flags.Flag{
+				Name:      "-o",
+				WithValue: true,
+				Help:      "offset in list",
+				Default:   "0",
+			},
+			&flags.Flag{
+				Name:      "--sort",
+				WithValue: true,
+				Help:      "column number by which sorting will be performed (1 - method, 2 - parent, 3 - count of problems)",
+				Default:   "3",
+			},
+			&flags.Flag{
+				Name: "-r",
+				Help: "reverse sort",
+			},
+			&flags.Flag{
+				Name: "--suspicious",
+				Help: "show only overrides whose signature breaks the parent contract",
+			},
+			&flags.Flag{
+				Name: "--refused",
+				Help: "show only overrides that refuse the parent implementation (refused bequest)",
+			},
+			&flags.Flag{
+				Name:      "--json",
+				Help:      "path to the file where the data will be saved in json format",
+				WithValue: true,
+			},
+			formatFlag(),
+			formatOutFlag(),
+		),
+		Func: func(c *shell.Context) {
+			count := c.GetIntFlagValue("-c")
+			offset := c.GetIntFlagValue("-o")
+			sortColumn := c.GetIntFlagValue("--sort")
+			reverseSort := c.Flags.Contains("-r")
+
+			toJson, jsonFile := handleOutputInJson(c)
+
+			list := getter.GetOverridesByOptions(walkers.GlobalCtx.Overrides, getter.OverridesGetOptions{
+				OnlySuspicious: c.Flags.Contains("--suspicious"),
+				OnlyRefused:    c.Flags.Contains("--refused"),
+				Count:          count,
+				Offset:         offset,
+				SortColumn:     sortColumn,
+				ReverseSort:    reverseSort,
+			})
+
+			if toJson {
+				data, err := representator.GetPrettifyJsonOverridesRepr(list)
+				if err != nil {
+					c.Error(fmt.Errorf("writing list to file: %v", err))
+				}
+				fmt.Fprintln(jsonFile, data)
+				jsonFile.Close()
+				cfmt.Printf("The overrides list was {{successfully}}::green saved to file {{'%s'}}::blue\n", jsonFile.Name())
+				return
+			}
+
+			summary := fmt.Sprintf("Showing %d overrides out of %d starting from %d\n\n", len(list), len(walkers.GlobalCtx.Overrides), offset+1)
+			printTable(c, summary, representator.OverridesToTable(list, offset))
+		},
+	}
+
+	listUnreachableExecutor := &shell.Executor{
+		Name: "unreachable",
+		Help: "shows list of functions, classes or files that are not reachable from any entry point",
+		Flags: flags.NewFlags(
+			&flags.Flag{
+				Name:      "-c",
+				WithValue: true,
+				Help:      "count in list",
+				Default:   "10",
+			},
+			&flags.Flag{
+				Name:      "-o",
+				WithValue: true,
+				Help:      "offset in list",
+				Default:   "0",
+			},
+			&flags.Flag{
+				Name: "--classes",
+				Help: "show unreachable classes instead of functions",
+			},
+			&flags.Flag{
+				Name: "--files",
+				Help: "show unreachable files instead of functions",
+			},
+			callGraphModeFlag(),
+			&flags.Flag{
+				Name:      "--json",
+				Help:      "path to the file where the data will be saved in json format",
+				WithValue: true,
+			},
+			formatFlag(),
+			formatOutFlag(),
+		),
+		Func: func(c *shell.Context) {
+			count := c.GetIntFlagValue("-c")
+			offset := c.GetIntFlagValue("-o")
+
+			mode, ok := handleCallGraphMode(c)
+			if !ok {
+				return
+			}
+
+			res, err := walkers.GlobalCtx.Reachability(mode)
+			if err != nil {
+				c.Error(fmt.Errorf("entry points: %v", err))
+				return
+			}
+
+			if len(res.EntryPoints) == 0 {
+				c.Error(fmt.Errorf("no entry points found, see the 'entryPoints' section of the config"))
+				return
+			}
+
+			unreachable := res.Unreachable(walkers.GlobalCtx.Files, walkers.GlobalCtx.Functions, walkers.GlobalCtx.Classes)
+			opts := getter.UnreachableGetOptions{
+				Count:  count,
+				Offset: offset,
+			}
+
+			toJson, jsonFile := handleOutputInJson(c)
+
+			var data string
+			var summary string
+			var table *representator.Table
+			var kind string
+			switch {
+			case c.Flags.Contains("--classes"):
+				kind = "classes"
+				classes := getter.GetUnreachableClassesByOptions(unreachable, opts)
+				if toJson {
+					data, err = representator.GetPrettifyJsonClassesRepr(classes)
+				} else {
+					summary = fmt.Sprintf("Showing %d unreachable classes out of %d starting from %d\n\n", len(classes), len(unreachable.Classes), offset+1)
+					table = representator.ClassesToTable(classes, offset)
+				}
+			case c.Flags.Contains("--files"):
+				kind = "files"
+				files := getter.GetUnreachableFilesByOptions(unreachable, opts)
+				if toJson {
+					data, err = representator.GetPrettifyJsonFilesRepr(files)
+				} else {
+					summary = fmt.Sprintf("Showing %d unreachable files out of %d starting from %d\n\n", len(files), len(unreachable.Files), offset+1)
+					table = representator.FilesToTable(files, offset)
+				}
+			default:
+				kind = "functions"
+				funcs := getter.GetUnreachableFunctionsByOptions(unreachable, opts)
+				if toJson {
+					data, err = representator.GetPrettifyJsonFunctionsRepr(funcs)
+				} else {
+					summary = fmt.Sprintf("Showing %d unreachable functions out of %d starting from %d\n\n", len(funcs), len(unreachable.Funcs), offset+1)
+					table = representator.FunctionsToTable(funcs, offset)
+				}
+			}
+
+			if !toJson {
+				printTable(c, summary, table)
+				return
+			}
+
+			if err != nil {
+				c.Error(fmt.Errorf("writing list to file: %v", err))
 			}
+			fmt.Fprintln(jsonFile, data)
+			jsonFile.Close()
+			cfmt.Printf("The unreachable %s list was {{successfully}}::green saved to file {{'%s'}}::blue\n", kind, jsonFile.Name())
 		},
 	}
 
@@ -489,6 +1271,13 @@ func List() *shell.Executor {
 	listExecutor.AddExecutor(listInterfaceExecutor)
 	listExecutor.AddExecutor(listTraitsExecutor)
 	listExecutor.AddExecutor(listNamespacesByLevelExecutor)
+	listExecutor.AddExecutor(listDuplicatesExecutor)
+	listExecutor.AddExecutor(listUntestedExecutor)
+	listExecutor.AddExecutor(listExceptionsExecutor)
+	listExecutor.AddExecutor(listOverridesExecutor)
+	listExecutor.AddExecutor(listGlobalsExecutor)
+	listExecutor.AddExecutor(listUnresolvedExecutor)
+	listExecutor.AddExecutor(listUnreachableExecutor)
 
 	return listExecutor
 }
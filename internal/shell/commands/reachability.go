@@ -0,0 +1,122 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/i582/cfmt"
+
+	"github.com/i582/phpstats/internal/getter"
+	"github.com/i582/phpstats/internal/representator"
+	"github.com/i582/phpstats/internal/shell"
+	"github.com/i582/phpstats/internal/shell/flags"
+	"github.com/i582/phpstats/internal/stats/walkers"
+)
+
+func Reachability() *shell.Executor {
+	reachabilitySummaryExecutor := &shell.Executor{
+		Name: "summary",
+		Help: "shows the entry points and the number of functions, classes and files reachable from them",
+		Flags: flags.NewFlags(
+			&flags.Flag{
+				Name:      "-c",
+				WithValue: true,
+				Help:      "count in list",
+				Default:   "10",
+			},
+			&flags.Flag{
+				Name:      "-o",
+				WithValue: true,
+				Help:      "offset in list",
+				Default:   "0",
+			},
+			&flags.Flag{
+				Name:      "--sort",
+				WithValue: true,
+				Help:      "column number by which sorting will be performed",
+				Default:   "2",
+			},
+			&flags.Flag{
+				Name: "-r",
+				Help: "reverse sort",
+			},
+			&flags.Flag{
+				Name:      "--kind",
+				WithValue: true,
+				Help:      "show only entry points of the kind: script, controller, command or tagged",
+			},
+			callGraphModeFlag(),
+			&flags.Flag{
+				Name:      "--json",
+				Help:      "path to the file where the data will be saved in json format",
+				WithValue: true,
+			},
+			formatFlag(),
+			formatOutFlag(),
+		),
+		Func: func(c *shell.Context) {
+			count := c.GetIntFlagValue("-c")
+			offset := c.GetIntFlagValue("-o")
+			sortColumn := c.GetIntFlagValue("--sort")
+			reverseSort := c.Flags.Contains("-r")
+
+			mode, ok := handleCallGraphMode(c)
+			if !ok {
+				return
+			}
+
+			res, err := walkers.GlobalCtx.Reachability(mode)
+			if err != nil {
+				c.Error(fmt.Errorf("entry points: %v", err))
+				return
+			}
+
+			toJson, jsonFile := handleOutputInJson(c)
+
+			entries := getter.GetEntryPointsByOptions(res.EntryPoints, getter.EntryPointsGetOptions{
+				Kind:        c.GetFlagValue("--kind"),
+				Count:       count,
+				Offset:      offset,
+				SortColumn:  sortColumn,
+				ReverseSort: reverseSort,
+			})
+
+			unreachable := res.Unreachable(walkers.GlobalCtx.Files, walkers.GlobalCtx.Functions, walkers.GlobalCtx.Classes)
+			data := representator.ReachabilitySummaryToData(res, unreachable, entries)
+
+			if toJson {
+				data, err := representator.GetPrettifyJsonReachabilitySummaryRepr(data)
+				if err != nil {
+					c.Error(fmt.Errorf("writing summary to file: %v", err))
+				}
+				fmt.Fprintln(jsonFile, data)
+				jsonFile.Close()
+				cfmt.Printf("The reachability summary was {{successfully}}::green saved to file {{'%s'}}::blue\n", jsonFile.Name())
+				return
+			}
+
+			if !exportTable(c) {
+				fmt.Println(representator.GetStringReachabilitySummaryRepr(data))
+
+				if len(entries) == 0 {
+					return
+				}
+			}
+
+			summary := fmt.Sprintf("Showing %d entry points out of %d starting from %d\n\n", len(entries), len(res.EntryPoints), offset+1)
+			printTable(c, summary, representator.EntryPointsToTable(entries, offset))
+		},
+	}
+
+	reachabilityExecutor := &shell.Executor{
+		Name:  "reachability",
+		Help:  "shows the reachability of the code from the entry points",
+		Flags: flags.NewFlags(),
+		Func: func(c *shell.Context) {
+			c.ShowHelpPage()
+		},
+	}
+
+	reachabilityExecutor.AddExecutor(reachabilitySummaryExecutor)
+
+	return reachabilityExecutor
+}
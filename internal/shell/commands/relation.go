@@ -9,6 +9,7 @@ import (
 	"github.com/i582/phpstats/internal/relations"
 	"github.com/i582/phpstats/internal/shell"
 	"github.com/i582/phpstats/internal/shell/flags"
+	"github.com/i582/phpstats/internal/stats/symbols"
 	"github.com/i582/phpstats/internal/stats/walkers"
 )
 
@@ -48,10 +49,26 @@ func Relation() *shell.Executor {
 				Help:      "comma-separated list of functions to be excluded from found paths",
 				WithValue: true,
 			},
+			&flags.Flag{
+				Name:      "--exclude-ns",
+				Help:      "comma-separated list of namespaces whose functions are excluded from found paths",
+				WithValue: true,
+			},
+			&flags.Flag{
+				Name:      "--exclude-regex",
+				Help:      "regular expression for the names of functions to be excluded from found paths",
+				WithValue: true,
+			},
 			&flags.Flag{
 				Name:      "--depth",
 				WithValue: true,
-				Help:      "max search depth",
+				Help:      "max number of calls in a path, 0 for no limit",
+				Default:   "0",
+			},
+			&flags.Flag{
+				Name:      "--paths",
+				WithValue: true,
+				Help:      "max number of the shortest paths to find",
 				Default:   "10",
 			},
 			&flags.Flag{
@@ -59,26 +76,20 @@ func Relation() *shell.Executor {
 				Help:      "path to the file where the data will be saved in json format",
 				WithValue: true,
 			},
+			callGraphModeFlag(),
+			formatFlag(),
+			formatOutFlag(),
 		),
 		Func: func(c *shell.Context) {
 			count := c.GetIntFlagValue("-c")
 			offset := c.GetIntFlagValue("-o")
 			showPaths := c.ContainsFlag("--show")
-			maxDepth := c.GetIntFlagValue("--depth")
 			parentFunctionName := c.GetFlagValue("--parent")
 			childFunctionName := c.GetFlagValue("--child")
-			excludedFunctionsSlice := strings.Split(c.GetFlagValue("--exclude"), ",")
-			excludedFunctions := relations.ReachabilityExcludedMap{}
 
-			if c.GetFlagValue("--exclude") != "" {
-				for _, excludedFunctionName := range excludedFunctionsSlice {
-					excludedFunction, err := walkers.GlobalCtx.Functions.GetFunctionByPartOfName(excludedFunctionName)
-					if err != nil {
-						c.Error(err)
-						return
-					}
-					excludedFunctions[excludedFunction] = excludedFunction
-				}
+			opts, ok := handleReachabilityOptions(c)
+			if !ok {
+				return
 			}
 
 			parentFunction, err := walkers.GlobalCtx.Functions.GetFunctionByPartOfName(parentFunctionName)
@@ -93,14 +104,18 @@ func Relation() *shell.Executor {
 				return
 			}
 
-			toJson, jsonFile := handleOutputInJson(c)
-
-			rel := relations.GetReachabilityFunction(parentFunction, childFunction, excludedFunctions, maxDepth)
+			rel := relations.GetReachabilityFunction(parentFunction, childFunction, opts)
 
 			rel.PrintPaths = showPaths
 			rel.PrintCount = count
 			rel.PrintOffset = offset
 
+			if exportTable(c) {
+				exportTables(c, rel.Table())
+				return
+			}
+
+			toJson, jsonFile := handleOutputInJson(c)
 			if toJson {
 				data, err := rel.Json()
 				if err != nil {
@@ -149,13 +164,13 @@ func Relation() *shell.Executor {
 
 			for i := 0; i < len(classes); i++ {
 				for j := i + 1; j < len(classes); j++ {
-					targetClass, err := walkers.GlobalCtx.Classes.GetClassByPartOfName(classes[i])
+					targetClass, err := walkers.GlobalCtx.Classes.GetAnyTypeClassByPartOfName(classes[i])
 					if err != nil {
 						c.Error(err)
 						return
 					}
 
-					relatedClass, err := walkers.GlobalCtx.Classes.GetClassByPartOfName(classes[j])
+					relatedClass, err := walkers.GlobalCtx.Classes.GetAnyTypeClassByPartOfName(classes[j])
 					if err != nil {
 						c.Error(err)
 						return
@@ -187,7 +202,7 @@ func Relation() *shell.Executor {
 
 			for i := 0; i < len(classes); i++ {
 				for j := 0; j < len(funcs); j++ {
-					targetClass, err := walkers.GlobalCtx.Classes.GetClassByPartOfName(classes[i])
+					targetClass, err := walkers.GlobalCtx.Classes.GetAnyTypeClassByPartOfName(classes[i])
 					if err != nil {
 						c.Error(err)
 						return
@@ -206,6 +221,139 @@ func Relation() *shell.Executor {
 		},
 	}
 
+	relationNamespaceExecutor := &shell.Executor{
+		Name:      "namespace",
+		Help:      "shows all class and function edges between two namespaces in both directions",
+		WithValue: true,
+		CountArgs: 2,
+		Flags: flags.NewFlags(
+			formatFlag(),
+			formatOutFlag(),
+		),
+		Func: func(c *shell.Context) {
+			targetNamespace, ok := walkers.GlobalCtx.Namespaces.GetNamespace(c.Args[0])
+			if !ok {
+				c.Error(fmt.Errorf("namespace %s not found", c.Args[0]))
+				return
+			}
+
+			relatedNamespace, ok := walkers.GlobalCtx.Namespaces.GetNamespace(c.Args[1])
+			if !ok {
+				c.Error(fmt.Errorf("namespace %s not found", c.Args[1]))
+				return
+			}
+
+			rel := relations.GetNamespace2NamespaceRelation(targetNamespace, relatedNamespace)
+			if rel == nil {
+				c.Error(fmt.Errorf("namespaces must be different"))
+				return
+			}
+
+			if exportTable(c) {
+				exportTables(c, rel.Table())
+				return
+			}
+
+			fmt.Println(rel)
+		},
+	}
+
+	relationFileExecutor := &shell.Executor{
+		Name:      "file",
+		Help:      "shows require chains between two files and symbols of one file used in the other",
+		WithValue: true,
+		CountArgs: 2,
+		Flags: flags.NewFlags(
+			formatFlag(),
+			formatOutFlag(),
+		),
+		Func: func(c *shell.Context) {
+			targetFile, err := walkers.GlobalCtx.Files.GetFileByPartOfName(c.Args[0])
+			if err != nil {
+				c.Error(err)
+				return
+			}
+
+			relatedFile, err := walkers.GlobalCtx.Files.GetFileByPartOfName(c.Args[1])
+			if err != nil {
+				c.Error(err)
+				return
+			}
+
+			rel := relations.GetFile2FileRelation(targetFile, relatedFile)
+			if rel == nil {
+				c.Error(fmt.Errorf("files must be different"))
+				return
+			}
+
+			if exportTable(c) {
+				exportTables(c, rel.Tables()...)
+				return
+			}
+
+			fmt.Println(rel)
+		},
+	}
+
+	relationCommonExecutor := &shell.Executor{
+		Name: "common",
+		Help: "shows the common callers, callees and the lowest common caller of functions or classes",
+		Flags: flags.NewFlags(
+			&flags.Flag{
+				Name:      "--funcs",
+				Help:      "comma-separated list of functions without spaces for which you want to find common callers and callees",
+				WithValue: true,
+			},
+			&flags.Flag{
+				Name:      "--classes",
+				Help:      "comma-separated list of classes without spaces for which you want to find common dependents and dependencies",
+				WithValue: true,
+			},
+			callGraphModeFlag(),
+			formatFlag(),
+			formatOutFlag(),
+		),
+		Func: func(c *shell.Context) {
+			mode, ok := handleCallGraphMode(c)
+			if !ok {
+				return
+			}
+
+			var funcs []*symbols.Function
+			for _, name := range splitNames(c.GetFlagValue("--funcs")) {
+				fn, err := walkers.GlobalCtx.Functions.GetFunctionByPartOfName(name)
+				if err != nil {
+					c.Error(err)
+					return
+				}
+				funcs = append(funcs, fn)
+			}
+
+			var classes []*symbols.Class
+			for _, name := range splitNames(c.GetFlagValue("--classes")) {
+				class, err := walkers.GlobalCtx.Classes.GetAnyTypeClassByPartOfName(name)
+				if err != nil {
+					c.Error(err)
+					return
+				}
+				classes = append(classes, class)
+			}
+
+			if len(funcs) < 2 && len(classes) < 2 {
+				c.Error(fmt.Errorf("at least two functions or two classes are required"))
+				return
+			}
+
+			rel := relations.GetCommonRelation(funcs, classes, mode)
+			if exportTable(c) {
+				exportTables(c, rel.Table())
+				return
+			}
+
+			fmt.Println(rel)
+		},
+	}
+
 	relationExecutor := &shell.Executor{
 		Name:  "relation",
 		Help:  "shows relation",
@@ -217,6 +365,22 @@ func Relation() *shell.Executor {
 
 	relationExecutor.AddExecutor(relationFuncReachabilityExecutor)
 	relationExecutor.AddExecutor(relationAllExecutor)
+	relationExecutor.AddExecutor(relationNamespaceExecutor)
+	relationExecutor.AddExecutor(relationFileExecutor)
+	relationExecutor.AddExecutor(relationCommonExecutor)
 
 	return relationExecutor
 }
+
+// splitNames splits the comma-separated list of names.
+func splitNames(list string) []string {
+	if list == "" {
+		return nil
+	}
+
+	names := strings.Split(list, ",")
+	for i := range names {
+		names[i] = strings.TrimSpace(names[i])
+	}
+	return names
+}
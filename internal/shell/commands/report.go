@@ -0,0 +1,534 @@
+package commands
+
+import (
+	"fmt"
+	"html"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/alexeyco/simpletable"
+	"github.com/gookit/color"
+	"github.com/i582/cfmt"
+
+	"github.com/i582/phpstats/internal/getter"
+	"github.com/i582/phpstats/internal/grapher"
+	"github.com/i582/phpstats/internal/representator"
+	"github.com/i582/phpstats/internal/shell"
+	"github.com/i582/phpstats/internal/shell/flags"
+	"github.com/i582/phpstats/internal/stats/symbols"
+	"github.com/i582/phpstats/internal/stats/walkers"
+)
+
+func HtmlReport() *shell.Executor {
+	htmlReportExecutor := &shell.Executor{
+		Name:      "html-report",
+		Help:      "generates a static HTML report of the project in the specified directory",
+		WithValue: true,
+		CountArgs: 1,
+		Flags: flags.NewFlags(
+			&flags.Flag{
+				Name: "--tests",
+				Help: "include tests in report",
+			},
+			&flags.Flag{
+				Name: "--no-graphs",
+				Help: "do not embed graphs in the pages of classes and namespaces",
+			},
+		),
+		Func: func(c *shell.Context) {
+			dir := c.Args[0]
+
+			err := WriteHtmlReport(dir, HtmlReportOptions{
+				WithTests:  handleWithTests(c),
+				WithGraphs: !c.Flags.Contains("--no-graphs"),
+			})
+			if err != nil {
+				c.Error(err)
+				return
+			}
+
+			cfmt.Printf("The report was {{successfully}}::green saved to directory {{'%s'}}::blue\n", dir)
+		},
+	}
+
+	return htmlReportExecutor
+}
+
+type HtmlReportOptions struct {
+	WithTests bool
+	// WithGraphs embeds the graphs in the pages if Graphviz is installed.
+	WithGraphs bool
+}
+
+// WriteHtmlReport writes the static site with the project statistics to the
+// directory: the overview, the lists of classes, functions, files and
+// namespaces, and a page for each class and namespace.
+func WriteHtmlReport(dir string, opts HtmlReportOptions) error {
+	if opts.WithGraphs && !grapher.DotAvailable() {
+		color.Yellow.Println("Warning: Graphviz is not installed, the graphs will not be embedded in the report")
+		opts.WithGraphs = false
+	}
+
+	r := &htmlReport{
+		dir:            dir,
+		opts:           opts,
+		classPages:     map[string]string{},
+		namespacePages: map[string]string{},
+		usedPages:      map[string]struct{}{},
+	}
+
+	r.classes = getter.GetClassesByOption(walkers.GlobalCtx.Classes, getter.ClassesGetOptions{
+		WithTests:  opts.WithTests,
+		Count:      int64(walkers.GlobalCtx.Classes.Len()),
+		SortColumn: 1,
+	})
+	for _, class := range r.classes {
+		r.classPages[class.Name] = r.pageName("classes", class.Name)
+	}
+
+	r.namespaces = allNamespaces()
+	for _, ns := range r.namespaces {
+		r.namespacePages[ns.FullName] = r.pageName("namespaces", ns.FullName)
+	}
+
+	for _, dir := range []string{r.dir, filepath.Join(r.dir, "classes"), filepath.Join(r.dir, "namespaces")} {
+		err := os.MkdirAll(dir, 0777)
+		if err != nil {
+			return fmt.Errorf("creating directory: %v", err)
+		}
+	}
+
+	writers := []func() error{r.writeIndex, r.writeClasses, r.writeFunctions, r.writeFiles, r.writeNamespaces}
+	for _, write := range writers {
+		if err := write(); err != nil {
+			return err
+		}
+	}
+
+	for _, class := range r.classes {
+		if err := r.writeClass(class); err != nil {
+			return err
+		}
+	}
+
+	for _, ns := range r.namespaces {
+		if err := r.writeNamespace(ns); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type htmlReport struct {
+	dir  string
+	opts HtmlReportOptions
+
+	classes    []*symbols.Class
+	namespaces []*symbols.Namespace
+
+	// classPages and namespacePages store the paths to the pages
+	// relative to the root of the report by the full names.
+	classPages     map[string]string
+	namespacePages map[string]string
+	usedPages      map[string]struct{}
+}
+
+var pageNameRegexp = regexp.MustCompile(`[^a-zA-Z0-9_.-]+`)
+
+// pageName returns the unique path to the page of the symbol.
+func (r *htmlReport) pageName(dir, name string) string {
+	base := pageNameRegexp.ReplaceAllString(strings.ReplaceAll(strings.Trim(name, `\`), `\`, "."), "_")
+	if base == "" {
+		base = "root"
+	}
+
+	page := dir + "/" + base + ".html"
+	for index := 2; ; index++ {
+		if _, used := r.usedPages[strings.ToLower(page)]; !used {
+			break
+		}
+		page = fmt.Sprintf("%s/%s-%d.html", dir, base, index)
+	}
+	r.usedPages[strings.ToLower(page)] = struct{}{}
+
+	return page
+}
+
+// allNamespaces returns the namespaces of all levels sorted by name.
+func allNamespaces() []*symbols.Namespace {
+	var res []*symbols.Namespace
+	for level := int64(0); ; level++ {
+		nss := walkers.GlobalCtx.Namespaces.GetNamespacesWithSpecificLevel(level, int64(walkers.GlobalCtx.Namespaces.Count())+1, 0)
+		if len(nss) == 0 {
+			break
+		}
+		res = append(res, nss...)
+	}
+
+	sortNamespaces(res)
+	return res
+}
+
+func sortNamespaces(nss []*symbols.Namespace) {
+	sort.Slice(nss, func(i, j int) bool {
+		return nss[i].FullName < nss[j].FullName
+	})
+}
+
+// link returns the path to the page relative to the page at the depth.
+func link(page string, depth int) string {
+	if page == "" {
+		return ""
+	}
+	return strings.Repeat("../", depth) + page
+}
+
+func (r *htmlReport) classLink(name string, depth int) string {
+	return link(r.classPages[name], depth)
+}
+
+func (r *htmlReport) namespaceLink(name string, depth int) string {
+	return link(r.namespacePages[name], depth)
+}
+
+func (r *htmlReport) classesTable(classes []*symbols.Class, depth int) string {
+	return representator.ClassesToTable(classes, 0).HTML(func(row, column int) string {
+		if column != 1 {
+			return ""
+		}
+		return r.classLink(classes[row].Name, depth)
+	})
+}
+
+func (r *htmlReport) functionsTable(funcs []*symbols.Function, depth int) string {
+	return representator.FunctionsToTable(funcs, 0).HTML(func(row, column int) string {
+		if column != 1 || funcs[row].Class == nil {
+			return ""
+		}
+		return r.classLink(funcs[row].Class.Name, depth)
+	})
+}
+
+func (r *htmlReport) namespacesTable(nss []*symbols.Namespace, depth int) string {
+	return representator.NamespacesToTable(nss, 0).HTML(func(row, column int) string {
+		if column != 1 {
+			return ""
+		}
+		return r.namespaceLink(nss[row].FullName, depth)
+	})
+}
+
+func (r *htmlReport) graph(data string) string {
+	if !r.opts.WithGraphs {
+		return ""
+	}
+
+	svg, err := grapher.RenderSvg(data)
+	if err != nil {
+		return fmt.Sprintf("<p class=\"note\">Graph is not available: %s</p>\n", html.EscapeString(err.Error()))
+	}
+
+	return "<div class=\"graph\">\n" + svg + "</div>\n"
+}
+
+func (r *htmlReport) writeIndex() error {
+	var body string
+
+	body += "<h1>" + html.EscapeString(projectTitle()) + "</h1>\n"
+	body += fmt.Sprintf("<p>%d classes, %d namespaces, %d files.</p>\n", len(r.classes), len(r.namespaces), walkers.GlobalCtx.Files.Len())
+
+	metrics := collectBriefMetrics(r.opts.WithTests).Table()
+	metrics.Title = "Overview"
+	body += metrics.HTML(nil)
+
+	return r.writePage("index.html", projectTitle(), 0, body)
+}
+
+func (r *htmlReport) writeClasses() error {
+	body := "<h1>Classes</h1>\n" + r.classesTable(r.classes, 0)
+	return r.writePage("classes.html", "Classes", 0, body)
+}
+
+func (r *htmlReport) writeFunctions() error {
+	funcs := getter.GetFunctionsByOptions(walkers.GlobalCtx.Functions, getter.FunctionsGetOptions{
+		WithTests:  r.opts.WithTests,
+		Count:      int64(walkers.GlobalCtx.Functions.Len()),
+		SortColumn: 1,
+	})
+
+	body := "<h1>Functions and methods</h1>\n" + r.functionsTable(funcs, 0)
+	return r.writePage("functions.html", "Functions", 0, body)
+}
+
+func (r *htmlReport) writeFiles() error {
+	files := getter.GetFilesByOptions(walkers.GlobalCtx.Files, getter.FilesGetOptions{
+		Count:      int64(walkers.GlobalCtx.Files.Len()),
+		SortColumn: 1,
+	})
+
+	body := "<h1>Files</h1>\n" + representator.FilesToTable(files, 0).HTML(nil)
+	return r.writePage("files.html", "Files", 0, body)
+}
+
+func (r *htmlReport) writeNamespaces() error {
+	body := "<h1>Namespaces</h1>\n" + r.namespacesTable(r.namespaces, 0)
+	return r.writePage("namespaces.html", "Namespaces", 0, body)
+}
+
+func (r *htmlReport) writeClass(class *symbols.Class) error {
+	const depth = 1
+
+	var body string
+
+	body += "<h1>" + html.EscapeString(class.Name) + "</h1>\n"
+	body += "<ul class=\"info\">\n"
+	body += fmt.Sprintf("  <li>Type: %s</li>\n", class.Type())
+	body += fmt.Sprintf("  <li>File: %s</li>\n", html.EscapeString(walkers.GlobalCtx.RelativePath(class.File.Path)))
+	if class.Namespace != nil {
+		body += fmt.Sprintf("  <li>Namespace: %s</li>\n", r.anchor(class.Namespace.FullName, r.namespaceLink(class.Namespace.FullName, depth)))
+	}
+	body += r.classListItem("Extends", class.Extends, depth)
+	body += r.classListItem("Implements", class.Implements, depth)
+	body += r.classListItem("Uses traits", class.Uses, depth)
+	body += r.classListItem("Extended by", class.ExtendsBy, depth)
+	body += r.classListItem("Implemented by", class.ImplementsBy, depth)
+	body += "</ul>\n"
+
+	metrics := representator.ClassesToTable([]*symbols.Class{class}, 0)
+	metrics.Title = "Metrics"
+	body += metrics.HTML(nil)
+
+	deps := representator.ClassDependenciesToTable(class)
+	body += deps.HTML(func(row, column int) string {
+		if column != 0 {
+			return ""
+		}
+		return r.classLink(representator.StripColors(deps.Rows[row][0]), depth)
+	})
+
+	methods := getter.GetFunctionsByOptions(class.Methods, getter.FunctionsGetOptions{
+		OnlyMethods: true,
+		WithTests:   true,
+		Count:       int64(class.Methods.Len()),
+		SortColumn:  1,
+	})
+	if len(methods) != 0 {
+		body += "<h2>Methods</h2>\n" + representator.FunctionsToTable(methods, 0).HTML(nil)
+	}
+
+	if !class.IsInterface {
+		body += representator.ClassCohesionToTable(class).HTML(nil)
+	}
+
+	body += r.graph(g.ClassDeps(class, 1, false))
+
+	return r.writePage(r.classPages[class.Name], class.Name, depth, body)
+}
+
+func (r *htmlReport) classListItem(title string, classes *symbols.Classes, depth int) string {
+	if classes == nil || classes.Len() == 0 {
+		return ""
+	}
+
+	names := make([]string, 0, classes.Len())
+	for _, class := range classes.Classes {
+		names = append(names, class.Name)
+	}
+	sort.Strings(names)
+
+	links := make([]string, 0, len(names))
+	for _, name := range names {
+		links = append(links, r.anchor(name, r.classLink(name, depth)))
+	}
+
+	return fmt.Sprintf("  <li>%s: %s</li>\n", title, strings.Join(links, ", "))
+}
+
+func (r *htmlReport) anchor(text, href string) string {
+	if href == "" {
+		return html.EscapeString(text)
+	}
+	return fmt.Sprintf(`<a href="%s">%s</a>`, html.EscapeString(href), html.EscapeString(text))
+}
+
+func (r *htmlReport) writeNamespace(ns *symbols.Namespace) error {
+	const depth = 1
+
+	var body string
+
+	body += "<h1>" + html.EscapeString(ns.FullName) + "</h1>\n"
+	if parent := representator.NamespaceOfName(ns.FullName); parent != `\` {
+		body += fmt.Sprintf("<ul class=\"info\">\n  <li>Parent: %s</li>\n</ul>\n", r.anchor(parent, r.namespaceLink(parent, depth)))
+	}
+
+	metrics := representator.NamespacesToTable([]*symbols.Namespace{ns}, 0)
+	metrics.Title = "Metrics"
+	body += metrics.HTML(nil)
+
+	deps := namespaceDependenciesTable(ns)
+	body += deps.HTML(func(row, column int) string {
+		if column != 0 {
+			return ""
+		}
+		return r.namespaceLink(deps.Rows[row][0], depth)
+	})
+
+	childs := make([]*symbols.Namespace, 0, ns.Childs.Len())
+	for _, child := range ns.Childs.Namespaces {
+		childs = append(childs, child)
+	}
+	sortNamespaces(childs)
+	if len(childs) != 0 {
+		body += "<h2>Child namespaces</h2>\n" + r.namespacesTable(childs, depth)
+	}
+
+	classes := getter.GetClassesByOption(ns.Classes, getter.ClassesGetOptions{
+		WithTests:  r.opts.WithTests,
+		Count:      int64(ns.Classes.Len()),
+		SortColumn: 1,
+	})
+	if len(classes) != 0 {
+		body += "<h2>Classes</h2>\n" + r.classesTable(classes, depth)
+	}
+
+	funcs := getter.GetFunctionsByOptions(ns.Functions, getter.FunctionsGetOptions{
+		OnlyFuncs:  true,
+		WithTests:  r.opts.WithTests,
+		Count:      int64(ns.Functions.Len()),
+		SortColumn: 1,
+	})
+	if len(funcs) != 0 {
+		body += "<h2>Functions</h2>\n" + representator.FunctionsToTable(funcs, 0).HTML(nil)
+	}
+
+	body += r.graph(g.NamespacesDeps(ns, 1))
+
+	return r.writePage(r.namespacePages[ns.FullName], ns.FullName, depth, body)
+}
+
+// namespaceDependenciesTable returns the table of the namespaces on which
+// the classes of the namespace depend and the namespaces whose classes depend
+// on them, the dependencies between the nested namespaces are not included.
+func namespaceDependenciesTable(ns *symbols.Namespace) *representator.Table {
+	deps := map[string]int64{}
+	dependents := map[string]int64{}
+
+	var collect func(current *symbols.Namespace)
+	collect = func(current *symbols.Namespace) {
+		for _, class := range current.Classes.Classes {
+			for _, dep := range class.Deps.Classes {
+				if name := representator.NamespaceOfName(dep.Name); !representator.InNamespace(name, ns.FullName) {
+					deps[name]++
+				}
+			}
+			for _, dep := range class.DepsBy.Classes {
+				if name := representator.NamespaceOfName(dep.Name); !representator.InNamespace(name, ns.FullName) {
+					dependents[name]++
+				}
+			}
+		}
+		for _, child := range current.Childs.Namespaces {
+			collect(child)
+		}
+	}
+	collect(ns)
+
+	table := representator.NewTable(
+		representator.TableColumn{Header: "Namespace"},
+		representator.TableColumn{Header: "Direction"},
+		representator.TableColumn{Header: "Classes", Align: simpletable.AlignRight},
+	)
+	table.Title = "Dependencies"
+
+	for _, part := range []struct {
+		direction string
+		counts    map[string]int64
+	}{
+		{direction: "depends on", counts: deps},
+		{direction: "dependent", counts: dependents},
+	} {
+		names := make([]string, 0, len(part.counts))
+		for name := range part.counts {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			table.AddRow(name, part.direction, fmt.Sprint(part.counts[name]))
+		}
+	}
+
+	return table
+}
+
+func projectTitle() string {
+	if walkers.GlobalCtx.ProjectName == "" {
+		return "Project statistics"
+	}
+	return fmt.Sprintf("'%s' project statistics", walkers.GlobalCtx.ProjectName)
+}
+
+func (r *htmlReport) writePage(page, title string, depth int, body string) error {
+	nav := []struct {
+		page, title string
+	}{
+		{"index.html", "Overview"},
+		{"classes.html", "Classes"},
+		{"functions.html", "Functions"},
+		{"files.html", "Files"},
+		{"namespaces.html", "Namespaces"},
+	}
+
+	var res string
+	res += "<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n"
+	res += "<title>" + html.EscapeString(title) + "</title>\n"
+	res += "<style>\n" + reportStyle + "</style>\n"
+	res += "</head>\n<body>\n<nav>"
+	for _, item := range nav {
+		res += r.anchor(item.title, link(item.page, depth))
+	}
+	res += "</nav>\n<main>\n" + body + "</main>\n"
+	res += "<script>\n" + reportScript + "</script>\n"
+	res += "</body>\n</html>\n"
+
+	return ioutil.WriteFile(filepath.Join(r.dir, filepath.FromSlash(page)), []byte(res), 0666)
+}
+
+const reportStyle = `body { margin: 0; font-family: sans-serif; font-size: 14px; color: #222; }
+nav { padding: 10px 20px; background: #2b2b2b; }
+nav a { color: #fff; margin-right: 20px; text-decoration: none; }
+main { padding: 10px 20px; }
+table { border-collapse: collapse; margin: 15px 0; }
+caption { text-align: left; font-weight: bold; font-size: 16px; padding: 5px 0; }
+th, td { border: 1px solid #ddd; padding: 4px 8px; }
+th { background: #f3f3f3; cursor: pointer; user-select: none; }
+tr:nth-child(even) td { background: #fafafa; }
+a { color: #1a5fb4; }
+.note { color: #888; }
+.graph svg { max-width: 100%; height: auto; }
+`
+
+// reportScript makes the tables sortable by clicking on the headers.
+const reportScript = `document.querySelectorAll("table").forEach(function (table) {
+  table.querySelectorAll("th").forEach(function (th, index) {
+    var asc = true;
+    th.addEventListener("click", function () {
+      var body = table.tBodies[0];
+      var rows = Array.prototype.slice.call(body.rows);
+      rows.sort(function (a, b) {
+        var x = a.cells[index].textContent.trim(), y = b.cells[index].textContent.trim();
+        var nx = parseFloat(x), ny = parseFloat(y);
+        var res = !isNaN(nx) && !isNaN(ny) ? nx - ny : x.localeCompare(y);
+        return asc ? res : -res;
+      });
+      asc = !asc;
+      rows.forEach(function (row) { body.appendChild(row); });
+    });
+  });
+});
+`
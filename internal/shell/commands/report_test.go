@@ -0,0 +1,26 @@
+package commands
+
+import (
+	"testing"
+)
+
+func TestHtmlReportPageName(t *testing.T) {
+	r := &htmlReport{usedPages: map[string]struct{}{}}
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: `\App\Foo`, want: "classes/App.Foo.html"},
+		{name: `\App\foo`, want: "classes/App.foo-2.html"},
+		{name: `\App\Foo@anon`, want: "classes/App.Foo_anon.html"},
+		{name: `\`, want: "classes/root.html"},
+	}
+
+	for _, test := range tests {
+		got := r.pageName("classes", test.name)
+		if got != test.want {
+			t.Errorf("pageName(%q): got %q, want %q", test.name, got, test.want)
+		}
+	}
+}
@@ -1,10 +1,23 @@
 package commands
 
 import (
+	"fmt"
 	"log"
 	"os"
+	"regexp"
+	"strconv"
+	"strings"
 
+	"github.com/i582/cfmt"
+
+	"github.com/i582/phpstats/internal/filter"
+	"github.com/i582/phpstats/internal/getter"
+	"github.com/i582/phpstats/internal/relations"
+	"github.com/i582/phpstats/internal/representator"
 	"github.com/i582/phpstats/internal/shell"
+	"github.com/i582/phpstats/internal/shell/flags"
+	"github.com/i582/phpstats/internal/stats/symbols"
+	"github.com/i582/phpstats/internal/stats/walkers"
 )
 
 func handleOutputInJson(c *shell.Context) (bool, *os.File) {
@@ -20,3 +33,243 @@ func handleOutputInJson(c *shell.Context) (bool, *os.File) {
 	}
 	return toJson, jsonFile
 }
+
+// handleWithTests checks whether tests should be included in the metrics.
+func handleWithTests(c *shell.Context) bool {
+	return walkers.GlobalCtx.IncludeTestsInMetrics || c.Flags.Contains("--tests")
+}
+
+// metricsClasses returns the classes for which metrics are calculated.
+func metricsClasses(withTests bool) *symbols.Classes {
+	if withTests {
+		return walkers.GlobalCtx.Classes
+	}
+	return walkers.GlobalCtx.Classes.WithoutTests()
+}
+
+// metricsFunctions returns the functions for which metrics are calculated.
+func metricsFunctions(withTests bool) *symbols.Functions {
+	if withTests {
+		return walkers.GlobalCtx.Functions
+	}
+	return walkers.GlobalCtx.Functions.WithoutTests()
+}
+
+// handleCoverageThresholds returns the options for filtering
+// functions by the CRAP score and coverage.
+func handleCoverageThresholds(c *shell.Context) (getter.FunctionsGetOptions, error) {
+	var opts getter.FunctionsGetOptions
+
+	withMinCrap := c.Flags.Contains("--min-crap")
+	withMaxCoverage := c.Flags.Contains("--max-coverage")
+
+	if (withMinCrap || withMaxCoverage) && !walkers.GlobalCtx.CoverageLoaded {
+		return opts, fmt.Errorf("coverage report is not loaded, use the '--coverage' option of the 'collect' command")
+	}
+
+	if withMinCrap {
+		opts.MinCrap = float64(c.GetIntFlagValue("--min-crap"))
+	}
+
+	if withMaxCoverage {
+		opts.UseMaxCoverage = true
+		opts.MaxCoverage = float64(c.GetIntFlagValue("--max-coverage"))
+	}
+
+	return opts, nil
+}
+
+// callGraphModeFlag is the flag for choosing the call graph mode.
+func callGraphModeFlag() *flags.Flag {
+	return &flags.Flag{
+		Name:      "--mode",
+		WithValue: true,
+		Help:      "call graph mode: direct (only resolved calls), cha (also calls of all overriding methods) or rta (cha only for instantiated classes)",
+		Default:   "direct",
+	}
+}
+
+// handleCallGraphMode returns the call graph mode from the --mode flag.
+func handleCallGraphMode(c *shell.Context) (symbols.CallGraphMode, bool) {
+	mode, err := symbols.ParseCallGraphMode(c.GetFlagValue("--mode"))
+	if err != nil {
+		c.Error(err)
+		return symbols.DirectCalls, false
+	}
+	return mode, true
+}
+
+// handleReachabilityOptions returns the options of the search of paths between
+// functions from the --exclude, --exclude-ns, --exclude-regex, --depth, --paths and --mode flags.
+func handleReachabilityOptions(c *shell.Context) (relations.ReachabilityOptions, bool) {
+	mode, ok := handleCallGraphMode(c)
+	if !ok {
+		return relations.ReachabilityOptions{}, false
+	}
+
+	exclusions := &relations.ReachabilityExclusions{
+		Functions: relations.ReachabilityExcludedMap{},
+	}
+
+	if c.GetFlagValue("--exclude") != "" {
+		for _, excludedFunctionName := range strings.Split(c.GetFlagValue("--exclude"), ",") {
+			excludedFunction, err := walkers.GlobalCtx.Functions.GetFunctionByPartOfName(excludedFunctionName)
+			if err != nil {
+				c.Error(err)
+				return relations.ReachabilityOptions{}, false
+			}
+			exclusions.Functions[excludedFunction] = excludedFunction
+		}
+	}
+
+	if c.GetFlagValue("--exclude-ns") != "" {
+		exclusions.Namespaces = strings.Split(c.GetFlagValue("--exclude-ns"), ",")
+	}
+
+	if c.GetFlagValue("--exclude-regex") != "" {
+		excludeRegexp, err := regexp.Compile(c.GetFlagValue("--exclude-regex"))
+		if err != nil {
+			c.Error(fmt.Errorf("exclude regex: %v", err))
+			return relations.ReachabilityOptions{}, false
+		}
+		exclusions.Regexp = excludeRegexp
+	}
+
+	return relations.ReachabilityOptions{
+		Exclusions: exclusions,
+		MaxDepth:   c.GetIntFlagValue("--depth"),
+		MaxPaths:   c.GetIntFlagValue("--paths"),
+		Mode:       mode,
+	}, true
+}
+
+func whereFlag() *flags.Flag {
+	return &flags.Flag{
+		Name:      "--where",
+		WithValue: true,
+		Help:      "filter expression over the columns, for example, 'lcom4 > 1 && namespace ~ \"App\\\\Billing\"'",
+	}
+}
+
+func namespaceFilterFlag() *flags.Flag {
+	return &flags.Flag{
+		Name:      "--namespace",
+		WithValue: true,
+		Help:      "show only symbols from the namespace and its nested namespaces",
+	}
+}
+
+func pathFilterFlag() *flags.Flag {
+	return &flags.Flag{
+		Name:      "--path",
+		WithValue: true,
+		Help:      "show only symbols from files whose path contains the specified string",
+	}
+}
+
+// handleListFilters returns the number of the column by which sorting will be
+// performed, it can be specified by number or by name, and the --where expression.
+func handleListFilters(c *shell.Context, columns representator.Columns) (int64, *filter.Expr, bool) {
+	sortValue := c.GetFlagValue("--sort")
+
+	sortColumn, err := strconv.ParseInt(sortValue, 10, 64)
+	if err != nil {
+		column, ok := columns.Find(sortValue)
+		if !ok {
+			c.Error(fmt.Errorf("unknown column '%s', available columns: %s", sortValue, columns.Names()))
+			return 0, nil, false
+		}
+		if column.Sort == 0 {
+			c.Error(fmt.Errorf("sorting by column '%s' is not supported", column.Name))
+			return 0, nil, false
+		}
+		sortColumn = column.Sort
+	}
+
+	where := c.GetFlagValue("--where")
+	if where == "" {
+		return sortColumn, nil, true
+	}
+
+	expr, err := filter.Parse(where, columns.Resolve)
+	if err != nil {
+		c.Error(fmt.Errorf("invalid --where expression: %v, available columns: %s", err, columns.Names()))
+		return 0, nil, false
+	}
+
+	return sortColumn, expr, true
+}
+
+func formatFlag() *flags.Flag {
+	return &flags.Flag{
+		Name:      "--format",
+		WithValue: true,
+		Help:      "output format of the table: " + strings.Join(representator.TableFormats, ", "),
+		Default:   representator.FormatTable,
+	}
+}
+
+func formatOutFlag() *flags.Flag {
+	return &flags.Flag{
+		Name:      "--out",
+		WithValue: true,
+		Help:      "path to the file where the table will be saved in the format from --format",
+	}
+}
+
+// exportTable checks whether the table should be printed in a format
+// other than the shell one or saved to the file.
+func exportTable(c *shell.Context) bool {
+	format := c.GetFlagValue("--format")
+	return (format != "" && format != representator.FormatTable) || c.Flags.Contains("--out")
+}
+
+// printTable prints the table in the format from the --format flag or saves
+// it to the file from the --out flag. The summary is shown only for the table
+// printed in the shell as is.
+func printTable(c *shell.Context, summary string, table *representator.Table) {
+	if !exportTable(c) {
+		fmt.Print(summary)
+		if len(table.Rows) != 0 {
+			fmt.Println(table.String())
+		}
+		return
+	}
+
+	exportTables(c, table)
+}
+
+// exportTables prints the tables one after another in the format from
+// the --format flag or saves them to the file from the --out flag.
+func exportTables(c *shell.Context, tables ...*representator.Table) {
+	format := c.GetFlagValue("--format")
+
+	var data string
+	for index, table := range tables {
+		res, err := table.Format(format)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+
+		if index != 0 {
+			data += "\n"
+		}
+		data += res
+	}
+
+	if !c.Flags.Contains("--out") {
+		fmt.Print(data)
+		return
+	}
+
+	file, err := c.ValidateFile("--out")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	fmt.Fprint(file, representator.StripColors(data))
+	file.Close()
+
+	cfmt.Printf("The table was {{successfully}}::green saved to file {{'%s'}}::blue\n", file.Name())
+}
@@ -119,10 +119,10 @@ func (e *Executor) Execute(ctx *Context) {
 			})
 			return
 		}
-
-		ctx.Flags, ctx.Args = flags.ParseFlags(ctx.Args, e.Flags)
 	}
 
+	ctx.Flags, ctx.Args = flags.ParseFlags(ctx.Args, e.Flags)
+
 	ctx.Exec = e
 
 	if e.CountArgs == 0 && len(e.SubExecs) != 0 {
@@ -196,9 +196,7 @@ func (s *Shell) executor(in string) {
 		return
 	}
 
-	tokens := strings.FieldsFunc(line, func(r rune) bool {
-		return r == '=' || r == ' '
-	})
+	tokens := splitLine(line)
 	if len(tokens) == 0 {
 		return
 	}
@@ -251,9 +249,7 @@ func (s *Shell) OldShell() {
 			continue
 		}
 
-		tokens := strings.FieldsFunc(line, func(r rune) bool {
-			return r == '=' || r == ' '
-		})
+		tokens := splitLine(line)
 		if len(tokens) == 0 {
 			continue
 		}
@@ -274,6 +270,40 @@ func (s *Shell) OldShell() {
 	}
 }
 
+// splitLine splits the command line by spaces and '=', the quoted
+// parts are kept as is, so the flag values may contain them.
+func splitLine(line string) []string {
+	var tokens []string
+	var cur strings.Builder
+	var quote rune
+
+	for _, r := range line {
+		switch {
+		case quote != 0:
+			cur.WriteRune(r)
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+			cur.WriteRune(r)
+		case r == ' ' || r == '=':
+			if cur.Len() != 0 {
+				tokens = append(tokens, cur.String())
+				cur.Reset()
+			}
+		default:
+			cur.WriteRune(r)
+		}
+	}
+
+	if cur.Len() != 0 {
+		tokens = append(tokens, cur.String())
+	}
+
+	return tokens
+}
+
 func (s *Shell) Run() {
 	fmt.Println("Entering interactive mode (type \"help\" for commands)")
 	s.ImprovedShell()
@@ -0,0 +1,273 @@
+package clones
+
+import (
+	"sort"
+
+	"github.com/i582/phpstats/internal/stats/symbols"
+)
+
+// DefaultMinTokens is the minimum length of a duplicated
+// sequence of tokens used if no other value is set.
+const DefaultMinTokens = 70
+
+// Fragment describes one occurrence of the duplicated code.
+type Fragment struct {
+	File      *symbols.File
+	StartLine int32
+	EndLine   int32
+}
+
+// CountLines returns the number of lines in the fragment.
+func (f *Fragment) CountLines() int64 {
+	return int64(f.EndLine-f.StartLine) + 1
+}
+
+// Group describes a set of fragments with the same code.
+type Group struct {
+	Fragments []*Fragment
+
+	// CountTokens is the length of the duplicated sequence.
+	CountTokens int64
+
+	// Exact is true when the fragments are identical (type-1 clone),
+	// otherwise, they differ in names or literals (type-2 clone).
+	Exact bool
+}
+
+// CountLines returns the number of lines in the largest fragment of the group.
+func (g *Group) CountLines() int64 {
+	var max int64
+	for _, fragment := range g.Fragments {
+		if fragment.CountLines() > max {
+			max = fragment.CountLines()
+		}
+	}
+	return max
+}
+
+type location struct {
+	file int
+	pos  int
+}
+
+// Detect finds all duplicated sequences of at least minTokens tokens in
+// the passed files and marks the lines of found fragments as duplicated.
+//
+// Groups are sorted by the length of the duplicated sequence.
+func Detect(f *symbols.Files, minTokens int) []*Group {
+	if minTokens <= 0 {
+		minTokens = DefaultMinTokens
+	}
+
+	files := make([]*symbols.File, 0, f.Len())
+	for _, file := range f.Files {
+		file.ResetDuplicatedLines()
+		if len(file.Tokens) >= minTokens {
+			files = append(files, file)
+		}
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].Path < files[j].Path
+	})
+
+	index := make(map[uint64][]location)
+	for fileIndex, file := range files {
+		for pos, hash := range windowHashes(file.Tokens, minTokens) {
+			index[hash] = append(index[hash], location{file: fileIndex, pos: pos})
+		}
+	}
+
+	covered := make([][]bool, len(files))
+	for fileIndex, file := range files {
+		covered[fileIndex] = make([]bool, len(file.Tokens))
+	}
+
+	var groups []*Group
+
+	for fileIndex, file := range files {
+		hashes := windowHashes(file.Tokens, minTokens)
+
+		for pos, hash := range hashes {
+			if covered[fileIndex][pos] {
+				continue
+			}
+
+			bucket := index[hash]
+			if len(bucket) < 2 {
+				continue
+			}
+
+			self := location{file: fileIndex, pos: pos}
+			members := []location{self}
+			length := len(file.Tokens)
+
+			for _, other := range bucket {
+				if other == self || covered[other.file][other.pos] {
+					continue
+				}
+
+				if overlapsAny(members, other, minTokens) {
+					continue
+				}
+
+				common := commonLength(files, self, other)
+				if common < minTokens {
+					continue
+				}
+
+				members = append(members, other)
+				if common < length {
+					length = common
+				}
+			}
+
+			if len(members) < 2 {
+				continue
+			}
+
+			if distance := minDistance(members); distance < length {
+				length = distance
+			}
+
+			group := &Group{
+				CountTokens: int64(length),
+				Exact:       true,
+			}
+
+			for _, member := range members {
+				tokens := files[member.file].Tokens
+				for i := member.pos; i < member.pos+length; i++ {
+					covered[member.file][i] = true
+				}
+
+				fragment := &Fragment{
+					File:      files[member.file],
+					StartLine: tokens[member.pos].Line,
+					EndLine:   tokens[member.pos+length-1].Line,
+				}
+				fragment.File.MarkDuplicatedLines(fragment.StartLine, fragment.EndLine)
+
+				group.Fragments = append(group.Fragments, fragment)
+
+				if group.Exact && !sameValues(files[members[0].file].Tokens[members[0].pos:], tokens[member.pos:], length) {
+					group.Exact = false
+				}
+			}
+
+			groups = append(groups, group)
+		}
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		return groups[i].CountTokens > groups[j].CountTokens
+	})
+
+	return groups
+}
+
+// windowHashes calculates the polynomial hash of each sequence of n tokens.
+func windowHashes(tokens []symbols.Token, n int) []uint64 {
+	if len(tokens) < n {
+		return nil
+	}
+
+	const base = 1000003
+
+	var power uint64 = 1
+	for i := 0; i < n; i++ {
+		power *= base
+	}
+
+	hashes := make([]uint64, 0, len(tokens)-n+1)
+
+	var hash uint64
+	for i, token := range tokens {
+		hash = hash*base + uint64(uint32(token.Kind))
+		if i >= n {
+			hash -= uint64(uint32(tokens[i-n].Kind)) * power
+		}
+		if i >= n-1 {
+			hashes = append(hashes, hash)
+		}
+	}
+
+	return hashes
+}
+
+// commonLength returns the length of the common sequence of token
+// kinds starting at the passed locations.
+//
+// For locations in the same file, the sequence is limited so that
+// the fragments do not overlap.
+func commonLength(files []*symbols.File, a, b location) int {
+	tokensA := files[a.file].Tokens
+	tokensB := files[b.file].Tokens
+
+	limit := len(tokensA) - a.pos
+	if len(tokensB)-b.pos < limit {
+		limit = len(tokensB) - b.pos
+	}
+	if a.file == b.file {
+		distance := a.pos - b.pos
+		if distance < 0 {
+			distance = -distance
+		}
+		if distance < limit {
+			limit = distance
+		}
+	}
+
+	var length int
+	for length < limit && tokensA[a.pos+length].Kind == tokensB[b.pos+length].Kind {
+		length++
+	}
+
+	return length
+}
+
+func overlapsAny(members []location, loc location, n int) bool {
+	for _, member := range members {
+		if member.file != loc.file {
+			continue
+		}
+
+		distance := member.pos - loc.pos
+		if distance < 0 {
+			distance = -distance
+		}
+		if distance < n {
+			return true
+		}
+	}
+	return false
+}
+
+// minDistance returns the minimum distance between locations in the same file.
+func minDistance(members []location) int {
+	min := int(^uint(0) >> 1)
+	for i := 0; i < len(members); i++ {
+		for j := i + 1; j < len(members); j++ {
+			if members[i].file != members[j].file {
+				continue
+			}
+
+			distance := members[i].pos - members[j].pos
+			if distance < 0 {
+				distance = -distance
+			}
+			if distance < min {
+				min = distance
+			}
+		}
+	}
+	return min
+}
+
+func sameValues(a, b []symbols.Token, length int) bool {
+	for i := 0; i < length; i++ {
+		if a[i].Value != b[i].Value {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,52 @@
+package clones
+
+import (
+	"testing"
+
+	"github.com/i582/phpstats/internal/stats/symbols"
+)
+
+func TestDetectRenamedClone(t *testing.T) {
+	first := symbols.NewFile("first.php")
+	first.Tokens = Tokenize([]byte(`<?php
+function sum($a, $b) {
+  $result = 0;
+  for ($i = $a; $i < $b; $i++) {
+    $result += $i * 2;
+  }
+  return $result;
+}
+`))
+
+	second := symbols.NewFile("second.php")
+	second.Tokens = Tokenize([]byte(`<?php
+function total($from, $to) {
+  $acc = 0;
+  for ($j = $from; $j < $to; $j++) {
+    $acc += $j * 3;
+  }
+  return $acc;
+}
+`))
+
+	files := symbols.NewFiles()
+	files.Add(first)
+	files.Add(second)
+
+	groups := Detect(files, 20)
+	if len(groups) != 1 {
+		t.Fatalf("expected 1 group of duplicates, got %d", len(groups))
+	}
+
+	group := groups[0]
+	if len(group.Fragments) != 2 {
+		t.Fatalf("expected 2 fragments, got %d", len(group.Fragments))
+	}
+	if group.Exact {
+		t.Errorf("expected renamed clone, got exact")
+	}
+
+	if first.CountDuplicatedLines() == 0 || second.CountDuplicatedLines() == 0 {
+		t.Errorf("expected duplicated lines to be marked in both files")
+	}
+}
@@ -0,0 +1,103 @@
+package clones
+
+import (
+	"hash/fnv"
+
+	"github.com/VKCOM/noverify/src/php/parser/scanner"
+
+	"github.com/i582/phpstats/internal/stats/symbols"
+)
+
+// Normalized kinds of tokens whose value does not matter when
+// searching for clones with renamed identifiers.
+const (
+	kindIdentifier = -1 - iota
+	kindVariable
+	kindLiteral
+)
+
+type lval struct {
+	token *scanner.Token
+}
+
+func (l *lval) Token(t *scanner.Token) {
+	l.token = t
+}
+
+// Tokenize splits the passed source code into normalized tokens.
+//
+// Names and literals are normalized so that code that differs only
+// in them is considered the same. Namespace and use statements at
+// the top level are skipped, since they are always similar.
+func Tokenize(data []byte) []symbols.Token {
+	lexer := scanner.NewLexer(data)
+	tokens := make([]symbols.Token, 0, len(data)/4)
+
+	var depth int
+	var skipStatement bool
+	var lv lval
+
+	for {
+		id := lexer.Lex(&lv)
+		if id == 0 {
+			break
+		}
+		tkn := lv.token
+
+		kind := int32(id)
+		switch scanner.TokenID(id) {
+		case scanner.T_OPEN_TAG, scanner.T_OPEN_TAG_WITH_ECHO, scanner.T_CLOSE_TAG, scanner.T_INLINE_HTML:
+			lexer.ReturnTokenToPool(tkn)
+			continue
+		case scanner.T_NAMESPACE, scanner.T_USE:
+			if depth == 0 {
+				skipStatement = true
+			}
+		case scanner.T_STRING, scanner.T_STRING_VARNAME:
+			kind = kindIdentifier
+		case scanner.T_VARIABLE:
+			kind = kindVariable
+		case scanner.T_LNUMBER, scanner.T_DNUMBER, scanner.T_CONSTANT_ENCAPSED_STRING,
+			scanner.T_ENCAPSED_AND_WHITESPACE, scanner.T_NUM_STRING:
+			kind = kindLiteral
+		}
+
+		switch id {
+		case '{', int(scanner.T_CURLY_OPEN), int(scanner.T_DOLLAR_OPEN_CURLY_BRACES):
+			if skipStatement {
+				skipStatement = false
+				lexer.ReturnTokenToPool(tkn)
+				continue
+			}
+			depth++
+		case '}':
+			if depth > 0 {
+				depth--
+			}
+		case ';':
+			if skipStatement {
+				skipStatement = false
+				lexer.ReturnTokenToPool(tkn)
+				continue
+			}
+		}
+
+		if !skipStatement {
+			tokens = append(tokens, symbols.Token{
+				Kind:  kind,
+				Value: hashValue(tkn.Value),
+				Line:  int32(tkn.StartLine),
+			})
+		}
+
+		lexer.ReturnTokenToPool(tkn)
+	}
+
+	return tokens
+}
+
+func hashValue(value string) uint32 {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(value))
+	return h.Sum32()
+}
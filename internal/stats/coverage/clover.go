@@ -0,0 +1,175 @@
+package coverage
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+)
+
+type cloverLine struct {
+	Num   int32  `xml:"num,attr"`
+	Type  string `xml:"type,attr"`
+	Count int64  `xml:"count,attr"`
+}
+
+type cloverFile struct {
+	Name  string       `xml:"name,attr"`
+	Lines []cloverLine `xml:"line"`
+}
+
+type cloverPackage struct {
+	Files []cloverFile `xml:"file"`
+}
+
+type cloverProject struct {
+	Files    []cloverFile    `xml:"file"`
+	Packages []cloverPackage `xml:"package"`
+}
+
+type cloverReport struct {
+	Projects []cloverProject `xml:"project"`
+}
+
+// File stores the execution counts of the statements of one file.
+type File struct {
+	Path string
+	// Lines maps the line number of a statement to the number of its executions.
+	Lines map[int32]int64
+}
+
+// Report is the line coverage read from the Clover XML report.
+type Report struct {
+	Files map[string]*File
+
+	byBaseName map[string][]*File
+}
+
+// OpenClover reads the Clover XML report at the passed path.
+func OpenClover(path string) (*Report, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseClover(data)
+}
+
+// ParseClover parses the Clover XML report.
+func ParseClover(data []byte) (*Report, error) {
+	var clover cloverReport
+	err := xml.Unmarshal(data, &clover)
+	if err != nil {
+		return nil, fmt.Errorf("parse clover report: %v", err)
+	}
+
+	report := &Report{
+		Files:      map[string]*File{},
+		byBaseName: map[string][]*File{},
+	}
+
+	for _, project := range clover.Projects {
+		for _, file := range project.Files {
+			report.add(file)
+		}
+		for _, pack := range project.Packages {
+			for _, file := range pack.Files {
+				report.add(file)
+			}
+		}
+	}
+
+	return report, nil
+}
+
+func (r *Report) add(f cloverFile) {
+	path := filepath.ToSlash(f.Name)
+
+	file, ok := r.Files[path]
+	if !ok {
+		file = &File{
+			Path:  path,
+			Lines: map[int32]int64{},
+		}
+		r.Files[path] = file
+
+		base := filepath.Base(path)
+		r.byBaseName[base] = append(r.byBaseName[base], file)
+	}
+
+	for _, line := range f.Lines {
+		if line.Type != "stmt" && line.Type != "cond" {
+			continue
+		}
+
+		file.Lines[line.Num] += line.Count
+	}
+}
+
+// Find returns the coverage of the file with the passed path.
+//
+// Since the report can be generated on another machine, if there is
+// no file with exactly the same path, then the file with the longest
+// common path suffix is returned. At least the file name and its
+// directory must match, and if several files match equally, none
+// of them is returned.
+func (r *Report) Find(path string) (*File, bool) {
+	path = filepath.ToSlash(path)
+
+	if file, ok := r.Files[path]; ok {
+		return file, true
+	}
+
+	parts := strings.Split(path, "/")
+
+	var found *File
+	var ambiguous bool
+	maxCommon := 1
+
+	for _, file := range r.byBaseName[filepath.Base(path)] {
+		common := commonSuffixLen(parts, strings.Split(file.Path, "/"))
+		switch {
+		case common > maxCommon:
+			found = file
+			maxCommon = common
+			ambiguous = false
+		case common == maxCommon && found != nil:
+			ambiguous = true
+		}
+	}
+
+	if ambiguous {
+		return nil, false
+	}
+
+	return found, found != nil
+}
+
+// Count returns the number of statements in the passed lines
+// and how many of them were executed.
+func (f *File) Count(startLine, endLine int32) (statements, covered int64) {
+	for line, count := range f.Lines {
+		if line < startLine || line > endLine {
+			continue
+		}
+
+		statements++
+		if count > 0 {
+			covered++
+		}
+	}
+
+	return statements, covered
+}
+
+func commonSuffixLen(a, b []string) int {
+	var count int
+	for i, j := len(a)-1, len(b)-1; i >= 0 && j >= 0; i, j = i-1, j-1 {
+		if a[i] != b[j] {
+			break
+		}
+		count++
+	}
+	return count
+}
@@ -0,0 +1,63 @@
+package coverage
+
+import (
+	"testing"
+)
+
+func TestParseClover(t *testing.T) {
+	report, err := ParseClover([]byte(`<?xml version="1.0" encoding="UTF-8"?>
+<coverage generated="1">
+  <project timestamp="1">
+    <file name="/ci/src/Other/Foo.php">
+      <line num="3" type="stmt" count="1"/>
+    </file>
+    <file name="/ci/src/B/User.php">
+      <line num="3" type="stmt" count="1"/>
+    </file>
+    <file name="/ci/lib/One/Helper.php">
+      <line num="3" type="stmt" count="1"/>
+    </file>
+    <file name="/ci/vendor/One/Helper.php">
+      <line num="3" type="stmt" count="0"/>
+    </file>
+    <package name="App">
+      <file name="/ci/src/App/Foo.php">
+        <line num="4" type="method" name="bar" count="1"/>
+        <line num="5" type="stmt" count="1"/>
+        <line num="6" type="stmt" count="0"/>
+        <line num="7" type="cond" count="3"/>
+        <line num="12" type="stmt" count="0"/>
+      </file>
+    </package>
+  </project>
+</coverage>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	file, ok := report.Find("/home/user/project/src/App/Foo.php")
+	if !ok {
+		t.Fatal("file not found")
+	}
+	if file.Path != "/ci/src/App/Foo.php" {
+		t.Errorf("expected file with the longest common suffix, got %s", file.Path)
+	}
+
+	statements, covered := file.Count(4, 8)
+	if statements != 3 || covered != 2 {
+		t.Errorf("expected 2 of 3 covered statements, got %d of %d", covered, statements)
+	}
+
+	notFound := []string{
+		"/home/user/project/src/App/Bar.php",
+		// Only the base name matches.
+		"/home/user/project/src/A/User.php",
+		// Both files match equally.
+		"/home/user/project/src/One/Helper.php",
+	}
+	for _, path := range notFound {
+		if file, ok := report.Find(path); ok {
+			t.Errorf("expected that %s is not found, got %s", path, file.Path)
+		}
+	}
+}
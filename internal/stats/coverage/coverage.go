@@ -0,0 +1,32 @@
+package coverage
+
+import (
+	"github.com/i582/phpstats/internal/stats/symbols"
+)
+
+// Apply sets the statement coverage for all project functions.
+// Functions from files that are missing in the report are considered not covered.
+func Apply(r *Report, funcs *symbols.Functions) {
+	for _, fn := range funcs.Funcs {
+		if fn.IsVendorFunction() || fn.IsEmbeddedFunc() {
+			continue
+		}
+
+		fn.HasCoverage = true
+		fn.CountStatements = 0
+		fn.CountCoveredStatements = 0
+
+		file, ok := r.Find(fn.Pos.Filename)
+		if !ok {
+			// The file was not executed by the tests at all, so the exact
+			// number of statements is unknown, the body lines are used instead.
+			fn.CountStatements = int64(fn.Pos.EndLine - fn.Pos.Line - 1)
+			if fn.CountStatements < 1 {
+				fn.CountStatements = 1
+			}
+			continue
+		}
+
+		fn.CountStatements, fn.CountCoveredStatements = file.Count(fn.Pos.Line, fn.Pos.EndLine)
+	}
+}
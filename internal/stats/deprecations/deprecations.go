@@ -0,0 +1,318 @@
+package deprecations
+
+import (
+	"sort"
+
+	"github.com/i582/phpstats/internal/stats/symbols"
+)
+
+// Kinds of deprecated symbols.
+const (
+	KindFunction = "function"
+	KindMethod   = "method"
+	KindClass    = "class"
+	KindConstant = "constant"
+)
+
+// Deprecation is a symbol marked with the @deprecated tag
+// together with the code that uses it.
+type Deprecation struct {
+	Kind    string
+	Name    string
+	Message string
+	Pos     symbols.Location
+
+	IsVendor bool
+
+	// Users stores the functions and classes that use the symbol directly
+	// or transitively, sorted by distance and name.
+	Users []*User
+}
+
+// CountDirectUsers returns the number of users that use the symbol directly.
+func (d *Deprecation) CountDirectUsers() int {
+	var count int
+	for _, user := range d.Users {
+		if user.Distance == 1 {
+			count++
+		}
+	}
+	return count
+}
+
+// User is a function or a class that uses a deprecated symbol.
+type User struct {
+	Func  *symbols.Function
+	Class *symbols.Class
+
+	// Distance is 1 for direct users, for transitive users it
+	// is the number of steps to the deprecated symbol.
+	Distance int
+	// Via is the name of the symbol through which the deprecated
+	// symbol is used, for direct users it is the deprecated symbol itself.
+	Via string
+	// Sites stores the places where the user uses the Via symbol, if they are known.
+	Sites []symbols.Location
+}
+
+// Name returns the name of the function or the class.
+func (u *User) Name() string {
+	if u.Func != nil {
+		return u.Func.Name.String()
+	}
+	return u.Class.Name
+}
+
+// Options describes which deprecations are collected.
+type Options struct {
+	// OnlyDirect disables the search for transitive users.
+	OnlyDirect bool
+
+	// RelativePath converts the file paths of the declarations, if it is nil,
+	// the paths are used as is.
+	RelativePath func(path string) string
+}
+
+// Collect finds all deprecated functions, methods, classes and constants,
+// including the vendor ones, and collects the code that uses them.
+func Collect(funcs *symbols.Functions, classes *symbols.Classes, constants *symbols.Constants, opt Options) []*Deprecation {
+	if opt.RelativePath == nil {
+		opt.RelativePath = func(path string) string { return path }
+	}
+
+	var res []*Deprecation
+	instantiatedBy := instantiators(funcs)
+
+	for _, fn := range funcs.Funcs {
+		if !fn.Deprecated {
+			continue
+		}
+
+		kind := KindFunction
+		if fn.IsMethod() {
+			kind = KindMethod
+		}
+
+		d := &Deprecation{
+			Kind:     kind,
+			Name:     fn.Name.String(),
+			Message:  fn.DeprecationMessage,
+			Pos:      symbols.Location{File: opt.RelativePath(fn.Pos.Filename), Line: fn.Pos.Line},
+			IsVendor: fn.IsVendorFunction() || fn.IsEmbeddedFunc() || (fn.Class != nil && fn.Class.IsVendor),
+		}
+
+		w := newWalker(d, opt.OnlyDirect, instantiatedBy)
+		for _, caller := range fn.CalledBy.Funcs {
+			w.addFunc(caller, 1, fn.Name.String(), caller.CallSites(fn))
+		}
+		w.walk()
+
+		res = append(res, d)
+	}
+
+	for _, class := range classes.Classes {
+		if !class.Deprecated {
+			continue
+		}
+
+		var path string
+		if class.File != nil {
+			path = opt.RelativePath(class.File.Path)
+		}
+
+		d := &Deprecation{
+			Kind:     KindClass,
+			Name:     class.Name,
+			Message:  class.DeprecationMessage,
+			Pos:      symbols.Location{File: path},
+			IsVendor: class.IsVendor,
+		}
+
+		w := newWalker(d, opt.OnlyDirect, instantiatedBy)
+		w.skipClass = class
+		for _, method := range class.Methods.Funcs {
+			for _, caller := range method.CalledBy.Funcs {
+				w.addFunc(caller, 1, class.Name, caller.CallSites(method))
+			}
+		}
+		for _, constant := range class.Constants.Constants {
+			for _, user := range constant.Used.Funcs {
+				w.addFunc(user, 1, class.Name, nil)
+			}
+		}
+		for _, fn := range instantiatedBy[class] {
+			w.addFunc(fn, 1, class.Name, nil)
+		}
+		for _, dep := range class.DepsBy.Classes {
+			w.addClass(dep, 1, class.Name)
+		}
+		w.walk()
+
+		res = append(res, d)
+	}
+
+	for _, constant := range constants.Constants {
+		if !constant.Deprecated {
+			continue
+		}
+
+		var path string
+		if constant.Class != nil && constant.Class.File != nil {
+			path = opt.RelativePath(constant.Class.File.Path)
+		}
+
+		d := &Deprecation{
+			Kind:     KindConstant,
+			Name:     constant.String(),
+			Message:  constant.DeprecationMessage,
+			Pos:      symbols.Location{File: path},
+			IsVendor: constant.Class != nil && constant.Class.IsVendor,
+		}
+
+		w := newWalker(d, opt.OnlyDirect, instantiatedBy)
+		for _, user := range constantUsers(classes, constant).Funcs {
+			w.addFunc(user, 1, constant.String(), nil)
+		}
+		w.walk()
+
+		res = append(res, d)
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Name < res[j].Name
+	})
+
+	return res
+}
+
+// constantUsers returns the functions that use the constant.
+//
+// The uses are recorded in the constants of the class in which the
+// constant is declared, so the constant is looked up there first.
+func constantUsers(classes *symbols.Classes, constant *symbols.Constant) *symbols.Functions {
+	if constant.Class == nil {
+		return constant.Used
+	}
+
+	class, ok := classes.Get(constant.Class.Name)
+	if !ok {
+		return constant.Used
+	}
+
+	used, ok := class.Constants.Get(symbols.NewConstantKey(constant.Name, class))
+	if !ok {
+		return constant.Used
+	}
+
+	return used.Used
+}
+
+// instantiators returns the functions that create objects of each class with new.
+func instantiators(funcs *symbols.Functions) map[*symbols.Class][]*symbols.Function {
+	res := make(map[*symbols.Class][]*symbols.Function)
+	for _, fn := range funcs.Funcs {
+		for _, class := range fn.Instantiates.Classes {
+			res[class] = append(res[class], fn)
+		}
+	}
+	return res
+}
+
+// walker collects the users of one deprecated symbol by walking the
+// callers of functions, the dependent classes and the functions that
+// create objects of the classes.
+type walker struct {
+	d          *Deprecation
+	onlyDirect bool
+
+	instantiatedBy map[*symbols.Class][]*symbols.Function
+
+	// skipClass is the deprecated class, its own methods are not its users.
+	skipClass *symbols.Class
+
+	funcs   map[*symbols.Function]*User
+	classes map[*symbols.Class]*User
+	queue   []*User
+}
+
+func newWalker(d *Deprecation, onlyDirect bool, instantiatedBy map[*symbols.Class][]*symbols.Function) *walker {
+	return &walker{
+		d:              d,
+		onlyDirect:     onlyDirect,
+		instantiatedBy: instantiatedBy,
+		funcs:          map[*symbols.Function]*User{},
+		classes:        map[*symbols.Class]*User{},
+	}
+}
+
+func (w *walker) addFunc(fn *symbols.Function, distance int, via string, sites []symbols.Location) {
+	if fn.Name.String() == w.d.Name || (w.skipClass != nil && fn.Class == w.skipClass) {
+		return
+	}
+
+	if user, ok := w.funcs[fn]; ok {
+		if user.Distance == distance && user.Via == via {
+			user.Sites = append(user.Sites, sites...)
+		}
+		return
+	}
+
+	user := &User{Func: fn, Distance: distance, Via: via, Sites: sites}
+	w.funcs[fn] = user
+	w.queue = append(w.queue, user)
+}
+
+func (w *walker) addClass(class *symbols.Class, distance int, via string) {
+	if class == w.skipClass {
+		return
+	}
+
+	if _, ok := w.classes[class]; ok {
+		return
+	}
+
+	user := &User{Class: class, Distance: distance, Via: via}
+	w.classes[class] = user
+	w.queue = append(w.queue, user)
+}
+
+func (w *walker) walk() {
+	for i := 0; i < len(w.queue); i++ {
+		user := w.queue[i]
+		w.d.Users = append(w.d.Users, user)
+
+		if w.onlyDirect {
+			continue
+		}
+
+		if user.Func != nil {
+			for _, caller := range user.Func.CalledBy.Funcs {
+				w.addFunc(caller, user.Distance+1, user.Name(), caller.CallSites(user.Func))
+			}
+			continue
+		}
+
+		for _, dep := range user.Class.DepsBy.Classes {
+			w.addClass(dep, user.Distance+1, user.Name())
+		}
+		for _, fn := range w.instantiatedBy[user.Class] {
+			w.addFunc(fn, user.Distance+1, user.Name(), nil)
+		}
+	}
+
+	for _, user := range w.d.Users {
+		sort.Slice(user.Sites, func(i, j int) bool {
+			if user.Sites[i].File != user.Sites[j].File {
+				return user.Sites[i].File < user.Sites[j].File
+			}
+			return user.Sites[i].Line < user.Sites[j].Line
+		})
+	}
+
+	sort.SliceStable(w.d.Users, func(i, j int) bool {
+		if w.d.Users[i].Distance != w.d.Users[j].Distance {
+			return w.d.Users[i].Distance < w.d.Users[j].Distance
+		}
+		return w.d.Users[i].Name() < w.d.Users[j].Name()
+	})
+}
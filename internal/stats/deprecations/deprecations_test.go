@@ -0,0 +1,62 @@
+package deprecations
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/i582/phpstats/internal/stats/symbols"
+	"github.com/i582/phpstats/internal/stats/symbols/symbolstest"
+)
+
+func TestCollect(t *testing.T) {
+	g := symbolstest.NewGraph("foo.php")
+	funcs := g.Funcs
+
+	old := g.Func(`\old`)
+	old.Deprecated = true
+	old.DeprecationMessage = "use \\fresh instead"
+
+	helper := g.Func(`\helper`)
+	controller := g.Func(`\controller`)
+	fresh := g.Func(`\fresh`)
+
+	g.CallAt(helper, old, 10)
+	g.CallAt(controller, helper, 20)
+	g.CallAt(controller, fresh, 21)
+
+	list := Collect(funcs, symbols.NewClasses(), symbols.NewConstants(), Options{})
+	if len(list) != 1 {
+		t.Fatalf("expected 1 deprecation, got %d", len(list))
+	}
+
+	d := list[0]
+	if d.Name != `\old` || d.Kind != KindFunction || d.Message != "use \\fresh instead" {
+		t.Errorf("unexpected deprecation: %+v", d)
+	}
+
+	type user struct {
+		name     string
+		distance int
+		via      string
+		sites    []symbols.Location
+	}
+
+	var got []user
+	for _, u := range d.Users {
+		got = append(got, user{name: u.Name(), distance: u.Distance, via: u.Via, sites: u.Sites})
+	}
+
+	want := []user{
+		{name: `\helper`, distance: 1, via: `\old`, sites: []symbols.Location{{File: "foo.php", Line: 10}}},
+		{name: `\controller`, distance: 2, via: `\helper`, sites: []symbols.Location{{File: "foo.php", Line: 20}}},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("users: got %+v, want %+v", got, want)
+	}
+
+	direct := Collect(funcs, symbols.NewClasses(), symbols.NewConstants(), Options{OnlyDirect: true})
+	if len(direct[0].Users) != 1 || direct[0].CountDirectUsers() != 1 {
+		t.Errorf("expected only the direct user, got %d users", len(direct[0].Users))
+	}
+}
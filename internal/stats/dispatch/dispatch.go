@@ -0,0 +1,126 @@
+package dispatch
+
+import (
+	"strings"
+
+	"github.com/i582/phpstats/internal/stats/symbols"
+)
+
+// Resolve links the instance method calls with all methods that override
+// or implement the called method in the subclasses and implementing classes
+// (Class Hierarchy Analysis) and marks the classes that have created objects,
+// which is used to filter the links in the Rapid Type Analysis mode.
+func Resolve(funcs *symbols.Functions, classes *symbols.Classes) {
+	markInstances(classes)
+
+	subtypes := map[*symbols.Class][]*symbols.Class{}
+
+	for _, fn := range funcs.Funcs {
+		for _, called := range fn.Called.Funcs {
+			if called.Class == nil || called.IsAnonymous() {
+				continue
+			}
+			if called.Signature != nil && (called.Signature.Visibility == "private" || called.Signature.IsStatic) {
+				continue
+			}
+
+			dep, ok := fn.CallEdges.Get(called.Name.String())
+			if !ok || dep.Counts[symbols.DepCall] == 0 {
+				continue
+			}
+
+			classSubtypes, ok := subtypes[called.Class]
+			if !ok {
+				classSubtypes = Subtypes(called.Class)
+				subtypes[called.Class] = classSubtypes
+			}
+
+			name := strings.ToLower(called.Name.Name)
+			for _, class := range classSubtypes {
+				method, ok := findMethod(class, name)
+				if !ok || method == called {
+					continue
+				}
+				if _, ok := fn.Called.Get(method.Name); ok {
+					continue
+				}
+
+				fn.AddVirtualCall(method, dep.Sites)
+			}
+		}
+	}
+}
+
+// markInstances marks the instantiated classes and all their parents
+// as classes having instances.
+func markInstances(classes *symbols.Classes) {
+	for _, class := range classes.Classes {
+		if !class.IsInstantiated {
+			continue
+		}
+
+		visited := map[*symbols.Class]struct{}{}
+		queue := []*symbols.Class{class}
+
+		for len(queue) != 0 {
+			cur := queue[0]
+			queue = queue[1:]
+
+			if _, ok := visited[cur]; ok {
+				continue
+			}
+			visited[cur] = struct{}{}
+
+			cur.HasInstances = true
+
+			for _, parent := range cur.Extends.Classes {
+				queue = append(queue, parent)
+			}
+			for _, iface := range cur.Implements.Classes {
+				queue = append(queue, iface)
+			}
+		}
+	}
+}
+
+// Subtypes returns all classes that extend or implement
+// the passed class directly or transitively.
+func Subtypes(class *symbols.Class) []*symbols.Class {
+	var res []*symbols.Class
+	visited := map[*symbols.Class]struct{}{class: {}}
+	queue := []*symbols.Class{class}
+
+	for len(queue) != 0 {
+		cur := queue[0]
+		queue = queue[1:]
+
+		for _, child := range cur.ExtendsBy.Classes {
+			if _, ok := visited[child]; ok {
+				continue
+			}
+			visited[child] = struct{}{}
+			res = append(res, child)
+			queue = append(queue, child)
+		}
+		for _, child := range cur.ImplementsBy.Classes {
+			if _, ok := visited[child]; ok {
+				continue
+			}
+			visited[child] = struct{}{}
+			res = append(res, child)
+			queue = append(queue, child)
+		}
+	}
+
+	return res
+}
+
+// findMethod returns the method of the class with the passed lowercase name.
+func findMethod(class *symbols.Class, name string) (*symbols.Function, bool) {
+	for _, method := range class.Methods.Funcs {
+		if strings.ToLower(method.Name.Name) == name {
+			return method, true
+		}
+	}
+	return nil, false
+}
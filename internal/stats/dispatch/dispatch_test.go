@@ -0,0 +1,55 @@
+package dispatch
+
+import (
+	"testing"
+
+	"github.com/i582/phpstats/internal/stats/symbols"
+	"github.com/i582/phpstats/internal/stats/symbols/symbolstest"
+)
+
+func TestResolve(t *testing.T) {
+	g := symbolstest.NewGraph("foo.php")
+
+	shape := symbols.NewInterface(`\Shape`, g.File)
+	g.Classes.Add(shape)
+	square := g.Class(`\Square`)
+	circle := g.Class(`\Circle`)
+
+	square.AddImplements(shape)
+	circle.AddImplements(shape)
+
+	square.IsInstantiated = true
+
+	area := g.Method(shape, "area")
+	squareArea := g.Method(square, "area")
+	circleArea := g.Method(circle, "area")
+
+	caller := g.Func(`\total`)
+	g.CallAt(caller, area, 10)
+
+	Resolve(g.Funcs, g.Classes)
+
+	if _, ok := caller.Callees(symbols.DirectCalls).Get(squareArea.Name); ok {
+		t.Errorf("direct mode must not contain virtual calls")
+	}
+
+	cha := caller.Callees(symbols.CHACalls)
+	if cha.Len() != 3 {
+		t.Errorf("cha: expected 3 callees, got %d", cha.Len())
+	}
+
+	rta := caller.Callees(symbols.RTACalls)
+	if _, ok := rta.Get(squareArea.Name); !ok {
+		t.Errorf("rta: expected the call of the instantiated class method")
+	}
+	if _, ok := rta.Get(circleArea.Name); ok {
+		t.Errorf("rta: unexpected call of the not instantiated class method")
+	}
+
+	if !caller.IsVirtualCall(circleArea) || len(caller.CallSites(circleArea)) != 1 {
+		t.Errorf("expected virtual call of %s with the site of the parent call", circleArea.Name)
+	}
+	if !circleArea.IsUnused(symbols.RTACalls) || circleArea.IsUnused(symbols.CHACalls) {
+		t.Errorf("unexpected unused state of %s", circleArea.Name)
+	}
+}
@@ -0,0 +1,192 @@
+package effects
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/i582/phpstats/internal/stats/symbols"
+)
+
+// Special categories that are not described by the function patterns.
+const (
+	// GlobalState is the category of functions that read
+	// or write global variables, superglobals or static properties.
+	GlobalState = "global"
+	// Unknown is the category of functions that contain calls whose
+	// target could not be determined, so they may perform any effects.
+	Unknown = "unknown"
+)
+
+// DefaultCategories contains the side effect categories of built-in functions
+// and methods. Patterns are matched against the function name or the
+// "Class::method" name case-insensitively, * matches any sequence of characters.
+// The language constructs echo and print are matched by their names.
+var DefaultCategories = map[string][]string{
+	"db": {
+		"mysqli_*", "mysql_*", "pg_*", "sqlite_*", "oci_*",
+		`PDO::*`, `PDOStatement::*`, `mysqli::*`, `mysqli_stmt::*`, `mysqli_result::*`, `SQLite3::*`,
+	},
+	"filesystem": {
+		"fopen", "fclose", "fread", "fwrite", "fputs", "fgets", "fgetcsv", "fputcsv", "flock", "ftruncate",
+		"file", "file_get_contents", "file_put_contents", "file_exists", "is_file", "is_dir",
+		"unlink", "rename", "copy", "mkdir", "rmdir", "touch", "chmod", "chown", "tempnam", "tmpfile",
+		"scandir", "glob", "opendir", "readdir", "readfile", "move_uploaded_file",
+		`SplFileObject::*`, `SplFileInfo::*`,
+	},
+	"network": {
+		"curl_*", "fsockopen", "pfsockopen", "socket_*", "stream_socket_*", "mail",
+		"gethostbyname", "dns_get_record", "ftp_*", `SoapClient::*`,
+	},
+	"output": {
+		"echo", "print", "printf", "vprintf", "print_r", "var_dump", "fpassthru",
+		"header", "setcookie", "http_response_code", "flush", "ob_*",
+	},
+	"time": {
+		"time", "microtime", "hrtime", "date", "gmdate", "strtotime", "mktime", "date_create",
+		"sleep", "usleep",
+	},
+	"random": {
+		"rand", "mt_rand", "random_int", "random_bytes", "uniqid", "lcg_value",
+		"shuffle", "str_shuffle", "array_rand", "srand", "mt_srand",
+	},
+}
+
+// Category is a named group of functions with the same kind of side effects.
+type Category struct {
+	Name     string
+	patterns []*regexp.Regexp
+}
+
+// Match checks if the function name or the construct name
+// matches one of the patterns of the category.
+func (c *Category) Match(name string) bool {
+	name = strings.TrimPrefix(name, `\`)
+	for _, pattern := range c.patterns {
+		if pattern.MatchString(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// NewCategories compiles the categories, the categories from the
+// config replace the default categories with the same name.
+func NewCategories(config map[string][]string) ([]*Category, error) {
+	all := make(map[string][]string, len(DefaultCategories)+len(config))
+	for name, patterns := range DefaultCategories {
+		all[name] = patterns
+	}
+	for name, patterns := range config {
+		all[name] = patterns
+	}
+
+	names := make([]string, 0, len(all))
+	for name := range all {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	categories := make([]*Category, 0, len(names))
+	for _, name := range names {
+		if name == GlobalState || name == Unknown {
+			return nil, fmt.Errorf("category name '%s' is reserved", name)
+		}
+
+		category := &Category{Name: name}
+
+		for _, pattern := range all[name] {
+			re, err := compilePattern(pattern)
+			if err != nil {
+				return nil, fmt.Errorf("category '%s': %v", name, err)
+			}
+			category.patterns = append(category.patterns, re)
+		}
+
+		categories = append(categories, category)
+	}
+
+	return categories, nil
+}
+
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	pattern = strings.TrimPrefix(pattern, `\`)
+	quoted := strings.ReplaceAll(regexp.QuoteMeta(pattern), `\*`, `.*`)
+	return regexp.Compile(`(?i)^` + quoted + `$`)
+}
+
+// Propagate calculates for each function the side effects performed
+// by the function itself and the effects that it may perform through
+// the called functions.
+func Propagate(funcs *symbols.Functions, categories []*Category) {
+	queue := make([]*symbols.Function, 0, funcs.Len())
+	inQueue := make(map[*symbols.Function]struct{}, funcs.Len())
+
+	for _, fn := range funcs.Funcs {
+		fn.DirectEffects = directEffects(fn, categories)
+		fn.Effects = symbols.NewEffects()
+
+		for name := range fn.DirectEffects.Names {
+			fn.Effects.Add(name)
+		}
+
+		if fn.Effects.Len() != 0 {
+			queue = append(queue, fn)
+			inQueue[fn] = struct{}{}
+		}
+	}
+
+	for len(queue) != 0 {
+		called := queue[0]
+		queue = queue[1:]
+		delete(inQueue, called)
+
+		for _, caller := range called.CalledBy.Funcs {
+			var changed bool
+
+			for name := range called.Effects.Names {
+				if caller.Effects.Has(name) {
+					continue
+				}
+
+				caller.Effects.Add(name)
+				changed = true
+			}
+
+			if _, ok := inQueue[caller]; changed && !ok {
+				queue = append(queue, caller)
+				inQueue[caller] = struct{}{}
+			}
+		}
+	}
+}
+
+func directEffects(fn *symbols.Function, categories []*Category) *symbols.Effects {
+	effects := symbols.NewEffects()
+
+	name := fn.Name.String()
+	for _, category := range categories {
+		if category.Match(name) {
+			effects.Add(category.Name)
+			continue
+		}
+
+		for construct := range fn.Constructs {
+			if category.Match(construct) {
+				effects.Add(category.Name)
+				break
+			}
+		}
+	}
+
+	if fn.ReadsGlobals.Len() != 0 || fn.WritesGlobals.Len() != 0 {
+		effects.Add(GlobalState)
+	}
+
+	if fn.Unresolved.CountCalls() != 0 {
+		effects.Add(Unknown)
+	}
+
+	return effects
+}
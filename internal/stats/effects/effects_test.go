@@ -0,0 +1,73 @@
+package effects
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/i582/phpstats/internal/stats/symbols"
+	"github.com/i582/phpstats/internal/stats/symbols/symbolstest"
+)
+
+func TestPropagate(t *testing.T) {
+	g := symbolstest.NewGraph("foo.php")
+
+	query := g.Method(g.Class(`\PDO`), "query")
+	fopen := g.Func(`\fopen`)
+	strlen := g.Func(`\strlen`)
+
+	repository := g.Func(`\repository`)
+	logger := g.Func(`\logger`)
+	controller := g.Func(`\controller`)
+	helper := g.Func(`\helper`)
+
+	logger.AddConstruct("echo")
+
+	g.Call(repository, query)
+	g.Call(logger, fopen)
+	g.Call(controller, repository)
+	g.Call(controller, logger)
+	g.Call(helper, strlen)
+
+	categories, err := NewCategories(map[string][]string{
+		"filesystem": {"fopen"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	Propagate(g.Funcs, categories)
+
+	tests := []struct {
+		fn     *symbols.Function
+		direct []string
+		all    []string
+	}{
+		{fn: query, direct: []string{"db"}, all: []string{"db"}},
+		{fn: repository, direct: []string{}, all: []string{"db"}},
+		{fn: logger, direct: []string{"output"}, all: []string{"filesystem", "output"}},
+		{fn: controller, direct: []string{}, all: []string{"db", "filesystem", "output"}},
+		{fn: helper, direct: []string{}, all: []string{}},
+	}
+
+	for _, tt := range tests {
+		if got := tt.fn.DirectEffects.Sorted(); !reflect.DeepEqual(got, tt.direct) {
+			t.Errorf("%s direct effects: got %v, want %v", tt.fn.Name, got, tt.direct)
+		}
+		if got := tt.fn.Effects.Sorted(); !reflect.DeepEqual(got, tt.all) {
+			t.Errorf("%s effects: got %v, want %v", tt.fn.Name, got, tt.all)
+		}
+	}
+
+	if !helper.IsPure() {
+		t.Errorf("%s must be pure", helper.Name)
+	}
+}
+
+func TestNewCategoriesReserved(t *testing.T) {
+	_, err := NewCategories(map[string][]string{
+		GlobalState: {"foo"},
+	})
+	if err == nil {
+		t.Errorf("expected error for reserved category name")
+	}
+}
@@ -0,0 +1,133 @@
+package exceptions
+
+import (
+	"github.com/VKCOM/noverify/src/meta"
+	"github.com/VKCOM/noverify/src/solver"
+
+	"github.com/i582/phpstats/internal/stats/symbols"
+)
+
+// Propagate calculates for each function the exceptions that can be
+// thrown out of it, taking into account the exceptions thrown by the
+// called functions and the try blocks around the calls.
+//
+// For each exception, the entry points from which it can be thrown
+// out are also collected.
+func Propagate(funcs *symbols.Functions) {
+	queue := make([]*symbols.Function, 0, funcs.Len())
+	inQueue := make(map[*symbols.Function]struct{}, funcs.Len())
+
+	for _, fn := range funcs.Funcs {
+		fn.ThrowsTransitive = symbols.NewClasses()
+
+		for _, site := range fn.Throws.Sites {
+			if IsCaught(site.Exception, site.Guards) {
+				continue
+			}
+
+			fn.ThrowsTransitive.Add(site.Exception)
+		}
+
+		if fn.ThrowsTransitive.Len() != 0 {
+			queue = append(queue, fn)
+			inQueue[fn] = struct{}{}
+		}
+	}
+
+	for len(queue) != 0 {
+		called := queue[0]
+		queue = queue[1:]
+		delete(inQueue, called)
+
+		for _, caller := range called.CalledBy.Funcs {
+			var changed bool
+
+			for _, exception := range called.ThrowsTransitive.Classes {
+				if _, ok := caller.ThrowsTransitive.Get(exception.Name); ok {
+					continue
+				}
+
+				if !CanEscape(caller, called, exception) {
+					continue
+				}
+
+				caller.ThrowsTransitive.Add(exception)
+				changed = true
+			}
+
+			if _, ok := inQueue[caller]; changed && !ok {
+				queue = append(queue, caller)
+				inQueue[caller] = struct{}{}
+			}
+		}
+	}
+
+	for _, fn := range funcs.Funcs {
+		if !IsEntryPoint(fn) {
+			continue
+		}
+
+		for _, exception := range fn.ThrowsTransitive.Classes {
+			exception.UncaughtIn.Add(fn)
+		}
+	}
+}
+
+// CanEscape checks if the exception thrown by the called function
+// is not caught by the caller at least at one call site.
+func CanEscape(caller, called *symbols.Function, exception *symbols.Class) bool {
+	if _, ok := caller.UnguardedCalls.Get(called.Name); ok {
+		return true
+	}
+
+	for _, call := range caller.GuardedCalls {
+		if call.Called != called {
+			continue
+		}
+
+		if !IsCaught(exception, call.Guards) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsCaught checks if the exception is caught by any of the passed catch classes.
+func IsCaught(exception *symbols.Class, guards []*symbols.Class) bool {
+	for _, guard := range guards {
+		if IsSubclassOf(exception.Name, guard.Name) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsSubclassOf checks if the class is the same as parent, extends it or implements it.
+func IsSubclassOf(className, parentName string) bool {
+	visited := make(map[string]struct{})
+
+	for cur := className; cur != ""; {
+		if cur == parentName {
+			return true
+		}
+
+		if _, ok := visited[cur]; ok {
+			break
+		}
+		visited[cur] = struct{}{}
+
+		class, ok := meta.Info.GetClass(cur)
+		if !ok {
+			break
+		}
+		cur = class.Parent
+	}
+
+	return solver.Implements(className, parentName)
+}
+
+// IsEntryPoint checks if the function is a project function that is not called by any other function.
+func IsEntryPoint(fn *symbols.Function) bool {
+	return fn.CalledBy.Len() == 0 && !fn.IsVendorFunction() && !fn.IsEmbeddedFunc()
+}
@@ -0,0 +1,50 @@
+package exceptions
+
+import (
+	"testing"
+
+	"github.com/i582/phpstats/internal/stats/symbols"
+	"github.com/i582/phpstats/internal/stats/symbols/symbolstest"
+)
+
+func TestPropagate(t *testing.T) {
+	g := symbolstest.NewGraph("foo.php")
+	exception := g.Class(`\FooException`)
+
+	thrower := g.Func(`\thrower`)
+	passer := g.Func(`\passer`)
+	catcher := g.Func(`\catcher`)
+	main := g.Func(`\main`)
+
+	thrower.AddThrow(&symbols.ExceptionSite{Exception: exception, Func: thrower})
+
+	// The walker records the try blocks around each call.
+	call := func(caller, called *symbols.Function, guards ...*symbols.Class) {
+		g.Call(caller, called)
+		caller.AddCallGuards(called, guards)
+	}
+
+	call(passer, thrower)
+	call(catcher, thrower, exception)
+	call(main, passer)
+	call(main, catcher)
+
+	Propagate(g.Funcs)
+
+	for _, fn := range []*symbols.Function{thrower, passer, main} {
+		if _, ok := fn.ThrowsTransitive.Get(exception.Name); !ok {
+			t.Errorf("expected %s to throw %s out", fn.Name, exception.Name)
+		}
+	}
+
+	if catcher.ThrowsTransitive.Len() != 0 {
+		t.Errorf("expected %s not to throw anything out", catcher.Name)
+	}
+
+	if exception.UncaughtIn.Len() != 1 {
+		t.Fatalf("expected 1 entry point, got %d", exception.UncaughtIn.Len())
+	}
+	if _, ok := exception.UncaughtIn.Get(main.Name); !ok {
+		t.Errorf("expected %s to be uncaught in %s", exception.Name, main.Name)
+	}
+}
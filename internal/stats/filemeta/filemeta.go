@@ -13,6 +13,8 @@ type FileMeta struct {
 
 	CountCommentLine        int64
 	CountAnonymousFunctions int64
+
+	Tokens []symbols.Token
 }
 
 // NewFileMeta returns a new FileMeta instance with pre-allocated fields.
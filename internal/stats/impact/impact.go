@@ -0,0 +1,352 @@
+package impact
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/i582/phpstats/internal/stats/symbols"
+)
+
+// Kinds of the affected symbols.
+const (
+	KindFunction = "function"
+	KindMethod   = "method"
+	KindClass    = "class"
+	KindConstant = "constant"
+	KindField    = "field"
+	KindFile     = "file"
+)
+
+// Node is a symbol that may be affected by the change.
+type Node struct {
+	Func     *symbols.Function
+	Class    *symbols.Class
+	Constant *symbols.Constant
+	Field    *symbols.Field
+	File     *symbols.File
+
+	// Distance is the number of dependencies between
+	// the changed symbol and the affected one.
+	Distance int
+	// Via is the symbol through which the node is affected,
+	// it is nil for the changed symbol itself.
+	Via *Node
+	// Group is the package or the namespace of the symbol.
+	Group string
+}
+
+func (n *Node) Name() string {
+	switch {
+	case n.Func != nil:
+		return n.Func.Name.String()
+	case n.Class != nil:
+		return n.Class.Name
+	case n.Constant != nil:
+		return n.Constant.String()
+	case n.Field != nil:
+		return n.Field.Class.Name + "::$" + n.Field.Name
+	case n.File != nil:
+		return n.File.Path
+	}
+	return ""
+}
+
+func (n *Node) Kind() string {
+	switch {
+	case n.Func != nil && n.Func.IsMethod():
+		return KindMethod
+	case n.Func != nil:
+		return KindFunction
+	case n.Class != nil:
+		return KindClass
+	case n.Constant != nil:
+		return KindConstant
+	case n.Field != nil:
+		return KindField
+	}
+	return KindFile
+}
+
+// IsTest checks if the node is a test class or a method of a test class.
+func (n *Node) IsTest() bool {
+	return (n.Func != nil && n.Func.IsTest()) || (n.Class != nil && n.Class.IsTest)
+}
+
+// Options describes the impact analysis.
+type Options struct {
+	// MaxDepth is the maximum distance of the affected symbols, 0 means no limit.
+	MaxDepth int
+	// PackageOf returns the package of the class with the passed name, if it exists.
+	PackageOf func(className string) (string, bool)
+}
+
+// Result stores the symbols that may be affected by the change of the symbol.
+type Result struct {
+	// Roots are the changed symbols, for files these are also
+	// the classes and functions declared in the file.
+	Roots []*Node
+	// Affected stores the affected symbols sorted by distance and name.
+	Affected []*Node
+}
+
+// Tests returns the affected test classes and methods.
+func (r *Result) Tests() []*Node {
+	var res []*Node
+	for _, node := range r.Affected {
+		if node.IsTest() {
+			res = append(res, node)
+		}
+	}
+	return res
+}
+
+// CountByKind returns the number of the affected symbols of each kind, tests are skipped.
+func (r *Result) CountByKind() map[string]int64 {
+	counts := make(map[string]int64)
+	for _, node := range r.Affected {
+		if node.IsTest() {
+			continue
+		}
+		counts[node.Kind()]++
+	}
+	return counts
+}
+
+// CountByGroup returns the number of the affected symbols in each group, tests are skipped.
+func (r *Result) CountByGroup() map[string]int64 {
+	counts := make(map[string]int64)
+	for _, node := range r.Affected {
+		if node.IsTest() {
+			continue
+		}
+		counts[node.Group]++
+	}
+	return counts
+}
+
+// MaxDistance returns the distance of the farthest affected symbol, tests are skipped.
+func (r *Result) MaxDistance() int {
+	var max int
+	for _, node := range r.Affected {
+		if node.IsTest() {
+			continue
+		}
+		if node.Distance > max {
+			max = node.Distance
+		}
+	}
+	return max
+}
+
+type walker struct {
+	opt Options
+
+	visited map[interface{}]struct{}
+	queue   []*Node
+	res     *Result
+}
+
+// ForFunction finds the symbols affected by the change of the function or method.
+func ForFunction(fn *symbols.Function, opt Options) *Result {
+	return analyze(opt, &Node{Func: fn})
+}
+
+// ForClass finds the symbols affected by the change of the class, interface or trait.
+func ForClass(class *symbols.Class, opt Options) *Result {
+	return analyze(opt, &Node{Class: class})
+}
+
+// ForConstant finds the symbols affected by the change of the constant.
+func ForConstant(constant *symbols.Constant, opt Options) *Result {
+	return analyze(opt, &Node{Constant: constant})
+}
+
+// ForField finds the symbols affected by the change of the class field.
+func ForField(field *symbols.Field, opt Options) *Result {
+	return analyze(opt, &Node{Field: field})
+}
+
+// ForFile finds the symbols affected by the change of the file,
+// that is, of all classes and functions declared in it.
+func ForFile(file *symbols.File, opt Options) *Result {
+	roots := []*Node{{File: file}}
+	for _, class := range file.Classes.Classes {
+		roots = append(roots, &Node{Class: class})
+	}
+	for _, fn := range file.Funcs.Funcs {
+		roots = append(roots, &Node{Func: fn})
+	}
+	return analyze(opt, roots...)
+}
+
+func analyze(opt Options, roots ...*Node) *Result {
+	w := &walker{
+		opt:     opt,
+		visited: map[interface{}]struct{}{},
+		res:     &Result{},
+	}
+
+	for _, root := range roots {
+		w.visited[root.key()] = struct{}{}
+		root.Group = w.group(root)
+		w.res.Roots = append(w.res.Roots, root)
+		w.queue = append(w.queue, root)
+	}
+
+	for len(w.queue) != 0 {
+		node := w.queue[0]
+		w.queue = w.queue[1:]
+
+		if w.opt.MaxDepth > 0 && node.Distance >= w.opt.MaxDepth {
+			continue
+		}
+
+		w.expand(node)
+	}
+
+	sort.SliceStable(w.res.Affected, func(i, j int) bool {
+		a, b := w.res.Affected[i], w.res.Affected[j]
+		if a.Distance != b.Distance {
+			return a.Distance < b.Distance
+		}
+		return a.Name() < b.Name()
+	})
+
+	return w.res
+}
+
+// expand adds the symbols that depend on the symbol of the node.
+func (w *walker) expand(node *Node) {
+	switch {
+	case node.Func != nil:
+		w.addFuncs(node, node.Func.CalledBy)
+
+	case node.Class != nil:
+		for _, class := range sortedClasses(node.Class.DepsBy) {
+			w.add(node, &Node{Class: class})
+		}
+		for _, method := range sortedFuncs(node.Class.Methods) {
+			w.addFuncs(node, method.CalledBy)
+		}
+		for _, constant := range node.Class.Constants.Constants {
+			w.addFuncs(node, constant.Used)
+		}
+		for _, field := range node.Class.Fields.Fields {
+			w.addFuncs(node, field.Used)
+		}
+
+	case node.Constant != nil:
+		w.addFuncs(node, node.Constant.Used)
+
+	case node.Field != nil:
+		w.addFuncs(node, node.Field.Used)
+
+	case node.File != nil:
+		paths := make([]string, 0, node.File.RequiredBy.Len())
+		for path := range node.File.RequiredBy.Files {
+			paths = append(paths, path)
+		}
+		sort.Strings(paths)
+
+		for _, path := range paths {
+			w.add(node, &Node{File: node.File.RequiredBy.Files[path]})
+		}
+	}
+}
+
+func (w *walker) addFuncs(via *Node, funcs *symbols.Functions) {
+	for _, fn := range sortedFuncs(funcs) {
+		// The methods of the changed class are parts of the change itself.
+		if via.Class != nil && fn.Class == via.Class {
+			continue
+		}
+		w.add(via, &Node{Func: fn})
+	}
+}
+
+func (w *walker) add(via *Node, node *Node) {
+	key := node.key()
+	if _, ok := w.visited[key]; ok {
+		return
+	}
+	w.visited[key] = struct{}{}
+
+	node.Via = via
+	node.Distance = via.Distance + 1
+	node.Group = w.group(node)
+
+	w.res.Affected = append(w.res.Affected, node)
+	w.queue = append(w.queue, node)
+}
+
+func (n *Node) key() interface{} {
+	switch {
+	case n.Func != nil:
+		return n.Func
+	case n.Class != nil:
+		return n.Class
+	case n.Constant != nil:
+		return n.Constant
+	case n.Field != nil:
+		return n.Field
+	}
+	return n.File
+}
+
+// group returns the package of the symbol, if the packages are
+// configured and the symbol belongs to one of them, or the namespace.
+func (w *walker) group(node *Node) string {
+	var className string
+	switch {
+	case node.Func != nil && node.Func.Class != nil:
+		className = node.Func.Class.Name
+	case node.Func != nil:
+		className = node.Func.Name.Name
+	case node.Class != nil:
+		className = node.Class.Name
+	case node.Constant != nil && node.Constant.Class != nil:
+		className = node.Constant.Class.Name
+	case node.Field != nil:
+		className = node.Field.Class.Name
+	default:
+		return ""
+	}
+
+	if w.opt.PackageOf != nil {
+		if pack, ok := w.opt.PackageOf(className); ok {
+			return pack
+		}
+	}
+
+	index := strings.LastIndex(className, `\`)
+	if index <= 0 {
+		return `\`
+	}
+	return className[:index]
+}
+
+func sortedClasses(c *symbols.Classes) []*symbols.Class {
+	classes := make([]*symbols.Class, 0, c.Len())
+	for _, class := range c.Classes {
+		classes = append(classes, class)
+	}
+
+	sort.Slice(classes, func(i, j int) bool {
+		return classes[i].Name < classes[j].Name
+	})
+
+	return classes
+}
+
+func sortedFuncs(f *symbols.Functions) []*symbols.Function {
+	funcs := make([]*symbols.Function, 0, f.Len())
+	for _, fn := range f.Funcs {
+		funcs = append(funcs, fn)
+	}
+
+	sort.Slice(funcs, func(i, j int) bool {
+		return funcs[i].Name.String() < funcs[j].Name.String()
+	})
+
+	return funcs
+}
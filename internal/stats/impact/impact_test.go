@@ -0,0 +1,39 @@
+package impact
+
+import (
+	"testing"
+
+	"github.com/i582/phpstats/internal/stats/symbols/symbolstest"
+)
+
+func TestForFunction(t *testing.T) {
+	g := symbolstest.NewGraph("/project/lib.php")
+
+	changed := g.Func(`\Core\load`)
+	direct := g.Func(`\Core\init`)
+	indirect := g.Func(`\App\run`)
+	far := g.Func(`\App\main`)
+
+	g.Call(direct, changed)
+	g.Call(indirect, direct)
+	g.Call(far, indirect)
+	g.Call(changed, indirect)
+
+	res := ForFunction(changed, Options{})
+	if len(res.Affected) != 3 {
+		t.Fatalf("expected 3 affected functions, got %d", len(res.Affected))
+	}
+	if res.MaxDistance() != 3 {
+		t.Errorf("expected max distance 3, got %d", res.MaxDistance())
+	}
+
+	groups := res.CountByGroup()
+	if groups[`\Core`] != 1 || groups[`\App`] != 2 {
+		t.Errorf("unexpected groups %v", groups)
+	}
+
+	limited := ForFunction(changed, Options{MaxDepth: 1})
+	if len(limited.Affected) != 1 || limited.Affected[0].Func != direct {
+		t.Errorf("expected only %s with max depth 1", direct.Name)
+	}
+}
@@ -1,8 +1,7 @@
 package metrics
 
 import (
-	"gonum.org/v1/gonum/graph/simple"
-	"gonum.org/v1/gonum/graph/topo"
+	"sort"
 
 	"github.com/i582/phpstats/internal/stats/symbols"
 )
@@ -29,12 +28,17 @@ func LackOfCohesionInMethods(c *symbols.Class) (float64, bool) {
 		return c.Lcom, true
 	}
 
+	// Methods and fields of the used traits are considered
+	// as the methods and fields of the class itself.
+	methods := c.MethodsWithTraits()
+	fields := c.FieldsWithTraits()
+
 	var usedSum int
-	for _, field := range c.Fields.Fields {
+	for _, field := range fields.Fields {
 		usedSum += field.Used.Len()
 	}
 
-	allFieldMethod := c.Fields.Len() * c.Methods.Len()
+	allFieldMethod := fields.Len() * methods.Len()
 
 	if allFieldMethod != 0 {
 		c.LcomResolved = true
@@ -49,32 +53,64 @@ func LackOfCohesionInMethods(c *symbols.Class) (float64, bool) {
 	return -1, false
 }
 
+// Lcom4Component describes one connected component of the LCOM4 graph,
+// that is, a group of methods that are related to each other through calls
+// or through the fields they share. Methods also contains the functions
+// outside the class that use its fields.
+type Lcom4Component struct {
+	Methods []*symbols.Function
+	Fields  []*symbols.Field
+}
+
 // LackOfCohesionInMethods4 calculates the Lack Of Cohesion In Methods 4 metric for the passed class.
 func LackOfCohesionInMethods4(c *symbols.Class) int64 {
 	if c.Lcom4Resolved {
 		return c.Lcom4
 	}
 
-	g := simple.NewUndirectedGraph()
+	c.Lcom4Resolved = true
+	c.Lcom4 = int64(len(LackOfCohesionInMethods4Components(c)))
 
-	for _, method := range c.Methods.Funcs {
-		if g.Node(method.Id) == nil {
-			g.AddNode(method)
+	return c.Lcom4
+}
+
+// LackOfCohesionInMethods4Components returns the connected components of the
+// LCOM4 graph for the passed class, the largest components first.
+//
+// Each component is a suggestion for a separate class when LCOM4 is greater than one.
+func LackOfCohesionInMethods4Components(c *symbols.Class) []*Lcom4Component {
+	methods := c.MethodsWithTraits()
+	fields := c.FieldsWithTraits()
+
+	related := make(map[*symbols.Function]map[*symbols.Function]struct{}, methods.Len())
+	// The functions outside the class are added to
+	// the graph only when they are linked to something.
+	link := func(a, b *symbols.Function) {
+		if a == b {
+			return
+		}
+		for _, fn := range []*symbols.Function{a, b} {
+			if _, ok := related[fn]; !ok {
+				related[fn] = map[*symbols.Function]struct{}{}
+			}
 		}
+		related[a][b] = struct{}{}
+		related[b][a] = struct{}{}
 	}
 
-	for _, method := range c.Methods.Funcs {
+	for _, method := range methods.Funcs {
+		related[method] = map[*symbols.Function]struct{}{}
+	}
+
+	for _, method := range methods.Funcs {
 		for _, called := range method.Called.Funcs {
-			if _, ok := c.Methods.Get(called.Name); ok && method != called {
-				g.SetEdge(simple.Edge{
-					F: method,
-					T: called,
-				})
+			if _, ok := methods.Get(called.Name); ok {
+				link(method, called)
 			}
 		}
 	}
 
-	for _, field := range c.Fields.Fields {
+	for _, field := range fields.Fields {
 		functions := make([]*symbols.Function, 0, field.Used.Len())
 
 		for _, used := range field.Used.Funcs {
@@ -83,22 +119,76 @@ func LackOfCohesionInMethods4(c *symbols.Class) int64 {
 
 		for i := 0; i < len(functions)-1; i++ {
 			for j := i + 1; j < len(functions); j++ {
-				if functions[i].Id == functions[j].Id {
+				link(functions[i], functions[j])
+			}
+		}
+	}
+
+	visited := make(map[*symbols.Function]struct{}, len(related))
+	var connectedComponents [][]*symbols.Function
+
+	for method := range related {
+		if _, ok := visited[method]; ok {
+			continue
+		}
+
+		visited[method] = struct{}{}
+		queue := []*symbols.Function{method}
+		var connectedComponent []*symbols.Function
+
+		for len(queue) != 0 {
+			current := queue[0]
+			queue = queue[1:]
+			connectedComponent = append(connectedComponent, current)
+
+			for next := range related[current] {
+				if _, ok := visited[next]; ok {
 					continue
 				}
-
-				g.SetEdge(simple.Edge{
-					F: functions[i],
-					T: functions[j],
-				})
+				visited[next] = struct{}{}
+				queue = append(queue, next)
 			}
 		}
+
+		connectedComponents = append(connectedComponents, connectedComponent)
 	}
 
-	connectedComponents := topo.ConnectedComponents(g)
+	components := make([]*Lcom4Component, 0, len(connectedComponents))
 
-	c.Lcom4Resolved = true
-	c.Lcom4 = int64(len(connectedComponents))
+	for _, connectedComponent := range connectedComponents {
+		component := &Lcom4Component{}
+		componentMethods := symbols.NewFunctions()
 
-	return c.Lcom4
+		for _, method := range connectedComponent {
+			componentMethods.Add(method)
+			component.Methods = append(component.Methods, method)
+		}
+
+		for _, field := range fields.Fields {
+			for _, used := range field.Used.Funcs {
+				if _, ok := componentMethods.Get(used.Name); ok {
+					component.Fields = append(component.Fields, field)
+					break
+				}
+			}
+		}
+
+		sort.Slice(component.Methods, func(i, j int) bool {
+			return component.Methods[i].Name.Name < component.Methods[j].Name.Name
+		})
+		sort.Slice(component.Fields, func(i, j int) bool {
+			return component.Fields[i].Name < component.Fields[j].Name
+		})
+
+		components = append(components, component)
+	}
+
+	sort.SliceStable(components, func(i, j int) bool {
+		if len(components[i].Methods) == len(components[j].Methods) {
+			return components[i].Methods[0].Name.Name < components[j].Methods[0].Name.Name
+		}
+		return len(components[i].Methods) > len(components[j].Methods)
+	})
+
+	return components
 }
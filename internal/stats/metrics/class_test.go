@@ -0,0 +1,137 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/VKCOM/noverify/src/meta"
+
+	"github.com/i582/phpstats/internal/stats/symbols"
+)
+
+func TestLackOfCohesionInMethods4Components(t *testing.T) {
+	class := symbols.NewClass(`\Foo`, symbols.NewFile("foo.php"))
+
+	newMethod := func(name string) *symbols.Function {
+		method := symbols.NewMethod(symbols.NewMethodKey(name, class.Name), meta.ElementPosition{}, class)
+		class.AddMethod(method)
+		return method
+	}
+
+	a := newMethod("a")
+	b := newMethod("b")
+	c := newMethod("c")
+	d := newMethod("d")
+
+	a.AddCalled(b)
+
+	field := symbols.NewField("x", class)
+	class.Fields.Add(field)
+	class.Fields.AddMethodAccess(symbols.NewFieldKey("x", class.Name), class, c)
+	class.Fields.AddMethodAccess(symbols.NewFieldKey("x", class.Name), class, d)
+
+	components := LackOfCohesionInMethods4Components(class)
+	if len(components) != 2 {
+		t.Fatalf("expected 2 components, got %d", len(components))
+	}
+
+	if LackOfCohesionInMethods4(class) != 2 {
+		t.Errorf("expected LCOM4 = 2, got %d", class.Lcom4)
+	}
+
+	first := components[0]
+	if len(first.Methods) != 2 || first.Methods[0] != a || first.Methods[1] != b || len(first.Fields) != 0 {
+		t.Errorf("unexpected first component: %v", first)
+	}
+
+	second := components[1]
+	if len(second.Methods) != 2 || second.Methods[0] != c || second.Methods[1] != d {
+		t.Errorf("unexpected second component: %v", second)
+	}
+	if len(second.Fields) != 1 || second.Fields[0] != field {
+		t.Errorf("expected field x in second component, got %v", second.Fields)
+	}
+}
+
+func TestLackOfCohesionInMethods4WithTraits(t *testing.T) {
+	file := symbols.NewFile("foo.php")
+	class := symbols.NewClass(`\Foo`, file)
+	trait := symbols.NewTrait(`\Bar`, file)
+	otherTrait := symbols.NewTrait(`\Baz`, file)
+
+	newMethod := func(c *symbols.Class, name string) *symbols.Function {
+		method := symbols.NewMethod(symbols.NewMethodKey(name, c.Name), meta.ElementPosition{}, c)
+		c.AddMethod(method)
+		return method
+	}
+
+	run := newMethod(class, "run")
+	increment := newMethod(trait, "increment")
+	log := newMethod(trait, "log")
+	newMethod(otherTrait, "log")
+
+	class.AddUses(trait)
+	class.AddUses(otherTrait)
+	class.TraitAdaptations = []*symbols.TraitAdaptation{
+		{Trait: trait.Name, Method: "log", InsteadOf: []string{otherTrait.Name}},
+	}
+
+	run.AddCalled(increment)
+
+	field := symbols.NewField("count", trait)
+	trait.Fields.Add(field)
+	trait.Fields.AddMethodAccess(symbols.NewFieldKey("count", trait.Name), trait, increment)
+	trait.Fields.AddMethodAccess(symbols.NewFieldKey("count", trait.Name), trait, log)
+
+	if methods := class.MethodsWithTraits(); methods.Len() != 3 {
+		t.Fatalf("expected 3 methods with traits, got %d", methods.Len())
+	}
+
+	if lcom4 := LackOfCohesionInMethods4(class); lcom4 != 1 {
+		t.Errorf("expected LCOM4 = 1, got %d", lcom4)
+	}
+}
+
+func TestLackOfCohesionInMethodsWithExternalUsers(t *testing.T) {
+	class := symbols.NewClass(`\Foo`, symbols.NewFile("foo.php"))
+
+	a := symbols.NewMethod(symbols.NewMethodKey("a", class.Name), meta.ElementPosition{}, class)
+	b := symbols.NewMethod(symbols.NewMethodKey("b", class.Name), meta.ElementPosition{}, class)
+	class.AddMethod(a)
+	class.AddMethod(b)
+
+	helper := symbols.NewFunction(symbols.NewFuncKey(`\helper`), meta.ElementPosition{})
+	lonely := symbols.NewFunction(symbols.NewFuncKey(`\lonely`), meta.ElementPosition{})
+
+	access := func(name string, fn *symbols.Function) {
+		class.Fields.AddMethodAccess(symbols.NewFieldKey(name, class.Name), class, fn)
+	}
+
+	// x links a with the function outside the class, z is
+	// used only by a function outside the class.
+	access("x", a)
+	access("x", helper)
+	access("y", b)
+	access("z", lonely)
+
+	// The external users count as the users of the fields:
+	// 1 - (2 + 1 + 1) / (3 fields * 2 methods).
+	want := 1 - float64(4)/float64(6)
+	if lcom, ok := LackOfCohesionInMethods(class); !ok || lcom != want {
+		t.Errorf("expected LCOM = %f, got %f", want, lcom)
+	}
+
+	components := LackOfCohesionInMethods4Components(class)
+	if len(components) != 2 {
+		t.Fatalf("expected 2 components, got %d", len(components))
+	}
+
+	first := components[0]
+	if len(first.Methods) != 2 || first.Methods[0] != helper || first.Methods[1] != a {
+		t.Errorf("expected a and helper in the first component, got %v", first.Methods)
+	}
+
+	second := components[1]
+	if len(second.Methods) != 1 || second.Methods[0] != b {
+		t.Errorf("expected only b in the second component, got %v", second.Methods)
+	}
+}
@@ -0,0 +1,347 @@
+package overrides
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/i582/phpstats/internal/stats/symbols"
+)
+
+// Kinds of the relation between a method and the parent method.
+const (
+	// KindOverride is a method that overrides a concrete method of a parent class.
+	KindOverride = "override"
+	// KindImplementation is a method that implements an interface or abstract method.
+	KindImplementation = "implementation"
+)
+
+// Problems of the overriding method signature that break
+// the Liskov substitution principle.
+const (
+	ProblemMoreRequiredParams = "requires more parameters"
+	ProblemFewerParams        = "accepts fewer parameters"
+	ProblemNarrowedParam      = "narrows parameter type"
+	ProblemWidenedReturn      = "widens return type"
+	ProblemReducedVisibility  = "reduces visibility"
+	ProblemStaticChanged      = "changes static modifier"
+)
+
+// Override describes a method that overrides or implements a parent method.
+type Override struct {
+	Method *symbols.Function
+	Parent *symbols.Function
+	Kind   string
+
+	// CallsParent is true if the method calls the parent implementation.
+	CallsParent bool
+	// RefusedBequest is true if the method replaces the concrete parent method
+	// with an empty body or a body that only throws an exception.
+	RefusedBequest bool
+
+	// Problems contains the differences of the signatures that break the
+	// parent contract, for example, the narrowed parameter types.
+	Problems []string
+}
+
+// IsSuspicious checks if the override breaks the parent contract.
+func (o *Override) IsSuspicious() bool {
+	return len(o.Problems) != 0
+}
+
+// Detect finds for all methods of the classes the overridden
+// or implemented parent methods and checks their contracts.
+func Detect(classes *symbols.Classes) []*Override {
+	var res []*Override
+
+	for _, class := range classes.Classes {
+		if class.IsInterface || class.IsTrait {
+			continue
+		}
+
+		for _, method := range class.Methods.Funcs {
+			if method.Signature != nil && method.Signature.Visibility == "private" {
+				continue
+			}
+
+			override, ok := detectMethod(classes, class, method)
+			if !ok {
+				continue
+			}
+
+			res = append(res, override)
+		}
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Method.Name.String() < res[j].Method.Name.String()
+	})
+
+	return res
+}
+
+func detectMethod(classes *symbols.Classes, class *symbols.Class, method *symbols.Function) (*Override, bool) {
+	name := strings.ToLower(method.Name.Name)
+
+	var parent *symbols.Function
+	var fromInterface bool
+
+	visited := map[*symbols.Class]struct{}{class: {}}
+	for cur := parentClass(class); cur != nil; cur = parentClass(cur) {
+		if _, ok := visited[cur]; ok {
+			break
+		}
+		visited[cur] = struct{}{}
+
+		fn, ok := findMethod(cur, name)
+		if ok && (fn.Signature == nil || fn.Signature.Visibility != "private") {
+			parent = fn
+			break
+		}
+	}
+
+	if parent == nil {
+		for _, iface := range interfaces(class) {
+			fn, ok := findMethod(iface, name)
+			if ok {
+				parent = fn
+				fromInterface = true
+				break
+			}
+		}
+	}
+
+	if parent == nil {
+		return nil, false
+	}
+
+	override := &Override{
+		Method:      method,
+		Parent:      parent,
+		Kind:        KindOverride,
+		CallsParent: method.CallsParent,
+	}
+
+	if fromInterface || (parent.Signature != nil && parent.Signature.IsAbstract) {
+		override.Kind = KindImplementation
+	}
+
+	if method.Signature == nil || parent.Signature == nil {
+		return override, true
+	}
+
+	override.RefusedBequest = override.Kind == KindOverride && !method.CallsParent &&
+		method.Signature.IsTrivial && !parent.Signature.IsTrivial
+
+	// Constructors are not checked for compatibility,
+	// unless they are declared in an interface or abstract.
+	if name == "__construct" && override.Kind == KindOverride {
+		return override, true
+	}
+
+	override.Problems = signatureProblems(classes, method.Signature, parent.Signature)
+
+	return override, true
+}
+
+// signatureProblems compares the signature of the method
+// with the signature of the parent method.
+func signatureProblems(classes *symbols.Classes, sign, parent *symbols.Signature) []string {
+	var problems []string
+
+	if sign.CountRequiredParams() > parent.CountRequiredParams() {
+		problems = append(problems, ProblemMoreRequiredParams)
+	}
+	if len(sign.Params) < len(parent.Params) && !sign.HasVariadic() {
+		problems = append(problems, ProblemFewerParams)
+	}
+
+	for i, param := range parent.Params {
+		if i >= len(sign.Params) {
+			break
+		}
+
+		if !isWiderParam(classes, sign.Params[i].Type, param.Type) {
+			problems = append(problems, ProblemNarrowedParam)
+			break
+		}
+	}
+
+	if !isNarrowerReturn(classes, sign.ReturnType, parent.ReturnType) {
+		problems = append(problems, ProblemWidenedReturn)
+	}
+
+	if visibilityLevel(sign.Visibility) < visibilityLevel(parent.Visibility) {
+		problems = append(problems, ProblemReducedVisibility)
+	}
+	if sign.IsStatic != parent.IsStatic {
+		problems = append(problems, ProblemStaticChanged)
+	}
+
+	return problems
+}
+
+// isWiderParam checks if the parameter type of the method accepts
+// all values accepted by the parameter type of the parent method.
+func isWiderParam(classes *symbols.Classes, typ, parent string) bool {
+	if typ == "" || typ == "mixed" || typ == parent {
+		return true
+	}
+	if parent == "" {
+		return false
+	}
+
+	nullable := strings.HasPrefix(typ, "?")
+	parentNullable := strings.HasPrefix(parent, "?")
+	if parentNullable && !nullable {
+		return false
+	}
+
+	return isSubtype(classes, strings.TrimPrefix(parent, "?"), strings.TrimPrefix(typ, "?"))
+}
+
+// isNarrowerReturn checks if the return type of the method is the same
+// as the return type of the parent method or is its subtype.
+func isNarrowerReturn(classes *symbols.Classes, typ, parent string) bool {
+	if parent == "" || parent == "mixed" || typ == parent {
+		return true
+	}
+	if typ == "" {
+		return false
+	}
+
+	nullable := strings.HasPrefix(typ, "?")
+	parentNullable := strings.HasPrefix(parent, "?")
+	if nullable && !parentNullable {
+		return false
+	}
+
+	return isSubtype(classes, strings.TrimPrefix(typ, "?"), strings.TrimPrefix(parent, "?"))
+}
+
+// isSubtype checks if the type is the same as the parent
+// type or is a class inherited from the parent type.
+func isSubtype(classes *symbols.Classes, typ, parent string) bool {
+	if typ == parent || parent == "mixed" {
+		return true
+	}
+
+	switch parent {
+	case "iterable":
+		if typ == "array" {
+			return true
+		}
+		class, ok := classes.Get(typ)
+		return ok && implementsName(class, `\Traversable`)
+	case "object":
+		_, ok := classes.Get(typ)
+		return ok
+	}
+
+	class, ok := classes.Get(typ)
+	if !ok {
+		return false
+	}
+
+	return implementsName(class, parent)
+}
+
+// implementsName checks if the class extends or implements the class with the passed name.
+func implementsName(class *symbols.Class, name string) bool {
+	visited := map[*symbols.Class]struct{}{}
+	queue := []*symbols.Class{class}
+
+	for len(queue) != 0 {
+		cur := queue[0]
+		queue = queue[1:]
+
+		if _, ok := visited[cur]; ok {
+			continue
+		}
+		visited[cur] = struct{}{}
+
+		if strings.EqualFold(cur.Name, name) {
+			return true
+		}
+
+		for _, parent := range cur.Extends.Classes {
+			queue = append(queue, parent)
+		}
+		for _, iface := range cur.Implements.Classes {
+			queue = append(queue, iface)
+		}
+	}
+
+	return false
+}
+
+// interfaces returns all interfaces implemented by the class and its parents,
+// including the interfaces extended by them.
+func interfaces(class *symbols.Class) []*symbols.Class {
+	var res []*symbols.Class
+	visited := map[*symbols.Class]struct{}{}
+	queue := []*symbols.Class{class}
+
+	for len(queue) != 0 {
+		cur := queue[0]
+		queue = queue[1:]
+
+		if _, ok := visited[cur]; ok {
+			continue
+		}
+		visited[cur] = struct{}{}
+
+		if cur.IsInterface {
+			res = append(res, cur)
+		}
+
+		for _, iface := range sortedClasses(cur.Implements) {
+			queue = append(queue, iface)
+		}
+		for _, parent := range sortedClasses(cur.Extends) {
+			queue = append(queue, parent)
+		}
+	}
+
+	return res
+}
+
+// parentClass returns the class from which the class is inherited.
+func parentClass(class *symbols.Class) *symbols.Class {
+	for _, parent := range class.Extends.Classes {
+		return parent
+	}
+	return nil
+}
+
+// findMethod returns the method of the class with the passed lowercase name.
+func findMethod(class *symbols.Class, name string) (*symbols.Function, bool) {
+	for _, method := range class.Methods.Funcs {
+		if strings.ToLower(method.Name.Name) == name {
+			return method, true
+		}
+	}
+	return nil, false
+}
+
+func sortedClasses(c *symbols.Classes) []*symbols.Class {
+	classes := make([]*symbols.Class, 0, c.Len())
+	for _, class := range c.Classes {
+		classes = append(classes, class)
+	}
+
+	sort.Slice(classes, func(i, j int) bool {
+		return classes[i].Name < classes[j].Name
+	})
+
+	return classes
+}
+
+func visibilityLevel(visibility string) int {
+	switch visibility {
+	case "private":
+		return 0
+	case "protected":
+		return 1
+	}
+	return 2
+}
@@ -0,0 +1,82 @@
+package overrides
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/VKCOM/noverify/src/meta"
+
+	"github.com/i582/phpstats/internal/stats/symbols"
+)
+
+func TestDetect(t *testing.T) {
+	classes := symbols.NewClasses()
+	file := symbols.NewFile("foo.php")
+
+	newClass := func(class *symbols.Class) *symbols.Class {
+		classes.Add(class)
+		return class
+	}
+	newMethod := func(class *symbols.Class, name string, sign *symbols.Signature) *symbols.Function {
+		fn := symbols.NewMethod(symbols.NewMethodKey(name, class.Name), meta.ElementPosition{Filename: "foo.php"}, class)
+		fn.Signature = sign
+		class.AddMethod(fn)
+		return fn
+	}
+
+	repository := newClass(symbols.NewInterface(`\Repository`, file))
+	base := newClass(symbols.NewClass(`\Base`, file))
+	user := newClass(symbols.NewClass(`\User`, file))
+	child := newClass(symbols.NewClass(`\Child`, file))
+
+	child.AddExtends(base)
+	base.AddImplements(repository)
+
+	newMethod(repository, "find", &symbols.Signature{
+		Params:     []symbols.Param{{Name: "id", Type: "int"}},
+		ReturnType: "?" + base.Name,
+		Visibility: "public",
+		IsAbstract: true,
+	})
+	newMethod(base, "save", &symbols.Signature{
+		Params:     []symbols.Param{{Name: "entity"}},
+		Visibility: "public",
+	})
+	newMethod(base, "log", &symbols.Signature{Visibility: "protected"})
+
+	find := newMethod(child, "find", &symbols.Signature{
+		Params:     []symbols.Param{{Name: "id"}},
+		ReturnType: child.Name,
+		Visibility: "public",
+	})
+	save := newMethod(child, "save", &symbols.Signature{
+		Params:     []symbols.Param{{Name: "entity", Type: user.Name}, {Name: "flush", Type: "bool"}},
+		Visibility: "public",
+	})
+	logMethod := newMethod(child, "log", &symbols.Signature{Visibility: "protected", IsTrivial: true})
+	newMethod(child, "extra", &symbols.Signature{Visibility: "public"})
+
+	list := Detect(classes)
+
+	got := map[*symbols.Function]*Override{}
+	for _, o := range list {
+		got[o.Method] = o
+	}
+
+	if len(got) != 3 {
+		t.Fatalf("expected 3 overrides, got %d", len(got))
+	}
+
+	if o := got[find]; o.Kind != KindImplementation || len(o.Problems) != 0 {
+		t.Errorf("find: got kind %s, problems %v", o.Kind, o.Problems)
+	}
+
+	wantProblems := []string{ProblemMoreRequiredParams, ProblemNarrowedParam}
+	if o := got[save]; o.Kind != KindOverride || !reflect.DeepEqual(o.Problems, wantProblems) {
+		t.Errorf("save: got kind %s, problems %v, want problems %v", o.Kind, o.Problems, wantProblems)
+	}
+
+	if o := got[logMethod]; !o.RefusedBequest {
+		t.Errorf("log: expected refused bequest")
+	}
+}
@@ -0,0 +1,437 @@
+package reachability
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/i582/phpstats/internal/stats/symbols"
+)
+
+// Kinds of entry points.
+const (
+	KindScript     = "script"
+	KindController = "controller"
+	KindCommand    = "command"
+	KindTagged     = "tagged"
+)
+
+// Defaults of the entry points configuration.
+var (
+	DefaultScripts = []string{`(^|[/\\])web[/\\]`}
+	DefaultTags    = []string{"@entrypoint"}
+)
+
+// Config describes how to find the entry points of the program.
+type Config struct {
+	// Scripts are the regular expressions for the paths of files whose
+	// top-level code is executed directly, the paths are relative to
+	// the project root if RelativePath is set.
+	Scripts []string
+	// Controllers and Commands are the regular expressions for the
+	// full names of classes whose public methods are called externally.
+	Controllers []string
+	Commands    []string
+	// Tags are the PHPDoc tags that mark functions and methods as entry points.
+	Tags []string
+
+	// RelativePath converts the paths of scripts for the names of entry points.
+	RelativePath func(path string) string
+}
+
+// EntryPoint is a file, a class or a function from which the execution starts.
+type EntryPoint struct {
+	Kind string
+	Name string
+
+	// Files and Funcs are the roots of the traversal,
+	// the file for scripts, public methods for classes.
+	Files []*symbols.File
+	Funcs []*symbols.Function
+
+	// Reachable stores the symbols reachable from the entry point.
+	Reachable *Set
+}
+
+// Set stores the reachable functions, classes and files.
+type Set struct {
+	Funcs   map[*symbols.Function]struct{}
+	Classes map[*symbols.Class]struct{}
+	Files   map[*symbols.File]struct{}
+}
+
+func NewSet() *Set {
+	return &Set{
+		Funcs:   map[*symbols.Function]struct{}{},
+		Classes: map[*symbols.Class]struct{}{},
+		Files:   map[*symbols.File]struct{}{},
+	}
+}
+
+func (s *Set) HasFunc(fn *symbols.Function) bool {
+	_, ok := s.Funcs[fn]
+	return ok
+}
+
+func (s *Set) HasClass(class *symbols.Class) bool {
+	_, ok := s.Classes[class]
+	return ok
+}
+
+func (s *Set) HasFile(file *symbols.File) bool {
+	_, ok := s.Files[file]
+	return ok
+}
+
+// Result is the reachability of the whole program from all entry points.
+type Result struct {
+	Mode        symbols.CallGraphMode
+	EntryPoints []*EntryPoint
+	Reachable   *Set
+}
+
+// Analyze finds the entry points and the symbols reachable from them
+// through the calls, the class instantiations and the file requires.
+func Analyze(files *symbols.Files, funcs *symbols.Functions, classes *symbols.Classes, cfg Config, mode symbols.CallGraphMode) (*Result, error) {
+	entries, err := Find(files, funcs, classes, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &Result{
+		Mode:        mode,
+		EntryPoints: entries,
+		Reachable:   NewSet(),
+	}
+
+	for _, entry := range entries {
+		entry.Reachable = NewSet()
+		walk(files, entry.Reachable, entry, mode)
+		walk(files, res.Reachable, entry, mode)
+	}
+
+	return res, nil
+}
+
+// Find returns the entry points of the program sorted by kind and name.
+func Find(files *symbols.Files, funcs *symbols.Functions, classes *symbols.Classes, cfg Config) ([]*EntryPoint, error) {
+	scripts, err := compile(cfg.Scripts)
+	if err != nil {
+		return nil, fmt.Errorf("scripts: %v", err)
+	}
+	controllers, err := compile(cfg.Controllers)
+	if err != nil {
+		return nil, fmt.Errorf("controllers: %v", err)
+	}
+	commands, err := compile(cfg.Commands)
+	if err != nil {
+		return nil, fmt.Errorf("commands: %v", err)
+	}
+
+	var entries []*EntryPoint
+
+	if scripts != nil {
+		for _, file := range files.Files {
+			if symbols.IsEmbeddedFunc(file.Path) {
+				continue
+			}
+
+			name := file.Path
+			if cfg.RelativePath != nil {
+				name = cfg.RelativePath(file.Path)
+			}
+
+			if !scripts.MatchString(name) {
+				continue
+			}
+
+			entries = append(entries, &EntryPoint{
+				Kind:  KindScript,
+				Name:  name,
+				Files: []*symbols.File{file},
+			})
+		}
+	}
+
+	for _, class := range classes.Classes {
+		if class.IsVendor || class.IsInterface || class.IsTrait || class.IsAbstract {
+			continue
+		}
+
+		var kind string
+		switch {
+		case controllers != nil && controllers.MatchString(class.Name):
+			kind = KindController
+		case commands != nil && commands.MatchString(class.Name):
+			kind = KindCommand
+		default:
+			continue
+		}
+
+		entries = append(entries, &EntryPoint{
+			Kind:  kind,
+			Name:  class.Name,
+			Funcs: publicMethods(class),
+		})
+	}
+
+	if len(cfg.Tags) != 0 {
+		for _, fn := range funcs.Funcs {
+			if !hasAnyTag(fn, cfg.Tags) {
+				continue
+			}
+
+			entries = append(entries, &EntryPoint{
+				Kind:  KindTagged,
+				Name:  fn.Name.String(),
+				Funcs: []*symbols.Function{fn},
+			})
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Kind != entries[j].Kind {
+			return entries[i].Kind < entries[j].Kind
+		}
+		return entries[i].Name < entries[j].Name
+	})
+
+	return entries, nil
+}
+
+// walk adds to the set all symbols reachable from the entry point.
+func walk(files *symbols.Files, set *Set, entry *EntryPoint, mode symbols.CallGraphMode) {
+	var funcQueue []*symbols.Function
+	var fileQueue []*symbols.File
+
+	addFunc := func(fn *symbols.Function) {
+		if set.HasFunc(fn) {
+			return
+		}
+		set.Funcs[fn] = struct{}{}
+		funcQueue = append(funcQueue, fn)
+	}
+	addFile := func(file *symbols.File) {
+		if file == nil || set.HasFile(file) {
+			return
+		}
+		set.Files[file] = struct{}{}
+		fileQueue = append(fileQueue, file)
+	}
+	addClass := func(class *symbols.Class) {
+		for cur := class; cur != nil && !set.HasClass(cur); cur = parentClass(cur) {
+			set.Classes[cur] = struct{}{}
+			addFile(cur.File)
+		}
+	}
+	addCreated := func(class *symbols.Class) {
+		addClass(class)
+		if constructor, ok := findConstructor(class); ok {
+			addFunc(constructor)
+		}
+	}
+
+	for _, file := range entry.Files {
+		addFile(file)
+	}
+	for _, fn := range entry.Funcs {
+		addFunc(fn)
+	}
+
+	for len(funcQueue) != 0 || len(fileQueue) != 0 {
+		for len(fileQueue) != 0 {
+			file := fileQueue[0]
+			fileQueue = fileQueue[1:]
+
+			for _, required := range file.RequiredRoot.Files {
+				addFile(required)
+			}
+			for _, required := range file.RequiredBlock.Files {
+				addFile(required)
+			}
+			for _, called := range file.Called.Funcs {
+				addFunc(called)
+			}
+			for _, class := range file.Instantiates.Classes {
+				addCreated(class)
+			}
+		}
+
+		for len(funcQueue) != 0 {
+			fn := funcQueue[0]
+			funcQueue = funcQueue[1:]
+
+			if fn.Class != nil {
+				addClass(fn.Class)
+			}
+			if file, ok := files.Get(fn.Pos.Filename); ok {
+				addFile(file)
+			}
+
+			for _, called := range fn.Callees(mode).Funcs {
+				addFunc(called)
+			}
+			for _, class := range fn.Instantiates.Classes {
+				addCreated(class)
+			}
+		}
+	}
+}
+
+// Unreachable returns the project functions, classes and files that are not
+// reachable from any entry point, the tests are skipped.
+func (r *Result) Unreachable(files *symbols.Files, funcs *symbols.Functions, classes *symbols.Classes) *Set {
+	res := NewSet()
+
+	for _, fn := range funcs.Funcs {
+		if !fn.IsTestable() || fn.IsAnonymous() || r.Reachable.HasFunc(fn) {
+			continue
+		}
+		res.Funcs[fn] = struct{}{}
+	}
+	for _, class := range classes.Classes {
+		if class.IsVendor || class.IsTest || class.IsAnonymous || r.Reachable.HasClass(class) {
+			continue
+		}
+		res.Classes[class] = struct{}{}
+	}
+	for _, file := range files.Files {
+		if symbols.IsEmbeddedFunc(file.Path) || r.Reachable.HasFile(file) || isTestFile(file) {
+			continue
+		}
+		res.Files[file] = struct{}{}
+	}
+
+	return res
+}
+
+// isTestFile checks if the file declares only test classes.
+func isTestFile(file *symbols.File) bool {
+	if file.Classes.Len() == 0 {
+		return false
+	}
+	for _, class := range file.Classes.Classes {
+		if !class.IsTest {
+			return false
+		}
+	}
+	return true
+}
+
+func compile(patterns []string) (*regexp.Regexp, error) {
+	if len(patterns) == 0 {
+		return nil, nil
+	}
+	return regexp.Compile(strings.Join(patterns, "|"))
+}
+
+func hasAnyTag(fn *symbols.Function, tags []string) bool {
+	for _, tag := range tags {
+		if fn.HasDocTag(tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// publicMethods returns the public methods of the class, including the
+// inherited ones, the methods of the class itself take precedence.
+func publicMethods(class *symbols.Class) []*symbols.Function {
+	var res []*symbols.Function
+	seen := map[string]struct{}{}
+	visited := map[*symbols.Class]struct{}{}
+
+	for cur := class; cur != nil; cur = parentClass(cur) {
+		if _, ok := visited[cur]; ok {
+			break
+		}
+		visited[cur] = struct{}{}
+
+		for _, method := range sortedMethods(cur) {
+			name := strings.ToLower(method.Name.Name)
+			if _, ok := seen[name]; ok {
+				continue
+			}
+			seen[name] = struct{}{}
+
+			if method.Signature != nil && (method.Signature.Visibility != "public" || method.Signature.IsAbstract) {
+				continue
+			}
+
+			res = append(res, method)
+		}
+	}
+
+	return res
+}
+
+// findConstructor returns the constructor of the class or of its nearest parent.
+func findConstructor(class *symbols.Class) (*symbols.Function, bool) {
+	visited := map[*symbols.Class]struct{}{}
+
+	for cur := class; cur != nil; cur = parentClass(cur) {
+		if _, ok := visited[cur]; ok {
+			break
+		}
+		visited[cur] = struct{}{}
+
+		for _, method := range cur.Methods.Funcs {
+			if strings.EqualFold(method.Name.Name, "__construct") {
+				return method, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// parentClass returns the class from which the class is inherited.
+func parentClass(class *symbols.Class) *symbols.Class {
+	for _, parent := range class.Extends.Classes {
+		return parent
+	}
+	return nil
+}
+
+func sortedMethods(class *symbols.Class) []*symbols.Function {
+	methods := make([]*symbols.Function, 0, class.Methods.Len())
+	for _, method := range class.Methods.Funcs {
+		methods = append(methods, method)
+	}
+
+	sort.Slice(methods, func(i, j int) bool {
+		return methods[i].Name.Name < methods[j].Name.Name
+	})
+
+	return methods
+}
+
+// CountProject returns the number of the project functions,
+// classes and files in the set, vendor and built-in code is skipped.
+func (s *Set) CountProject() (funcs, classes, files int64) {
+	for fn := range s.Funcs {
+		if fn.IsTestable() && !fn.IsAnonymous() {
+			funcs++
+		}
+	}
+	for class := range s.Classes {
+		if !class.IsVendor && !class.IsTest {
+			classes++
+		}
+	}
+	for file := range s.Files {
+		if !symbols.IsEmbeddedFunc(file.Path) {
+			files++
+		}
+	}
+	return funcs, classes, files
+}
+
+// CountByKind returns the number of entry points of each kind.
+func (r *Result) CountByKind() map[string]int64 {
+	counts := make(map[string]int64)
+	for _, entry := range r.EntryPoints {
+		counts[entry.Kind]++
+	}
+	return counts
+}
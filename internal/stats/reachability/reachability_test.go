@@ -0,0 +1,92 @@
+package reachability
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/i582/phpstats/internal/stats/symbols"
+	"github.com/i582/phpstats/internal/stats/symbols/symbolstest"
+)
+
+func TestAnalyze(t *testing.T) {
+	g := symbolstest.NewGraph("/project/lib.php")
+	funcs, classes, lib := g.Funcs, g.Classes, g.File
+
+	files := symbols.NewFiles()
+	index := symbols.NewFile("/project/web/index.php")
+	files.Add(index)
+	files.Add(lib)
+
+	app := g.Class(`\App`)
+	constructor := g.Method(app, "__construct")
+
+	render := g.Func(`\render`)
+	escape := g.Func(`\escape`)
+	cron := g.Func(`\cron`)
+	dead := g.Func(`\dead`)
+
+	g.Call(render, escape)
+	cron.DocTags = []string{"@entrypoint"}
+
+	index.AddRequiredRootFile(lib)
+	index.Called.Add(render)
+	index.Instantiates.Add(app)
+
+	res, err := Analyze(files, funcs, classes, Config{
+		Scripts: DefaultScripts,
+		Tags:    DefaultTags,
+	}, symbols.DirectCalls)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(res.EntryPoints) != 2 {
+		t.Fatalf("expected 2 entry points, got %d", len(res.EntryPoints))
+	}
+
+	script := res.EntryPoints[0]
+	if script.Kind != KindScript || !script.Reachable.HasFunc(escape) || !script.Reachable.HasFunc(constructor) || !script.Reachable.HasFile(lib) {
+		t.Errorf("script entry point must reach render, escape, the constructor and the required file")
+	}
+	if script.Reachable.HasFunc(cron) {
+		t.Errorf("script entry point must not reach the tagged function")
+	}
+
+	unreachable := res.Unreachable(files, funcs, classes)
+	if len(unreachable.Funcs) != 1 || !unreachable.HasFunc(dead) {
+		t.Errorf("expected only %s to be unreachable, got %d functions", dead.Name, len(unreachable.Funcs))
+	}
+}
+
+func TestFindScriptsUnderWebRoot(t *testing.T) {
+	files := symbols.NewFiles()
+	for _, path := range []string{
+		"/srv/web/app/lib.php",
+		"/srv/web/app/src/web.php",
+		"/srv/web/app/web/index.php",
+		"/srv/web/app/public/web/admin.php",
+	} {
+		files.Add(symbols.NewFile(path))
+	}
+
+	entries, err := Find(files, symbols.NewFunctions(), symbols.NewClasses(), Config{
+		Scripts: DefaultScripts,
+		RelativePath: func(path string) string {
+			return strings.TrimPrefix(path, "/srv/web/app/")
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name)
+	}
+
+	want := []string{"public/web/admin.php", "web/index.php"}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("got scripts %v, want %v", names, want)
+	}
+}
@@ -0,0 +1,107 @@
+package symbols
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CallGraphMode determines which edges of the call graph are considered.
+type CallGraphMode uint8
+
+const (
+	// DirectCalls mode uses only the calls of the resolved declarations.
+	DirectCalls CallGraphMode = iota
+	// CHACalls mode (Class Hierarchy Analysis) also links the calls of methods
+	// with all methods overriding or implementing them in subclasses.
+	CHACalls
+	// RTACalls mode (Rapid Type Analysis) is the same as CHACalls,
+	// but only for classes whose objects are created somewhere.
+	RTACalls
+)
+
+var callGraphModeNames = map[CallGraphMode]string{
+	DirectCalls: "direct",
+	CHACalls:    "cha",
+	RTACalls:    "rta",
+}
+
+func (m CallGraphMode) String() string {
+	return callGraphModeNames[m]
+}
+
+// ParseCallGraphMode returns the mode by its name, "direct", "cha" or "rta".
+func ParseCallGraphMode(name string) (CallGraphMode, error) {
+	for mode, modeName := range callGraphModeNames {
+		if modeName == name {
+			return mode, nil
+		}
+	}
+	return DirectCalls, fmt.Errorf("unknown call graph mode '%s', available modes: direct, cha, rta", name)
+}
+
+// Callees returns the functions called by the function in the passed mode.
+func (f *Function) Callees(mode CallGraphMode) *Functions {
+	return withVirtual(f.Called, f.VirtualCalled, mode)
+}
+
+// Callers returns the functions that call the function in the passed mode.
+func (f *Function) Callers(mode CallGraphMode) *Functions {
+	if mode == DirectCalls || f.VirtualCalledBy.Len() == 0 {
+		return f.CalledBy
+	}
+	if mode == RTACalls && !f.isLive() {
+		return f.CalledBy
+	}
+
+	res := NewFunctions()
+	for key, fn := range f.CalledBy.Funcs {
+		res.Funcs[key] = fn
+	}
+	for key, fn := range f.VirtualCalledBy.Funcs {
+		res.Funcs[key] = fn
+	}
+	return res
+}
+
+// IsUnused checks if the function is not called in the passed mode.
+//
+// The magic methods are called implicitly, so they are never considered unused.
+func (f *Function) IsUnused(mode CallGraphMode) bool {
+	if f.IsMethod() && strings.HasPrefix(f.Name.Name, "__") {
+		return false
+	}
+	return f.Callers(mode).Len() == 0
+}
+
+// IsVirtualCall checks if the function calls the passed one
+// only through the dynamic dispatch.
+func (f *Function) IsVirtualCall(fn *Function) bool {
+	if _, ok := f.Called.Get(fn.Name); ok {
+		return false
+	}
+	_, ok := f.VirtualCalled.Get(fn.Name)
+	return ok
+}
+
+// isLive checks if the method can be called on a created object.
+func (f *Function) isLive() bool {
+	return f.Class == nil || f.Class.HasInstances
+}
+
+func withVirtual(direct, virtual *Functions, mode CallGraphMode) *Functions {
+	if mode == DirectCalls || virtual.Len() == 0 {
+		return direct
+	}
+
+	res := NewFunctions()
+	for key, fn := range direct.Funcs {
+		res.Funcs[key] = fn
+	}
+	for key, fn := range virtual.Funcs {
+		if mode == RTACalls && !fn.isLive() {
+			continue
+		}
+		res.Funcs[key] = fn
+	}
+	return res
+}
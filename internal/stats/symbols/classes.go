@@ -24,6 +24,66 @@ func (c *Classes) Len() int {
 	return len(c.Classes)
 }
 
+// WithoutTests returns a new collection that contains all classes except tests.
+func (c *Classes) WithoutTests() *Classes {
+	classes := NewClasses()
+
+	for name, class := range c.Classes {
+		if class.IsTest {
+			continue
+		}
+
+		classes.Classes[name] = class
+	}
+
+	return classes
+}
+
+func (c *Classes) CountTestClasses() int64 {
+	var count int64
+	for _, class := range c.Classes {
+		if class.IsTest {
+			count++
+		}
+	}
+	return count
+}
+
+// CountTestableClasses returns the number of project classes
+// that can be used by tests.
+func (c *Classes) CountTestableClasses() int64 {
+	var count int64
+	for _, class := range c.Classes {
+		if class.IsTestable() {
+			count++
+		}
+	}
+	return count
+}
+
+// CountUntestedClasses returns the number of project classes
+// that are not used by any test.
+func (c *Classes) CountUntestedClasses() int64 {
+	var count int64
+	for _, class := range c.Classes {
+		if class.IsTestable() && class.CountTests() == 0 {
+			count++
+		}
+	}
+	return count
+}
+
+// CountExceptions returns the number of classes that are thrown or caught somewhere.
+func (c *Classes) CountExceptions() int64 {
+	var count int64
+	for _, class := range c.Classes {
+		if class.ThrownIn.Len() != 0 || class.CaughtIn.Len() != 0 {
+			count++
+		}
+	}
+	return count
+}
+
 func (c *Classes) CountClasses() int64 {
 	var count int64
 	for _, class := range c.Classes {
@@ -291,6 +351,14 @@ func (c *Classes) Get(name string) (*Class, bool) {
 	return class, ok
 }
 
+// MarkInstantiated marks the class as instantiated, the classes are marked
+// concurrently by the checkers of different files, so the flag is set under the lock.
+func (c *Classes) MarkInstantiated(class *Class) {
+	c.m.Lock()
+	class.IsInstantiated = true
+	c.m.Unlock()
+}
+
 type Class struct {
 	Name string
 	File *File
@@ -303,6 +371,17 @@ type Class struct {
 	ImplementsBy *Classes
 	ExtendsBy    *Classes
 
+	// Uses stores the traits used by the class.
+	Uses *Classes
+	// UsedBy stores the classes and traits that use the trait.
+	UsedBy *Classes
+
+	// UsedTraitNames are the full names of the traits from the `use`
+	// statements of the class, they are resolved in Uses after indexing.
+	UsedTraitNames []string
+	// TraitAdaptations stores the `insteadof` and `as` rules of the trait uses.
+	TraitAdaptations []*TraitAdaptation
+
 	IsAbstract  bool
 	IsInterface bool
 	IsTrait     bool
@@ -319,8 +398,46 @@ type Class struct {
 	// Зависят от нас
 	DepsBy *Classes
 
+	// DepEdges stores the number of dependencies on each class by kind.
+	DepEdges *DependencyEdges
+
 	IsVendor bool
 
+	// IsAnonymous is true for anonymous classes, they are
+	// owned by the function in which they are declared.
+	IsAnonymous bool
+	OwnerName   FuncKey
+	Owner       *Function
+
+	// ParentName is the full name of the parent class, it is set
+	// even if the parent class itself was not found.
+	ParentName string
+
+	// IsTest is true for PHPUnit test classes and classes from test directories.
+	IsTest bool
+
+	// IsInstantiated is true if an object of the class is created with new somewhere.
+	IsInstantiated bool
+	// HasInstances is true if an object of the class or of any
+	// of its subclasses is created, the methods of the class
+	// are live for the Rapid Type Analysis only in this case.
+	HasInstances bool
+
+	// ThrownIn stores the places where the class is thrown as an exception.
+	ThrownIn *ExceptionSites
+	// CaughtIn stores the places where the class is caught as an exception.
+	CaughtIn *ExceptionSites
+	// UncaughtIn stores the entry points from which the exception can be thrown out.
+	UncaughtIn *Functions
+
+	// TestedBy stores the test methods that use the class directly or transitively.
+	TestedBy *Functions
+
+	// Deprecated is true if the class is marked with the @deprecated tag,
+	// DeprecationMessage is the text after the tag.
+	Deprecated         bool
+	DeprecationMessage string
+
 	// metrics
 	LcomResolved bool
 	Lcom         float64
@@ -341,8 +458,15 @@ func NewClass(name string, file *File) *Class {
 		Extends:       NewClasses(),
 		ImplementsBy:  NewClasses(),
 		ExtendsBy:     NewClasses(),
+		Uses:          NewClasses(),
+		UsedBy:        NewClasses(),
 		Deps:          NewClasses(),
 		DepsBy:        NewClasses(),
+		DepEdges:      NewDependencyEdges(),
+		TestedBy:      NewFunctions(),
+		ThrownIn:      NewExceptionSites(),
+		CaughtIn:      NewExceptionSites(),
+		UncaughtIn:    NewFunctions(),
 	}
 }
 
@@ -367,6 +491,30 @@ func NewTrait(name string, file *File) *Class {
 	return class
 }
 
+func (c *Class) AddTestedBy(test *Function) {
+	c.TestedBy.Add(test)
+}
+
+// CountTests returns the number of test methods that use the class.
+func (c *Class) CountTests() int64 {
+	return int64(c.TestedBy.Len())
+}
+
+// IsTestable checks if the class is a project class that can be used by tests.
+func (c *Class) IsTestable() bool {
+	return !c.IsVendor && !c.IsTest && !c.IsInterface && !c.IsTrait
+}
+
+// IsDirectlyTested checks if the class is used directly by any test class.
+func (c *Class) IsDirectlyTested() bool {
+	for _, class := range c.DepsBy.Classes {
+		if class.IsTest {
+			return true
+		}
+	}
+	return false
+}
+
 func (c *Class) AddMethod(fn *Function) {
 	c.Methods.Add(fn)
 }
@@ -389,6 +537,18 @@ func (c *Class) AddDeps(class *Class) {
 	c.Deps.Add(class)
 }
 
+// AddDependency adds the class to the dependencies and
+// counts one more dependency of the passed kind on it.
+func (c *Class) AddDependency(class *Class, kind DepKind) {
+	if class == nil || c == class {
+		return
+	}
+
+	c.AddDeps(class)
+	class.AddDepsBy(c)
+	c.DepEdges.Add(class.Name, kind)
+}
+
 func (c *Class) AddDepsBy(class *Class) {
 	if c == class {
 		return
@@ -439,6 +599,21 @@ func (c *Class) CountFullyTypedMethods() int64 {
 	return count
 }
 
+// CountDuplicatedLines returns the number of duplicated lines in the class
+// methods and the total number of lines in them.
+func (c *Class) CountDuplicatedLines() (duplicated, all int64) {
+	if c.File == nil {
+		return 0, 0
+	}
+
+	for _, method := range c.Methods.Funcs {
+		duplicated += c.File.CountDuplicatedLinesInRange(method.Pos.Line, method.Pos.EndLine)
+		all += int64(method.Pos.EndLine-method.Pos.Line) + 1
+	}
+
+	return duplicated, all
+}
+
 // GobEncode is a custom gob marshaller
 func (c *Class) GobEncode() ([]byte, error) {
 	w := new(bytes.Buffer)
@@ -467,6 +642,30 @@ func (c *Class) GobEncode() ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
+	err = encoder.Encode(c.UsedTraitNames)
+	if err != nil {
+		return nil, err
+	}
+	err = encoder.Encode(c.TraitAdaptations)
+	if err != nil {
+		return nil, err
+	}
+	err = encoder.Encode(c.IsAnonymous)
+	if err != nil {
+		return nil, err
+	}
+	err = encoder.Encode(c.OwnerName)
+	if err != nil {
+		return nil, err
+	}
+	err = encoder.Encode(c.Deprecated)
+	if err != nil {
+		return nil, err
+	}
+	err = encoder.Encode(c.DeprecationMessage)
+	if err != nil {
+		return nil, err
+	}
 	return w.Bytes(), nil
 }
 
@@ -498,5 +697,29 @@ func (c *Class) GobDecode(buf []byte) error {
 	if err != nil {
 		return err
 	}
+	err = decoder.Decode(&c.UsedTraitNames)
+	if err != nil {
+		return err
+	}
+	err = decoder.Decode(&c.TraitAdaptations)
+	if err != nil {
+		return err
+	}
+	err = decoder.Decode(&c.IsAnonymous)
+	if err != nil {
+		return err
+	}
+	err = decoder.Decode(&c.OwnerName)
+	if err != nil {
+		return err
+	}
+	err = decoder.Decode(&c.Deprecated)
+	if err != nil {
+		return err
+	}
+	err = decoder.Decode(&c.DeprecationMessage)
+	if err != nil {
+		return err
+	}
 	return nil
 }
@@ -11,6 +11,11 @@ type Constant struct {
 	Class *Class
 
 	Used *Functions
+
+	// Deprecated is true if the constant is marked with the @deprecated tag,
+	// DeprecationMessage is the text after the tag.
+	Deprecated         bool
+	DeprecationMessage string
 }
 
 func NewConstant(name string, class *Class) *Constant {
@@ -82,7 +87,7 @@ func (c *Constants) AddMethodAccess(constantKey Constant, method *Function) {
 	}
 
 	if method.Class != nil {
-		method.Class.AddDeps(constant.Class)
+		method.Class.AddDependency(constant.Class, DepConstant)
 	}
 	method.UsedConstants.Add(constant)
 	constant.Used.Add(method)
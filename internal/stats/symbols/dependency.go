@@ -0,0 +1,190 @@
+package symbols
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// DepKind is the kind of a dependency between two symbols.
+type DepKind uint8
+
+const (
+	DepCall DepKind = iota
+	DepStaticCall
+	// DepIndirectCall is a call through a string or array callable.
+	DepIndirectCall
+	DepNew
+	DepExtends
+	DepImplements
+	DepTrait
+	// DepTypeHint covers the types of parameters, return values and properties,
+	// the PHPDoc types, instanceof checks and catch clauses.
+	DepTypeHint
+	DepConstant
+	DepProperty
+
+	countDepKinds
+)
+
+var depKindNames = [countDepKinds]string{
+	DepCall:         "call",
+	DepStaticCall:   "static call",
+	DepIndirectCall: "indirect call",
+	DepNew:          "new",
+	DepExtends:      "extends",
+	DepImplements:   "implements",
+	DepTrait:        "trait",
+	DepTypeHint:     "type hint",
+	DepConstant:     "constant",
+	DepProperty:     "property",
+}
+
+func (k DepKind) String() string {
+	if k >= countDepKinds {
+		return "unknown"
+	}
+	return depKindNames[k]
+}
+
+// Location is a place in a file where a symbol is used.
+type Location struct {
+	File string
+	Line int32
+}
+
+func (l Location) String() string {
+	return fmt.Sprintf("%s:%d", l.File, l.Line)
+}
+
+// Dependency stores the number of occurrences of each kind
+// of dependency of one symbol on another.
+type Dependency struct {
+	Counts [countDepKinds]int64
+
+	// Sites stores the places of the occurrences, if they are known.
+	Sites []Location
+}
+
+// Count returns the total number of occurrences.
+func (d *Dependency) Count() int64 {
+	var count int64
+	for _, c := range d.Counts {
+		count += c
+	}
+	return count
+}
+
+// Kinds returns the kinds of the dependency that occur at least once.
+func (d *Dependency) Kinds() []DepKind {
+	var kinds []DepKind
+	for kind, c := range d.Counts {
+		if c != 0 {
+			kinds = append(kinds, DepKind(kind))
+		}
+	}
+	return kinds
+}
+
+// CountsByKind returns the number of occurrences for each kind name.
+func (d *Dependency) CountsByKind() map[string]int64 {
+	counts := make(map[string]int64)
+	for _, kind := range d.Kinds() {
+		counts[kind.String()] = d.Counts[kind]
+	}
+	return counts
+}
+
+// SortedSites returns the places of the occurrences sorted by file and line.
+func (d *Dependency) SortedSites() []Location {
+	sites := make([]Location, len(d.Sites))
+	copy(sites, d.Sites)
+
+	sort.Slice(sites, func(i, j int) bool {
+		if sites[i].File != sites[j].File {
+			return sites[i].File < sites[j].File
+		}
+		return sites[i].Line < sites[j].Line
+	})
+
+	return sites
+}
+
+// String returns the kinds with their counts, for example, "call (3), new (1)".
+func (d *Dependency) String() string {
+	kinds := d.Kinds()
+	parts := make([]string, 0, len(kinds))
+	for _, kind := range kinds {
+		parts = append(parts, fmt.Sprintf("%s (%d)", kind, d.Counts[kind]))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// DependencyEdges stores the weighted dependencies of a symbol
+// on other symbols, the key is the name of the other symbol.
+type DependencyEdges struct {
+	m sync.Mutex
+
+	Edges map[string]*Dependency
+}
+
+func NewDependencyEdges() *DependencyEdges {
+	return &DependencyEdges{
+		Edges: map[string]*Dependency{},
+	}
+}
+
+// Add counts one more occurrence of the dependency of the passed kind.
+func (e *DependencyEdges) Add(name string, kind DepKind) {
+	e.m.Lock()
+	e.get(name).Counts[kind]++
+	e.m.Unlock()
+}
+
+// AddAt is the same as Add, but also stores the place of the occurrence.
+func (e *DependencyEdges) AddAt(name string, kind DepKind, site Location) {
+	e.m.Lock()
+	dep := e.get(name)
+	dep.Counts[kind]++
+	dep.Sites = append(dep.Sites, site)
+	e.m.Unlock()
+}
+
+func (e *DependencyEdges) get(name string) *Dependency {
+	dep, ok := e.Edges[name]
+	if !ok {
+		dep = &Dependency{}
+		e.Edges[name] = dep
+	}
+	return dep
+}
+
+func (e *DependencyEdges) Get(name string) (*Dependency, bool) {
+	e.m.Lock()
+	dep, ok := e.Edges[name]
+	e.m.Unlock()
+	return dep, ok
+}
+
+// Names returns the names of all symbols in the edges sorted
+// by the number of occurrences, the most used first.
+func (e *DependencyEdges) Names() []string {
+	e.m.Lock()
+	defer e.m.Unlock()
+
+	names := make([]string, 0, len(e.Edges))
+	for name := range e.Edges {
+		names = append(names, name)
+	}
+
+	sort.Slice(names, func(i, j int) bool {
+		ci, cj := e.Edges[names[i]].Count(), e.Edges[names[j]].Count()
+		if ci == cj {
+			return names[i] < names[j]
+		}
+		return ci > cj
+	})
+
+	return names
+}
@@ -0,0 +1,39 @@
+package symbols
+
+import (
+	"sort"
+)
+
+// Effects is a set of side effect categories, for example, "db" or "output".
+type Effects struct {
+	Names map[string]struct{}
+}
+
+func NewEffects() *Effects {
+	return &Effects{
+		Names: map[string]struct{}{},
+	}
+}
+
+func (e *Effects) Len() int {
+	return len(e.Names)
+}
+
+func (e *Effects) Add(name string) {
+	e.Names[name] = struct{}{}
+}
+
+func (e *Effects) Has(name string) bool {
+	_, ok := e.Names[name]
+	return ok
+}
+
+// Sorted returns the names of the effects sorted alphabetically.
+func (e *Effects) Sorted() []string {
+	names := make([]string, 0, len(e.Names))
+	for name := range e.Names {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
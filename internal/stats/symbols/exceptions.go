@@ -0,0 +1,66 @@
+package symbols
+
+import (
+	"sort"
+	"sync"
+)
+
+// ExceptionSite is a place in the function where an exception is thrown or caught.
+type ExceptionSite struct {
+	Exception *Class
+	Func      *Function
+	Line      int32
+
+	// Guards are the exceptions caught by the try blocks
+	// that enclose the site within the function.
+	Guards []*Class
+}
+
+type ExceptionSites struct {
+	m sync.Mutex
+
+	Sites []*ExceptionSite
+}
+
+func NewExceptionSites() *ExceptionSites {
+	return &ExceptionSites{}
+}
+
+func (s *ExceptionSites) Len() int {
+	return len(s.Sites)
+}
+
+func (s *ExceptionSites) Add(site *ExceptionSite) {
+	s.m.Lock()
+	s.Sites = append(s.Sites, site)
+	s.m.Unlock()
+}
+
+// Exceptions returns the unique exception classes of the sites sorted by name.
+func (s *ExceptionSites) Exceptions() []*Class {
+	visited := make(map[*Class]struct{}, len(s.Sites))
+	classes := make([]*Class, 0, len(s.Sites))
+
+	for _, site := range s.Sites {
+		if _, ok := visited[site.Exception]; ok {
+			continue
+		}
+		visited[site.Exception] = struct{}{}
+
+		classes = append(classes, site.Exception)
+	}
+
+	sort.Slice(classes, func(i, j int) bool {
+		return classes[i].Name < classes[j].Name
+	})
+
+	return classes
+}
+
+// GuardedCall is a function call inside try blocks.
+type GuardedCall struct {
+	Called *Function
+
+	// Guards are the exceptions caught by the enclosing try blocks.
+	Guards []*Class
+}
@@ -83,7 +83,7 @@ func (c *Fields) AddMethodAccess(key FieldKey, class *Class, method *Function) {
 	}
 
 	if method.Class != nil {
-		method.Class.AddDeps(field.Class)
+		method.Class.AddDependency(field.Class, DepProperty)
 	}
 	method.UsedFields.Add(field)
 
@@ -34,6 +34,14 @@ func (f *Files) CountLines() int64 {
 	return count
 }
 
+func (f *Files) CountDuplicatedLines() int64 {
+	var count int64
+	for _, file := range f.Files {
+		count += file.CountDuplicatedLines()
+	}
+	return count
+}
+
 func (f *Files) GetFileByPartOfName(name string) (*File, error) {
 	classes, err := f.GetFullFileName(name)
 	if err != nil {
@@ -81,6 +89,8 @@ func (f *Files) Get(path string) (*File, bool) {
 }
 
 type File struct {
+	m sync.Mutex
+
 	Name string
 	Path string
 
@@ -91,7 +101,15 @@ type File struct {
 	Classes *Classes
 	Funcs   *Functions
 
+	// Called stores the functions called in the top-level code of the file,
+	// Instantiates stores the classes created there.
+	Called       *Functions
+	Instantiates *Classes
+
 	CountLines int64
+
+	Tokens          []Token
+	duplicatedLines map[int32]struct{}
 }
 
 func NewFile(path string) *File {
@@ -103,6 +121,8 @@ func NewFile(path string) *File {
 		RequiredBy:    NewFiles(),
 		Classes:       NewClasses(),
 		Funcs:         NewFunctions(),
+		Called:        NewFunctions(),
+		Instantiates:  NewClasses(),
 	}
 }
 
@@ -133,6 +153,46 @@ func (f *File) AddFunc(fun *Function) {
 	f.Funcs.Add(fun)
 }
 
+// MarkDuplicatedLines marks lines from start to end inclusive as duplicated.
+func (f *File) MarkDuplicatedLines(start, end int32) {
+	f.m.Lock()
+	if f.duplicatedLines == nil {
+		f.duplicatedLines = map[int32]struct{}{}
+	}
+	for line := start; line <= end; line++ {
+		f.duplicatedLines[line] = struct{}{}
+	}
+	f.m.Unlock()
+}
+
+// ResetDuplicatedLines removes all marks set by MarkDuplicatedLines.
+func (f *File) ResetDuplicatedLines() {
+	f.m.Lock()
+	f.duplicatedLines = nil
+	f.m.Unlock()
+}
+
+func (f *File) CountDuplicatedLines() int64 {
+	f.m.Lock()
+	defer f.m.Unlock()
+	return int64(len(f.duplicatedLines))
+}
+
+// CountDuplicatedLinesInRange returns the number of duplicated lines
+// from start to end inclusive.
+func (f *File) CountDuplicatedLinesInRange(start, end int32) int64 {
+	f.m.Lock()
+	defer f.m.Unlock()
+
+	var count int64
+	for line := start; line <= end; line++ {
+		if _, ok := f.duplicatedLines[line]; ok {
+			count++
+		}
+	}
+	return count
+}
+
 // GobEncode is a custom gob marshaller
 func (f *File) GobEncode() ([]byte, error) {
 	w := new(bytes.Buffer)
@@ -97,7 +97,7 @@ func (f *Functions) maxMinAvgCyclomaticComplexity(onlyMethods, onlyFunctions boo
 			continue
 		}
 
-		if onlyFunctions && (fn.IsMethod() || fn.IsEmbeddedFunc()) {
+		if onlyFunctions && (fn.IsMethod() || fn.IsEmbeddedFunc() || fn.IsAnonymousFunction()) {
 			continue
 		}
 
@@ -147,7 +147,7 @@ func (f *Functions) maxMinAvgCountMagicNumbers(onlyMethods, onlyFunctions bool)
 			continue
 		}
 
-		if onlyFunctions && (fn.IsMethod() || fn.IsEmbeddedFunc()) {
+		if onlyFunctions && (fn.IsMethod() || fn.IsEmbeddedFunc() || fn.IsAnonymousFunction()) {
 			continue
 		}
 
@@ -177,6 +177,55 @@ func (f *Functions) maxMinAvgCountMagicNumbers(onlyMethods, onlyFunctions bool)
 	return max, min, avg
 }
 
+// WithoutTests returns a new collection that contains all functions except test methods.
+func (f *Functions) WithoutTests() *Functions {
+	funcs := NewFunctions()
+
+	for key, fn := range f.Funcs {
+		if fn.IsTest() {
+			continue
+		}
+
+		funcs.Funcs[key] = fn
+	}
+
+	return funcs
+}
+
+func (f *Functions) CountTestMethods() int64 {
+	var count int64
+	for _, fn := range f.Funcs {
+		if fn.IsTestMethod() {
+			count++
+		}
+	}
+	return count
+}
+
+// CountTestableMethods returns the number of project methods
+// that can be used by tests.
+func (f *Functions) CountTestableMethods() int64 {
+	var count int64
+	for _, fn := range f.Funcs {
+		if fn.IsTestable() && fn.IsMethod() {
+			count++
+		}
+	}
+	return count
+}
+
+// CountUntestedMethods returns the number of project methods
+// that are not called by any test.
+func (f *Functions) CountUntestedMethods() int64 {
+	var count int64
+	for _, fn := range f.Funcs {
+		if fn.IsTestable() && fn.IsMethod() && fn.CountTests() == 0 {
+			count++
+		}
+	}
+	return count
+}
+
 func (f *Functions) CountFunctions(withEmbedded bool) int64 {
 	var count int64
 	for _, fn := range f.Funcs {
@@ -188,13 +237,34 @@ func (f *Functions) CountFunctions(withEmbedded bool) int64 {
 			continue
 		}
 
-		if !fn.IsMethod() {
+		if !fn.IsMethod() && !fn.IsAnonymousFunction() {
+			count++
+		}
+	}
+	return count
+}
+
+// CountAnonymousFunctions returns the number of closures and arrow functions
+// excluding the embedded ones.
+func (f *Functions) CountAnonymousFunctions() int64 {
+	var count int64
+	for _, fn := range f.Funcs {
+		if fn.IsAnonymousFunction() && !fn.IsEmbeddedFunc() {
 			count++
 		}
 	}
 	return count
 }
 
+// CountCallSites returns the number of resolved and unresolved calls in all functions.
+func (f *Functions) CountCallSites() (resolved, unresolved int64) {
+	for _, fn := range f.Funcs {
+		resolved += fn.CountResolvedCalls
+		unresolved += fn.Unresolved.CountCalls()
+	}
+	return resolved, unresolved
+}
+
 func (f *Functions) CountMethods() int64 {
 	var count int64
 	for _, fn := range f.Funcs {
@@ -268,6 +338,13 @@ func (f *Functions) Get(fn FuncKey) (*Function, bool) {
 	return fun, ok
 }
 
+// Kinds of anonymous functions.
+const (
+	AnonymousClosure       = "closure"
+	AnonymousArrowFunction = "arrow function"
+	AnonymousClassMethod   = "anonymous class method"
+)
+
 var FunctionCount int64
 
 type Function struct {
@@ -279,9 +356,22 @@ type Function struct {
 	Called   *Functions
 	CalledBy *Functions
 
+	// CallEdges stores the number of calls of each called function by kind.
+	CallEdges *DependencyEdges
+
+	// VirtualCalled stores the methods that can be called through the dynamic
+	// dispatch of the calls of the overridden or implemented methods,
+	// VirtualCallEdges stores the places of these calls.
+	VirtualCalled    *Functions
+	VirtualCalledBy  *Functions
+	VirtualCallEdges *DependencyEdges
+
 	UsedFields    *Fields
 	UsedConstants *Constants
 
+	// Instantiates stores the classes created in the function with new.
+	Instantiates *Classes
+
 	UsesCount int64
 
 	depsResolved bool
@@ -295,6 +385,73 @@ type Function struct {
 
 	FullyTyped bool
 
+	// HasCoverage is true if the coverage report was loaded for the function.
+	HasCoverage            bool
+	CountStatements        int64
+	CountCoveredStatements int64
+
+	// Throws stores the places where the function throws exceptions.
+	Throws *ExceptionSites
+	// Catches stores the places where the function catches exceptions.
+	Catches *ExceptionSites
+
+	// UnguardedCalls stores the functions that are called outside of any try block.
+	UnguardedCalls *Functions
+	// GuardedCalls stores the calls inside try blocks.
+	GuardedCalls []*GuardedCall
+
+	// ThrowsTransitive stores the exceptions that can be thrown
+	// out of the function, including those from called functions.
+	ThrowsTransitive *Classes
+
+	// TestedBy stores the test methods that call the function directly or transitively.
+	TestedBy *Functions
+
+	// AnonymousKind is the kind of the anonymous function (closure, arrow
+	// function or anonymous class method), it is empty for named functions.
+	AnonymousKind string
+	// OwnerName is the name of the function in which the anonymous function is declared.
+	OwnerName FuncKey
+	// Owner is the function in which the anonymous function is declared.
+	Owner *Function
+	// Anonymous stores the anonymous functions declared directly in the function.
+	Anonymous *Functions
+
+	// ReadsGlobals and WritesGlobals store the global variables, superglobals
+	// and static properties read and written by the function.
+	ReadsGlobals  *Globals
+	WritesGlobals *Globals
+
+	// CountResolvedCalls is the number of calls in the function whose target is known.
+	CountResolvedCalls int64
+	// Unresolved stores the calls whose target could not be determined.
+	Unresolved *UnresolvedCalls
+
+	// Constructs stores the language constructs with side effects
+	// used in the function, for example, echo or print.
+	Constructs map[string]struct{}
+
+	// DirectEffects stores the side effects performed by the function itself.
+	DirectEffects *Effects
+	// Effects stores the side effects that the function may perform,
+	// including those performed by the called functions.
+	Effects *Effects
+
+	// Deprecated is true if the function is marked with the @deprecated tag,
+	// DeprecationMessage is the text after the tag.
+	Deprecated         bool
+	DeprecationMessage string
+
+	// Signature describes the parameters and the return type of the method,
+	// it is nil for functions and for methods that were not walked.
+	Signature *Signature
+	// CallsParent is true if the method calls the method
+	// with the same name of the parent class, parent::method().
+	CallsParent bool
+
+	// DocTags stores the names of the PHPDoc tags of the function, for example, "@api".
+	DocTags []string
+
 	// Method part
 	Class *Class
 
@@ -308,15 +465,32 @@ func (f *Function) ID() int64 {
 func NewFunction(name FuncKey, pos meta.ElementPosition) *Function {
 	atomic.AddInt64(&FunctionCount, 1)
 	return &Function{
-		Name:          name,
-		Called:        NewFunctions(),
-		CalledBy:      NewFunctions(),
-		UsedFields:    NewFields(),
-		UsedConstants: NewConstants(),
-		deps:          NewClasses(),
-		depsBy:        NewClasses(),
-		Pos:           pos,
-		Id:            FunctionCount,
+		Name:             name,
+		Called:           NewFunctions(),
+		CalledBy:         NewFunctions(),
+		UsedFields:       NewFields(),
+		UsedConstants:    NewConstants(),
+		Instantiates:     NewClasses(),
+		deps:             NewClasses(),
+		depsBy:           NewClasses(),
+		TestedBy:         NewFunctions(),
+		Throws:           NewExceptionSites(),
+		Catches:          NewExceptionSites(),
+		UnguardedCalls:   NewFunctions(),
+		ThrowsTransitive: NewClasses(),
+		Anonymous:        NewFunctions(),
+		CallEdges:        NewDependencyEdges(),
+		VirtualCalled:    NewFunctions(),
+		VirtualCalledBy:  NewFunctions(),
+		VirtualCallEdges: NewDependencyEdges(),
+		ReadsGlobals:     NewGlobals(),
+		WritesGlobals:    NewGlobals(),
+		Unresolved:       NewUnresolvedCalls(),
+		Constructs:       map[string]struct{}{},
+		DirectEffects:    NewEffects(),
+		Effects:          NewEffects(),
+		Pos:              pos,
+		Id:               FunctionCount,
 	}
 }
 
@@ -338,10 +512,84 @@ func (f *Function) IsEmbeddedFunc() bool {
 	return IsEmbeddedFunc(f.Pos.Filename)
 }
 
+// IsTest checks if the function is a method of a test class.
+func (f *Function) IsTest() bool {
+	return f.Class != nil && f.Class.IsTest
+}
+
+// IsTestMethod checks if the function is a test case of a test class.
+func (f *Function) IsTestMethod() bool {
+	return f.IsTest() && strings.HasPrefix(strings.ToLower(f.Name.Name), "test")
+}
+
+// Coverage returns the percentage of the function statements executed by tests.
+func (f *Function) Coverage() float64 {
+	if f.CountStatements == 0 {
+		return 100
+	}
+	return float64(f.CountCoveredStatements) / float64(f.CountStatements) * 100
+}
+
+// Crap returns the CRAP score comp^2 * (1 - Coverage()/100)^3 + comp, where comp
+// is CyclomaticComplexity + 1, since CyclomaticComplexity counts only the decision points.
+func (f *Function) Crap() float64 {
+	comp := float64(f.CyclomaticComplexity + 1)
+	uncovered := 1 - f.Coverage()/100
+
+	return comp*comp*uncovered*uncovered*uncovered + comp
+}
+
+// IsTestable checks if the function is a project function that can be called by tests.
+func (f *Function) IsTestable() bool {
+	return !f.IsVendorFunction() && !f.IsEmbeddedFunc() && !f.IsTest()
+}
+
+func (f *Function) AddTestedBy(test *Function) {
+	f.TestedBy.Add(test)
+}
+
+// CountTests returns the number of test methods that call the function.
+func (f *Function) CountTests() int64 {
+	return int64(f.TestedBy.Len())
+}
+
+// IsDirectlyTested checks if the function is called directly from any test class.
+func (f *Function) IsDirectlyTested() bool {
+	for _, fn := range f.CalledBy.Funcs {
+		if fn.IsTest() {
+			return true
+		}
+	}
+	return false
+}
+
 func (f *Function) IsMethod() bool {
 	return f.Name.IsMethod()
 }
 
+// IsAnonymous checks if the function is a closure, an arrow function or a method of an anonymous class.
+func (f *Function) IsAnonymous() bool {
+	return f.AnonymousKind != ""
+}
+
+// IsAnonymousFunction checks if the function is a closure or an arrow function.
+func (f *Function) IsAnonymousFunction() bool {
+	return f.IsAnonymous() && !f.IsMethod()
+}
+
+// AddAnonymous adds the anonymous function declared in the function.
+//
+// The declaration is considered as a call, so the anonymous
+// function is reachable from the function in the call graph.
+func (f *Function) AddAnonymous(fn *Function) {
+	fn.Owner = f
+	f.Anonymous.Add(fn)
+
+	f.Called.Add(fn)
+	fn.AddUse()
+	fn.CalledBy.Add(f)
+}
+
 func (f Function) Equal(fi2 Function) bool {
 	return f.Name.Equal(fi2.Name)
 }
@@ -406,6 +654,64 @@ func (f *Function) AddCalled(fn *Function) {
 	f.Class.AddDeps(fn.Class)
 }
 
+// AddCallEdge counts the call of the passed function of the passed kind made
+// in the passed place, the call is also counted as a dependency between
+// the classes of the functions.
+func (f *Function) AddCallEdge(fn *Function, kind DepKind, site Location) {
+	f.CallEdges.AddAt(fn.Name.String(), kind, site)
+	atomic.AddInt64(&f.CountResolvedCalls, 1)
+
+	if f.Class == nil || fn.Class == nil {
+		return
+	}
+
+	f.Class.AddDependency(fn.Class, kind)
+}
+
+// HasDocTag checks if the PHPDoc of the function contains the passed tag.
+func (f *Function) HasDocTag(tag string) bool {
+	for _, t := range f.DocTags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// AddConstruct records the use of the language construct in the function.
+func (f *Function) AddConstruct(name string) {
+	f.Constructs[name] = struct{}{}
+}
+
+// IsPure checks if the function does not perform any known side effects.
+func (f *Function) IsPure() bool {
+	return f.Effects.Len() == 0
+}
+
+// CallSites returns the places where the function calls the passed one,
+// for the virtual calls these are the places of the calls of the parent method.
+func (f *Function) CallSites(fn *Function) []Location {
+	dep, ok := f.CallEdges.Get(fn.Name.String())
+	if !ok {
+		dep, ok = f.VirtualCallEdges.Get(fn.Name.String())
+	}
+	if !ok {
+		return nil
+	}
+	return dep.SortedSites()
+}
+
+// AddVirtualCall links the function with the method that can be called
+// instead of the called parent method in the passed places.
+func (f *Function) AddVirtualCall(fn *Function, sites []Location) {
+	f.VirtualCalled.Add(fn)
+	fn.VirtualCalledBy.Add(f)
+
+	for _, site := range sites {
+		f.VirtualCallEdges.AddAt(fn.Name.String(), DepCall, site)
+	}
+}
+
 func (f *Function) AddCalledBy(fn *Function) {
 	if _, found := f.CalledBy.Get(fn.Name); !found {
 		f.AddUse()
@@ -419,6 +725,29 @@ func (f *Function) AddCalledBy(fn *Function) {
 	f.Class.AddDepsBy(fn.Class)
 }
 
+func (f *Function) AddThrow(site *ExceptionSite) {
+	f.Throws.Add(site)
+	site.Exception.ThrownIn.Add(site)
+}
+
+func (f *Function) AddCatch(site *ExceptionSite) {
+	f.Catches.Add(site)
+	site.Exception.CaughtIn.Add(site)
+}
+
+// AddCallGuards stores the exceptions caught by the try blocks around the call.
+func (f *Function) AddCallGuards(fn *Function, guards []*Class) {
+	if len(guards) == 0 {
+		f.UnguardedCalls.Add(fn)
+		return
+	}
+
+	f.GuardedCalls = append(f.GuardedCalls, &GuardedCall{
+		Called: fn,
+		Guards: guards,
+	})
+}
+
 func (f *Function) AddUse() {
 	atomic.AddInt64(&f.UsesCount, 1)
 }
@@ -447,6 +776,22 @@ func (f *Function) GobDecode(buf []byte) error {
 	if err != nil {
 		return err
 	}
+	err = decoder.Decode(&f.AnonymousKind)
+	if err != nil {
+		return err
+	}
+	err = decoder.Decode(&f.OwnerName)
+	if err != nil {
+		return err
+	}
+	err = decoder.Decode(&f.Deprecated)
+	if err != nil {
+		return err
+	}
+	err = decoder.Decode(&f.DeprecationMessage)
+	if err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -474,5 +819,21 @@ func (f *Function) GobEncode() ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
+	err = encoder.Encode(f.AnonymousKind)
+	if err != nil {
+		return nil, err
+	}
+	err = encoder.Encode(f.OwnerName)
+	if err != nil {
+		return nil, err
+	}
+	err = encoder.Encode(f.Deprecated)
+	if err != nil {
+		return nil, err
+	}
+	err = encoder.Encode(f.DeprecationMessage)
+	if err != nil {
+		return nil, err
+	}
 	return w.Bytes(), nil
 }
@@ -0,0 +1,39 @@
+package symbols
+
+import (
+	"testing"
+
+	"github.com/VKCOM/noverify/src/meta"
+)
+
+func TestFunctionCrap(t *testing.T) {
+	tests := []struct {
+		name       string
+		complexity int64
+		statements int64
+		covered    int64
+		coverage   float64
+		crap       float64
+	}{
+		// 3^2 * 1^3 + 3.
+		{name: "uncovered", complexity: 2, statements: 4, covered: 0, coverage: 0, crap: 12},
+		// 3^2 * 0.5^3 + 3.
+		{name: "half covered", complexity: 2, statements: 4, covered: 2, coverage: 50, crap: 4.125},
+		{name: "covered", complexity: 2, statements: 4, covered: 4, coverage: 100, crap: 3},
+		{name: "no statements", complexity: 0, statements: 0, covered: 0, coverage: 100, crap: 1},
+	}
+
+	for _, tt := range tests {
+		fn := NewFunction(NewFuncKey(`\`+tt.name), meta.ElementPosition{})
+		fn.CyclomaticComplexity = tt.complexity
+		fn.CountStatements = tt.statements
+		fn.CountCoveredStatements = tt.covered
+
+		if got := fn.Coverage(); got != tt.coverage {
+			t.Errorf("%s: got coverage %f, want %f", tt.name, got, tt.coverage)
+		}
+		if got := fn.Crap(); got != tt.crap {
+			t.Errorf("%s: got CRAP %f, want %f", tt.name, got, tt.crap)
+		}
+	}
+}
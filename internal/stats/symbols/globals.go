@@ -0,0 +1,151 @@
+package symbols
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// GlobalKind is the kind of a piece of global state.
+type GlobalKind uint8
+
+const (
+	// GlobalVariable is a variable imported with the global statement
+	// or accessed through $GLOBALS.
+	GlobalVariable GlobalKind = iota
+	// SuperGlobal is one of $_GET, $_POST, $_SESSION and others.
+	SuperGlobal
+	// StaticProperty is a static property of a class.
+	StaticProperty
+)
+
+func (k GlobalKind) String() string {
+	switch k {
+	case GlobalVariable:
+		return "global"
+	case SuperGlobal:
+		return "superglobal"
+	case StaticProperty:
+		return "static property"
+	}
+	return "unknown"
+}
+
+var GlobalsCount int64
+
+// Global is a piece of state shared between functions, the name
+// of a variable is "$name", the name of a static property is "\Class::$name".
+type Global struct {
+	Name string
+	Kind GlobalKind
+
+	Readers *Functions
+	Writers *Functions
+
+	Id int64
+}
+
+func NewGlobal(name string, kind GlobalKind) *Global {
+	atomic.AddInt64(&GlobalsCount, 1)
+	return &Global{
+		Name:    name,
+		Kind:    kind,
+		Readers: NewFunctions(),
+		Writers: NewFunctions(),
+		Id:      GlobalsCount,
+	}
+}
+
+func (g *Global) ID() int64 {
+	return g.Id
+}
+
+func (g *Global) String() string {
+	return g.Name
+}
+
+// Users returns the functions that read or write the global.
+func (g *Global) Users() *Functions {
+	users := NewFunctions()
+	for key, fn := range g.Readers.Funcs {
+		users.Funcs[key] = fn
+	}
+	for key, fn := range g.Writers.Funcs {
+		users.Funcs[key] = fn
+	}
+	return users
+}
+
+type Globals struct {
+	m sync.Mutex
+
+	Globals map[string]*Global
+}
+
+func NewGlobals() *Globals {
+	return &Globals{
+		Globals: map[string]*Global{},
+	}
+}
+
+func (g *Globals) Len() int {
+	return len(g.Globals)
+}
+
+func (g *Globals) Add(global *Global) {
+	g.m.Lock()
+	g.Globals[global.Name] = global
+	g.m.Unlock()
+}
+
+func (g *Globals) Get(name string) (*Global, bool) {
+	g.m.Lock()
+	global, ok := g.Globals[name]
+	g.m.Unlock()
+	return global, ok
+}
+
+// GetOrCreate returns the global with the passed name creating it if needed.
+func (g *Globals) GetOrCreate(name string, kind GlobalKind) *Global {
+	g.m.Lock()
+	defer g.m.Unlock()
+
+	global, ok := g.Globals[name]
+	if !ok {
+		global = NewGlobal(name, kind)
+		g.Globals[name] = global
+	}
+
+	return global
+}
+
+// AddAccess records that the function reads or writes the global.
+func (g *Globals) AddAccess(name string, kind GlobalKind, fn *Function, write bool) {
+	global := g.GetOrCreate(name, kind)
+
+	if write {
+		global.Writers.Add(fn)
+		fn.WritesGlobals.Add(global)
+		return
+	}
+
+	global.Readers.Add(fn)
+	fn.ReadsGlobals.Add(global)
+}
+
+// Sorted returns the globals sorted by name.
+func (g *Globals) Sorted() []*Global {
+	g.m.Lock()
+	defer g.m.Unlock()
+
+	globals := make([]*Global, 0, len(g.Globals))
+	for _, global := range g.Globals {
+		globals = append(globals, global)
+	}
+
+	sort.Slice(globals, func(i, j int) bool {
+		return globals[i].Name < globals[j].Name
+	})
+
+	return globals
+}
@@ -0,0 +1,77 @@
+package symbols
+
+import (
+	"strings"
+)
+
+// Param is a parameter of a function.
+type Param struct {
+	Name string
+	// Type is the type hint of the parameter with the full class
+	// names, it is empty if the parameter has no type hint.
+	Type     string
+	Optional bool
+	Variadic bool
+}
+
+func (p Param) String() string {
+	var res string
+	if p.Type != "" {
+		res += p.Type + " "
+	}
+	if p.Variadic {
+		res += "..."
+	}
+	res += "$" + p.Name
+	if p.Optional {
+		res += " = ..."
+	}
+	return res
+}
+
+// Signature describes the parameters, the return type
+// and the modifiers of a method.
+type Signature struct {
+	Params []Param
+	// ReturnType is the return type hint with the full class
+	// names, it is empty if the method has no return type hint.
+	ReturnType string
+
+	Visibility string
+	IsAbstract bool
+	IsStatic   bool
+
+	// IsTrivial is true if the body of the method
+	// is empty or only throws an exception.
+	IsTrivial bool
+}
+
+// CountRequiredParams returns the number of parameters without default values.
+func (s *Signature) CountRequiredParams() int {
+	var count int
+	for _, param := range s.Params {
+		if !param.Optional && !param.Variadic {
+			count++
+		}
+	}
+	return count
+}
+
+// HasVariadic checks if the last parameter is variadic.
+func (s *Signature) HasVariadic() bool {
+	return len(s.Params) != 0 && s.Params[len(s.Params)-1].Variadic
+}
+
+// String returns the signature in the PHP syntax, for example, "(int $a, $b = ...): string".
+func (s *Signature) String() string {
+	params := make([]string, 0, len(s.Params))
+	for _, param := range s.Params {
+		params = append(params, param.String())
+	}
+
+	res := "(" + strings.Join(params, ", ") + ")"
+	if s.ReturnType != "" {
+		res += ": " + s.ReturnType
+	}
+	return res
+}
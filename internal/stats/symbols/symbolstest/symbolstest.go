@@ -0,0 +1,73 @@
+// Package symbolstest provides utilities for building call graphs in tests.
+package symbolstest
+
+import (
+	"github.com/VKCOM/noverify/src/meta"
+
+	"github.com/i582/phpstats/internal/stats/symbols"
+)
+
+// Graph builds a call graph of functions and classes declared in one file.
+type Graph struct {
+	File    *symbols.File
+	Funcs   *symbols.Functions
+	Classes *symbols.Classes
+}
+
+// NewGraph returns an empty graph whose symbols are declared in the file at the path.
+func NewGraph(path string) *Graph {
+	return &Graph{
+		File:    symbols.NewFile(path),
+		Funcs:   symbols.NewFunctions(),
+		Classes: symbols.NewClasses(),
+	}
+}
+
+// Func returns the function with the passed full name, creating it if necessary.
+func (g *Graph) Func(name string) *symbols.Function {
+	key := symbols.NewFuncKey(name)
+	if fn, ok := g.Funcs.Get(key); ok {
+		return fn
+	}
+
+	fn := symbols.NewFunction(key, meta.ElementPosition{Filename: g.File.Path})
+	g.Funcs.Add(fn)
+	return fn
+}
+
+// Class returns the class with the passed full name, creating it if necessary.
+func (g *Graph) Class(name string) *symbols.Class {
+	if class, ok := g.Classes.Get(name); ok {
+		return class
+	}
+
+	class := symbols.NewClass(name, g.File)
+	g.Classes.Add(class)
+	return class
+}
+
+// Method returns the method of the class, creating it if necessary.
+func (g *Graph) Method(class *symbols.Class, name string) *symbols.Function {
+	key := symbols.NewMethodKey(name, class.Name)
+	if fn, ok := g.Funcs.Get(key); ok {
+		return fn
+	}
+
+	fn := symbols.NewMethod(key, meta.ElementPosition{Filename: g.File.Path}, class)
+	class.AddMethod(fn)
+	g.Funcs.Add(fn)
+	return fn
+}
+
+// Call adds the call edge from the caller to the callee.
+func (g *Graph) Call(caller, callee *symbols.Function) {
+	caller.AddCalled(callee)
+	callee.AddCalledBy(caller)
+}
+
+// CallAt adds the call edge from the caller to the callee
+// made at the passed line of the graph file.
+func (g *Graph) CallAt(caller, callee *symbols.Function, line int32) {
+	g.Call(caller, callee)
+	caller.AddCallEdge(callee, symbols.DepCall, symbols.Location{File: g.File.Path, Line: line})
+}
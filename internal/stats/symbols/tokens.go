@@ -0,0 +1,13 @@
+package symbols
+
+// Token is a normalized token of the source code that
+// is used to search for duplicated code.
+//
+// Kind is the same for tokens that differ only in the name
+// of the identifier or the value of the literal, while Value
+// is the hash of the original text of the token.
+type Token struct {
+	Kind  int32
+	Value uint32
+	Line  int32
+}
@@ -0,0 +1,133 @@
+package symbols
+
+import (
+	"strings"
+)
+
+// TraitAdaptation describes the `insteadof` or `as` rule of the trait use.
+type TraitAdaptation struct {
+	// Trait is the full name of the trait whose method is adapted,
+	// it is empty if the trait is not specified in the rule.
+	Trait  string
+	Method string
+
+	// InsteadOf contains the full names of the traits
+	// whose method with the same name is excluded.
+	InsteadOf []string
+
+	Alias    string
+	Modifier string
+}
+
+func (a *TraitAdaptation) String() string {
+	method := a.Method
+	if a.Trait != "" {
+		method = a.Trait + "::" + a.Method
+	}
+
+	if len(a.InsteadOf) != 0 {
+		return method + " insteadof " + strings.Join(a.InsteadOf, ", ")
+	}
+
+	res := method + " as"
+	if a.Modifier != "" {
+		res += " " + a.Modifier
+	}
+	if a.Alias != "" {
+		res += " " + a.Alias
+	}
+
+	return res
+}
+
+// AddUses adds the trait used by the class.
+func (c *Class) AddUses(trait *Class) {
+	if c == trait {
+		return
+	}
+
+	c.Uses.Add(trait)
+	trait.UsedBy.Add(c)
+}
+
+// IsExcludedTraitMethod checks if the trait method is replaced
+// with the method of another trait by the `insteadof` rule.
+func (c *Class) IsExcludedTraitMethod(trait *Class, method string) bool {
+	for _, adaptation := range c.TraitAdaptations {
+		if !strings.EqualFold(adaptation.Method, method) {
+			continue
+		}
+
+		for _, insteadOf := range adaptation.InsteadOf {
+			if insteadOf == trait.Name {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// MethodsWithTraits returns the methods of the class along with the
+// methods of all used traits, except the overridden and excluded ones.
+func (c *Class) MethodsWithTraits() *Functions {
+	return c.methodsWithTraits(map[*Class]struct{}{})
+}
+
+func (c *Class) methodsWithTraits(visited map[*Class]struct{}) *Functions {
+	visited[c] = struct{}{}
+
+	methods := NewFunctions()
+	names := make(map[string]struct{}, c.Methods.Len())
+
+	for key, method := range c.Methods.Funcs {
+		methods.Funcs[key] = method
+		names[strings.ToLower(key.Name)] = struct{}{}
+	}
+
+	for _, trait := range c.Uses.Classes {
+		if _, ok := visited[trait]; ok {
+			continue
+		}
+
+		for key, method := range trait.methodsWithTraits(visited).Funcs {
+			if _, ok := names[strings.ToLower(key.Name)]; ok {
+				continue
+			}
+			if c.IsExcludedTraitMethod(trait, key.Name) {
+				continue
+			}
+
+			methods.Funcs[key] = method
+		}
+	}
+
+	return methods
+}
+
+// FieldsWithTraits returns the fields of the class along with the fields of all used traits.
+func (c *Class) FieldsWithTraits() *Fields {
+	return c.fieldsWithTraits(map[*Class]struct{}{})
+}
+
+func (c *Class) fieldsWithTraits(visited map[*Class]struct{}) *Fields {
+	visited[c] = struct{}{}
+
+	fields := NewFields()
+
+	for key, field := range c.Fields.Fields {
+		fields.Fields[key] = field
+	}
+
+	for _, trait := range c.Uses.Classes {
+		if _, ok := visited[trait]; ok {
+			continue
+		}
+
+		for key, field := range trait.fieldsWithTraits(visited).Fields {
+			fields.Fields[key] = field
+		}
+	}
+
+	return fields
+}
@@ -0,0 +1,119 @@
+package symbols
+
+import (
+	"sort"
+	"sync"
+)
+
+// UnresolvedKind is the reason why the target of a call could not be determined.
+type UnresolvedKind uint8
+
+const (
+	// UnresolvedVariableFunction is a call of a variable or an expression, $fn().
+	UnresolvedVariableFunction UnresolvedKind = iota
+	// UnresolvedUnknownFunction is a call of a function that is not defined.
+	UnresolvedUnknownFunction
+	// UnresolvedDynamicMethod is a call with a variable method name, $obj->$method().
+	UnresolvedDynamicMethod
+	// UnresolvedDynamicClass is a static call on a variable class, $class::method().
+	UnresolvedDynamicClass
+	// UnresolvedUnknownClass is a static call on a class that is not defined.
+	UnresolvedUnknownClass
+	// UnresolvedUnknownReceiver is a method call on a receiver of unknown type.
+	UnresolvedUnknownReceiver
+	// UnresolvedUnknownMethod is a call of a method that is not found in the receiver classes.
+	UnresolvedUnknownMethod
+	// UnresolvedCallUserFunc is a call through call_user_func and call_user_func_array.
+	UnresolvedCallUserFunc
+	// UnresolvedDynamicNew is the creation of an object of a variable class, new $class.
+	UnresolvedDynamicNew
+
+	countUnresolvedKinds
+)
+
+var unresolvedKindNames = [countUnresolvedKinds]string{
+	UnresolvedVariableFunction: "variable function",
+	UnresolvedUnknownFunction:  "unknown function",
+	UnresolvedDynamicMethod:    "dynamic method",
+	UnresolvedDynamicClass:     "dynamic class",
+	UnresolvedUnknownClass:     "unknown class",
+	UnresolvedUnknownReceiver:  "unknown receiver",
+	UnresolvedUnknownMethod:    "unknown method",
+	UnresolvedCallUserFunc:     "call_user_func",
+	UnresolvedDynamicNew:       "dynamic new",
+}
+
+func (k UnresolvedKind) String() string {
+	if k >= countUnresolvedKinds {
+		return "unknown"
+	}
+	return unresolvedKindNames[k]
+}
+
+// IsCall checks if the kind describes a call, not the creation of an object.
+func (k UnresolvedKind) IsCall() bool {
+	return k != UnresolvedDynamicNew
+}
+
+// UnresolvedCall is a place in the function where the called
+// function or the created class could not be determined.
+type UnresolvedCall struct {
+	Kind UnresolvedKind
+	Func *Function
+	Site Location
+
+	// Expr is the source code of the called expression.
+	Expr string
+}
+
+type UnresolvedCalls struct {
+	m sync.Mutex
+
+	Calls []*UnresolvedCall
+}
+
+func NewUnresolvedCalls() *UnresolvedCalls {
+	return &UnresolvedCalls{}
+}
+
+func (c *UnresolvedCalls) Len() int {
+	return len(c.Calls)
+}
+
+func (c *UnresolvedCalls) Add(call *UnresolvedCall) {
+	c.m.Lock()
+	c.Calls = append(c.Calls, call)
+	c.m.Unlock()
+}
+
+// CountCalls returns the number of unresolved calls without the object creations.
+func (c *UnresolvedCalls) CountCalls() int64 {
+	var count int64
+	for _, call := range c.Calls {
+		if call.Kind.IsCall() {
+			count++
+		}
+	}
+	return count
+}
+
+// Sorted returns the unresolved calls of the passed kind sorted by file
+// and line, if the kind is empty, all calls are returned.
+func (c *UnresolvedCalls) Sorted(kind string) []*UnresolvedCall {
+	calls := make([]*UnresolvedCall, 0, len(c.Calls))
+	for _, call := range c.Calls {
+		if kind != "" && call.Kind.String() != kind {
+			continue
+		}
+		calls = append(calls, call)
+	}
+
+	sort.Slice(calls, func(i, j int) bool {
+		if calls[i].Site.File != calls[j].Site.File {
+			return calls[i].Site.File < calls[j].Site.File
+		}
+		return calls[i].Site.Line < calls[j].Site.Line
+	})
+
+	return calls
+}
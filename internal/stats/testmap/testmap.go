@@ -0,0 +1,198 @@
+package testmap
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/i582/phpstats/internal/stats/symbols"
+)
+
+// DefaultBaseClasses contains the base classes of PHPUnit tests.
+var DefaultBaseClasses = []string{
+	`\PHPUnit\Framework\TestCase`,
+	`\PHPUnit_Framework_TestCase`,
+}
+
+// lifecycleMethods are called by PHPUnit before each test,
+// so the code used in them is also considered tested.
+var lifecycleMethods = []string{
+	"setUp",
+	"setUpBeforeClass",
+}
+
+type Options struct {
+	// BaseClasses are the full names of the classes from which the tests are inherited.
+	BaseClasses []string
+	// Dirs are the directories, absolute or relative to ProjectRoot,
+	// all classes from which are considered tests.
+	Dirs        []string
+	ProjectRoot string
+}
+
+// Detect marks test classes and for each production class and function
+// collects the test methods that use them directly or transitively.
+func Detect(classes *symbols.Classes, funcs *symbols.Functions, opt Options) {
+	bases := make(map[string]struct{}, len(opt.BaseClasses))
+	for _, base := range opt.BaseClasses {
+		if !strings.HasPrefix(base, `\`) {
+			base = `\` + base
+		}
+		bases[base] = struct{}{}
+	}
+
+	dirs := testDirs(opt.Dirs, opt.ProjectRoot)
+
+	for _, class := range classes.Classes {
+		class.IsTest = false
+		class.TestedBy = symbols.NewFunctions()
+	}
+	for _, fn := range funcs.Funcs {
+		fn.TestedBy = symbols.NewFunctions()
+	}
+
+	for _, class := range classes.Classes {
+		if class.IsVendor || class.IsInterface {
+			continue
+		}
+
+		_, isBase := bases[class.Name]
+		class.IsTest = isBase || extendsBase(class, bases) || inDirs(class.File, dirs)
+	}
+
+	for _, class := range classes.Classes {
+		if !class.IsTest {
+			continue
+		}
+
+		var setUp []*symbols.Function
+		for _, name := range lifecycleMethods {
+			method, ok := class.Methods.Get(symbols.NewMethodKey(name, class.Name))
+			if ok {
+				setUp = append(setUp, method)
+			}
+		}
+
+		for _, method := range class.Methods.Funcs {
+			if !method.IsTestMethod() {
+				continue
+			}
+
+			for _, dep := range class.Deps.Classes {
+				if dep.IsTest || dep.IsVendor {
+					continue
+				}
+				dep.AddTestedBy(method)
+			}
+
+			markReachable(method, append(setUp, method))
+		}
+	}
+}
+
+// markReachable walks the call graph from the passed functions
+// and marks all reached production code as used by the test.
+func markReachable(test *symbols.Function, from []*symbols.Function) {
+	visited := make(map[*symbols.Function]struct{})
+	queue := make([]*symbols.Function, 0, len(from))
+
+	for _, fn := range from {
+		visited[fn] = struct{}{}
+		queue = append(queue, fn)
+	}
+
+	for len(queue) != 0 {
+		fn := queue[0]
+		queue = queue[1:]
+
+		for _, called := range fn.Called.Funcs {
+			if _, ok := visited[called]; ok {
+				continue
+			}
+			visited[called] = struct{}{}
+
+			if called.IsVendorFunction() || called.IsEmbeddedFunc() {
+				continue
+			}
+
+			if !called.IsTest() {
+				called.AddTestedBy(test)
+				if called.Class != nil {
+					called.Class.AddTestedBy(test)
+				}
+			}
+
+			queue = append(queue, called)
+		}
+	}
+}
+
+func extendsBase(class *symbols.Class, bases map[string]struct{}) bool {
+	visited := make(map[*symbols.Class]struct{})
+
+	for cur := class; cur != nil; cur = parent(cur) {
+		if _, ok := visited[cur]; ok {
+			return false
+		}
+		visited[cur] = struct{}{}
+
+		if _, ok := bases[cur.ParentName]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+func parent(class *symbols.Class) *symbols.Class {
+	for _, extend := range class.Extends.Classes {
+		return extend
+	}
+	return nil
+}
+
+// testDirs returns the absolute paths of the passed directories,
+// relative directories are resolved against the project root.
+func testDirs(dirs []string, root string) []string {
+	root, err := filepath.Abs(root)
+	if err != nil {
+		return nil
+	}
+
+	res := make([]string, 0, len(dirs))
+	for _, dir := range dirs {
+		dir = filepath.Clean(filepath.FromSlash(dir))
+		if dir == "." {
+			continue
+		}
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(root, dir)
+		}
+		res = append(res, dir)
+	}
+
+	return res
+}
+
+// inDirs checks if the file is located in one of the passed absolute directories.
+func inDirs(file *symbols.File, dirs []string) bool {
+	if file == nil || len(dirs) == 0 {
+		return false
+	}
+
+	path, err := filepath.Abs(file.Path)
+	if err != nil {
+		return false
+	}
+
+	for _, dir := range dirs {
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			continue
+		}
+		if rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,110 @@
+package testmap
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/i582/phpstats/internal/stats/symbols"
+	"github.com/i582/phpstats/internal/stats/symbols/symbolstest"
+)
+
+func TestInDirs(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	root := filepath.Join(wd, "project")
+
+	tests := []struct {
+		root string
+		dirs []string
+		path string
+		want bool
+	}{
+		{root: root, dirs: []string{"tests"}, path: filepath.Join(root, "tests", "UserTest.php"), want: true},
+		{root: root, dirs: []string{"./tests/"}, path: filepath.Join(root, "tests", "Unit", "UserTest.php"), want: true},
+		{root: root, dirs: []string{"tests"}, path: filepath.Join(root, "testsuite", "User.php"), want: false},
+		{root: root, dirs: []string{"tests"}, path: filepath.Join(root, "src", "User.php"), want: false},
+		{root: root, dirs: []string{filepath.Join(root, "tests")}, path: filepath.Join(root, "tests", "UserTest.php"), want: true},
+		{root: "", dirs: []string{"project/tests"}, path: filepath.Join(root, "tests", "UserTest.php"), want: true},
+		{root: ".", dirs: []string{"project/tests"}, path: filepath.Join("project", "tests", "UserTest.php"), want: true},
+		{root: "project", dirs: []string{"tests"}, path: filepath.Join(root, "tests", "UserTest.php"), want: true},
+		{root: root, dirs: []string{"."}, path: filepath.Join(root, "tests", "UserTest.php"), want: false},
+	}
+
+	for _, tt := range tests {
+		got := inDirs(symbols.NewFile(tt.path), testDirs(tt.dirs, tt.root))
+		if got != tt.want {
+			t.Errorf("inDirs(%q, %v, root %q): got %t, want %t", tt.path, tt.dirs, tt.root, got, tt.want)
+		}
+	}
+}
+
+func TestDetect(t *testing.T) {
+	g := symbolstest.NewGraph("/project/src/User.php")
+
+	testCase := g.Class(`\PHPUnit\Framework\TestCase`)
+	baseTest := g.Class(`\App\BaseTest`)
+	baseTest.ParentName = testCase.Name
+	baseTest.AddExtends(testCase)
+
+	userTest := g.Class(`\App\UserTest`)
+	userTest.ParentName = baseTest.Name
+	userTest.AddExtends(baseTest)
+
+	fixture := symbols.NewClass(`\App\Fixture`, symbols.NewFile("/project/tests/Fixture.php"))
+	g.Classes.Add(fixture)
+
+	user := g.Class(`\App\User`)
+	db := g.Class(`\App\Db`)
+	mailer := g.Class(`\App\Mailer`)
+
+	testCreate := g.Method(userTest, "testCreate")
+	setUp := g.Method(userTest, "setUp")
+	helper := g.Method(userTest, "helper")
+
+	create := g.Method(user, "create")
+	save := g.Method(user, "save")
+	connect := g.Method(db, "connect")
+	send := g.Method(mailer, "send")
+
+	g.Call(testCreate, create)
+	g.Call(create, save)
+	g.Call(setUp, connect)
+	g.Call(helper, send)
+
+	Detect(g.Classes, g.Funcs, Options{
+		BaseClasses: DefaultBaseClasses,
+		Dirs:        []string{"tests"},
+		ProjectRoot: "/project",
+	})
+
+	for _, class := range []*symbols.Class{testCase, baseTest, userTest, fixture} {
+		if !class.IsTest {
+			t.Errorf("%s must be a test class", class.Name)
+		}
+	}
+	for _, class := range []*symbols.Class{user, db, mailer} {
+		if class.IsTest {
+			t.Errorf("%s must not be a test class", class.Name)
+		}
+	}
+
+	for _, fn := range []*symbols.Function{create, save, connect} {
+		if _, ok := fn.TestedBy.Get(testCreate.Name); !ok || fn.TestedBy.Len() != 1 {
+			t.Errorf("%s must be tested only by %s", fn.Name, testCreate.Name)
+		}
+	}
+	if send.TestedBy.Len() != 0 {
+		t.Errorf("%s is used only by a helper and must not be tested", send.Name)
+	}
+
+	// The classes the test class depends on are considered
+	// tested by each of its test methods.
+	for _, class := range []*symbols.Class{user, db, mailer} {
+		if _, ok := class.TestedBy.Get(testCreate.Name); !ok {
+			t.Errorf("%s must be tested by %s", class.Name, testCreate.Name)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package walkers
+
+import (
+	"fmt"
+
+	"github.com/VKCOM/noverify/src/ir"
+	"github.com/VKCOM/noverify/src/ir/irutil"
+
+	"github.com/i582/phpstats/internal/stats/symbols"
+)
+
+// anonymousSymbol is a closure, an arrow function or a method
+// of an anonymous class declared in the body of a function.
+type anonymousSymbol struct {
+	Key   symbols.FuncKey
+	Kind  string
+	Owner symbols.FuncKey
+
+	// Node is the declaration of the symbol, its position
+	// bounds the nodes that belong to the symbol.
+	Node ir.Node
+	Body *ir.StmtList
+
+	Params     []ir.Node
+	ReturnType ir.Node
+
+	// Class is the anonymous class for methods.
+	Class *anonymousClass
+}
+
+type anonymousClass struct {
+	Name  string
+	Owner symbols.FuncKey
+}
+
+type anonymousCollector struct {
+	symbols []*anonymousSymbol
+	classes []*anonymousClass
+	names   map[string]int
+}
+
+// collectAnonymous returns all anonymous functions, anonymous classes and their methods
+// declared in the body of the passed function, including nested ones.
+//
+// The names of the symbols are built from the name of the owner function
+// and the line of the declaration, so that the indexer and the checker
+// give the same names to the same symbols.
+func collectAnonymous(owner symbols.FuncKey, body *ir.StmtList) ([]*anonymousSymbol, []*anonymousClass) {
+	c := &anonymousCollector{
+		names: map[string]int{},
+	}
+	c.collect(owner, body)
+	return c.symbols, c.classes
+}
+
+func (c *anonymousCollector) collect(owner symbols.FuncKey, body *ir.StmtList) {
+	if body == nil {
+		return
+	}
+
+	irutil.Inspect(body, func(n ir.Node) bool {
+		switch n := n.(type) {
+		case *ir.FunctionStmt, *ir.ClassStmt, *ir.InterfaceStmt, *ir.TraitStmt:
+			return false
+
+		case *ir.ClosureExpr:
+			sym := &anonymousSymbol{
+				Key:        symbols.NewFuncKey(c.name(owner, "closure", n)),
+				Kind:       symbols.AnonymousClosure,
+				Owner:      owner,
+				Node:       n,
+				Body:       &ir.StmtList{Stmts: n.Stmts},
+				Params:     n.Params,
+				ReturnType: n.ReturnType,
+			}
+			c.symbols = append(c.symbols, sym)
+			c.collect(sym.Key, sym.Body)
+			return false
+
+		case *ir.ArrowFunctionExpr:
+			sym := &anonymousSymbol{
+				Key:        symbols.NewFuncKey(c.name(owner, "fn", n)),
+				Kind:       symbols.AnonymousArrowFunction,
+				Owner:      owner,
+				Node:       n,
+				Body:       &ir.StmtList{Stmts: []ir.Node{&ir.ExpressionStmt{Expr: n.Expr}}},
+				Params:     n.Params,
+				ReturnType: n.ReturnType,
+			}
+			c.symbols = append(c.symbols, sym)
+			c.collect(sym.Key, sym.Body)
+			return false
+
+		case *ir.AnonClassExpr:
+			class := &anonymousClass{
+				Name:  c.name(owner, "class", n),
+				Owner: owner,
+			}
+			c.classes = append(c.classes, class)
+
+			for _, stmt := range n.Stmts {
+				method, ok := stmt.(*ir.ClassMethodStmt)
+				if !ok {
+					continue
+				}
+
+				methodBody, _ := method.Stmt.(*ir.StmtList)
+
+				sym := &anonymousSymbol{
+					Key:        symbols.NewMethodKey(method.MethodName.Value, class.Name),
+					Kind:       symbols.AnonymousClassMethod,
+					Owner:      owner,
+					Node:       method,
+					Body:       methodBody,
+					Params:     method.Params,
+					ReturnType: method.ReturnType,
+					Class:      class,
+				}
+				c.symbols = append(c.symbols, sym)
+				c.collect(sym.Key, sym.Body)
+			}
+
+			for _, arg := range n.Args {
+				c.collect(owner, &ir.StmtList{Stmts: []ir.Node{arg}})
+			}
+			return false
+		}
+
+		return true
+	})
+}
+
+// name returns a unique name for the anonymous symbol, for example,
+// \Foo::bar{closure:10} or \Foo::bar{closure:10#2} for the second
+// closure on the same line.
+func (c *anonymousCollector) name(owner symbols.FuncKey, kind string, n ir.Node) string {
+	var line int
+	if pos := ir.GetPosition(n); pos != nil {
+		line = pos.StartLine
+	}
+
+	name := fmt.Sprintf("%s{%s:%d", owner.String(), kind, line)
+
+	c.names[name]++
+	if count := c.names[name]; count > 1 {
+		name += fmt.Sprintf("#%d", count)
+	}
+
+	return name + "}"
+}
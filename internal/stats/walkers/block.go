@@ -27,6 +27,10 @@ func (b *blockChecker) LeaveNode(ir.Node) {}
 
 // AfterEnterNode describes the processing logic after entering the node.
 func (b *blockChecker) AfterEnterNode(n ir.Node) {
+	if !b.Root.firstVisit(n) {
+		return
+	}
+
 	switch n := n.(type) {
 	case *ir.Argument:
 		n.Expr.Walk(b)
@@ -36,6 +40,7 @@ func (b *blockChecker) AfterEnterNode(n ir.Node) {
 		b.handleMethodCall(n)
 	case *ir.StaticCallExpr:
 		b.handleStaticMethodCall(n)
+		b.handleParentCall(n)
 	case *ir.ImportExpr:
 		b.handleImport(n)
 	case *ir.NewExpr:
@@ -46,26 +51,211 @@ func (b *blockChecker) AfterEnterNode(n ir.Node) {
 		b.handleConstFetch(n)
 	case *ir.StaticPropertyFetchExpr:
 		b.handleStaticPropertyFetch(n)
+		b.handleGlobalAccess(n, b.isWriteTarget(n))
 	case *ir.SimpleVar:
 		b.handleSimpleVar(n)
+	case *ir.ArrayDimFetchExpr:
+		b.handleGlobalAccess(n, b.isWriteTarget(n))
+	case *ir.GlobalStmt:
+		b.handleGlobalStmt(n)
 	case *ir.PropertyFetchExpr:
 		b.handlePropertyFetch(n)
 	case *ir.Assign:
 		b.handleAssign(n)
+	case *ir.ThrowStmt:
+		b.handleThrow(n)
+	case *ir.CatchStmt:
+		b.handleCatch(n)
+	case *ir.InstanceOfExpr:
+		b.handleInstanceOf(n)
+	case *ir.EchoStmt:
+		b.handleConstruct(n, "echo")
+	case *ir.InlineHTMLStmt:
+		b.handleConstruct(n, "echo")
+	case *ir.PrintExpr:
+		b.handleConstruct(n, "print")
+	case *ir.String:
+		b.handleCallable(n)
+	case *ir.ArrayExpr:
+		b.handleCallable(n)
+	}
+}
+
+// currentFunc returns the function to which the passed node belongs,
+// this is the innermost anonymous function containing the node, if any,
+// otherwise the current function of the root checker.
+func (b *blockChecker) currentFunc(n ir.Node) (*symbols.Function, bool) {
+	pos := ir.GetPosition(n)
+	if pos == nil || len(b.Root.anonymousRanges) == 0 {
+		return b.Root.getCurrentFunc()
+	}
+
+	var cur *anonymousRange
+	for i := range b.Root.anonymousRanges {
+		r := &b.Root.anonymousRanges[i]
+		if pos.StartPos < r.start || pos.EndPos > r.end {
+			continue
+		}
+
+		if cur == nil || r.end-r.start < cur.end-cur.start {
+			cur = r
+		}
+	}
+
+	if cur == nil {
+		return b.Root.getCurrentFunc()
+	}
+
+	return cur.fn, true
+}
+
+func (b *blockChecker) handleThrow(n *ir.ThrowStmt) {
+	curFunc, ok := b.currentFunc(n)
+	if !ok {
+		return
+	}
+
+	guards := b.currentGuards()
+	line := int32(ir.GetPosition(n).StartLine)
+
+	tp := solver.ExprType(b.Ctx.Scope(), b.Ctx.ClassParseState(), n.Expr)
+	tp.Iterate(func(typ string) {
+		class, ok := GlobalCtx.Classes.Get(typ)
+		if !ok {
+			return
+		}
+
+		curFunc.AddThrow(&symbols.ExceptionSite{
+			Exception: class,
+			Func:      curFunc,
+			Line:      line,
+			Guards:    guards,
+		})
+	})
+}
+
+func (b *blockChecker) handleCatch(n *ir.CatchStmt) {
+	curFunc, ok := b.currentFunc(n)
+	if !ok {
+		return
+	}
+
+	line := int32(ir.GetPosition(n).StartLine)
+
+	curClass, inClass := b.Root.getCurrentClass()
+
+	for _, class := range b.catchClasses(n) {
+		if inClass {
+			curClass.AddDependency(class, symbols.DepTypeHint)
+		}
+
+		curFunc.AddCatch(&symbols.ExceptionSite{
+			Exception: class,
+			Func:      curFunc,
+			Line:      line,
+		})
+	}
+}
+
+func (b *blockChecker) handleConstruct(n ir.Node, name string) {
+	curFunc, ok := b.currentFunc(n)
+	if !ok {
+		return
+	}
+
+	curFunc.AddConstruct(name)
+}
+
+func (b *blockChecker) handleInstanceOf(n *ir.InstanceOfExpr) {
+	curClass, ok := b.Root.getCurrentClass()
+	if !ok {
+		return
+	}
+
+	className, ok := solver.GetClassName(b.Ctx.ClassParseState(), n.Class)
+	if !ok {
+		return
+	}
+
+	class, ok := GlobalCtx.Classes.Get(className)
+	if !ok {
+		return
+	}
+
+	curClass.AddDependency(class, symbols.DepTypeHint)
+}
+
+// currentGuards returns the exceptions caught by all try blocks
+// of the current function that enclose the current node.
+func (b *blockChecker) currentGuards() []*symbols.Class {
+	var guards []*symbols.Class
+
+	path := b.Ctx.NodePath()
+
+	var child ir.Node
+	for i := 0; ; i++ {
+		node := path.NthParent(i)
+		if node == nil {
+			break
+		}
+
+		switch node := node.(type) {
+		case *ir.ClosureExpr, *ir.ArrowFunctionExpr, *ir.FunctionStmt, *ir.ClassMethodStmt:
+			return guards
+		case *ir.TryStmt:
+			switch child.(type) {
+			case *ir.CatchStmt, *ir.FinallyStmt:
+				// Exceptions from catch and finally blocks are not caught by the same try.
+			default:
+				for _, c := range node.Catches {
+					guards = append(guards, b.catchClasses(c.(*ir.CatchStmt))...)
+				}
+			}
+		}
+
+		child = node
 	}
+
+	return guards
+}
+
+func (b *blockChecker) catchClasses(n *ir.CatchStmt) []*symbols.Class {
+	classes := make([]*symbols.Class, 0, len(n.Types))
+
+	for _, typ := range n.Types {
+		className, ok := solver.GetClassName(b.Ctx.ClassParseState(), typ)
+		if !ok {
+			continue
+		}
+
+		class, ok := GlobalCtx.Classes.Get(className)
+		if !ok {
+			continue
+		}
+
+		classes = append(classes, class)
+	}
+
+	return classes
 }
 
 func (b *blockChecker) handleAssign(a *ir.Assign) {
+	if !b.Root.firstVisit(a.Variable) {
+		return
+	}
+
 	switch n := a.Variable.(type) {
 	case *ir.PropertyFetchExpr:
 		b.handlePropertyFetch(n)
 	case *ir.StaticPropertyFetchExpr:
 		b.handleStaticPropertyFetch(n)
 	}
+
+	b.handleGlobalAssign(a.Variable)
 }
 
 func (b *blockChecker) handleStaticPropertyFetch(n *ir.StaticPropertyFetchExpr) {
-	curMethod, ok := b.Root.getCurrentFunc()
+	curMethod, ok := b.currentFunc(n)
 	if !ok {
 		return
 	}
@@ -95,7 +285,7 @@ func (b *blockChecker) handleStaticPropertyFetch(n *ir.StaticPropertyFetchExpr)
 }
 
 func (b *blockChecker) handlePropertyFetch(n *ir.PropertyFetchExpr) {
-	curMethod, ok := b.Root.getCurrentFunc()
+	curMethod, ok := b.currentFunc(n)
 	if !ok {
 		return
 	}
@@ -122,14 +312,16 @@ func (b *blockChecker) handlePropertyFetch(n *ir.PropertyFetchExpr) {
 	})
 }
 
-func (b *blockChecker) handleSimpleVar(*ir.SimpleVar) {}
+func (b *blockChecker) handleSimpleVar(n *ir.SimpleVar) {
+	b.handleGlobalAccess(n, b.isWriteTarget(n))
+}
 
 func (b *blockChecker) handleConstFetch(n *ir.ConstFetchExpr) {
 	curClass, ok := b.Root.getCurrentClass()
 	if !ok {
 		return
 	}
-	curMethod, ok := b.Root.getCurrentFunc()
+	curMethod, ok := b.currentFunc(n)
 	if !ok {
 		return
 	}
@@ -164,33 +356,45 @@ func (b *blockChecker) handleClassConstFetch(n *ir.ClassConstFetchExpr) {
 
 	constantName := n.ConstantName.Value
 
-	curMethod, ok := b.Root.getCurrentFunc()
+	curMethod, ok := b.currentFunc(n)
 	if ok {
 		class.Constants.AddMethodAccess(symbols.NewConstantKey(constantName, class), curMethod)
+		return
 	}
 
-	curClass.AddDeps(class)
-	class.AddDepsBy(curClass)
+	curClass.AddDependency(class, symbols.DepConstant)
 }
 
 func (b *blockChecker) handleNew(n *ir.NewExpr) {
-	curClass, ok := b.Root.getCurrentClass()
+	className, ok := solver.GetClassName(b.Ctx.ClassParseState(), n.Class)
 	if !ok {
+		switch n.Class.(type) {
+		case *ir.Name, *ir.Identifier, *ir.AnonClassExpr:
+		default:
+			b.handleUnresolved(n, symbols.UnresolvedDynamicNew, &ir.NewExpr{Class: n.Class})
+		}
 		return
 	}
 
-	className, ok := solver.GetClassName(b.Ctx.ClassParseState(), n.Class)
+	class, ok := GlobalCtx.Classes.Get(className)
 	if !ok {
 		return
 	}
 
-	class, ok := GlobalCtx.Classes.Get(className)
+	GlobalCtx.Classes.MarkInstantiated(class)
+
+	if curFunc, ok := b.currentFunc(n); ok {
+		curFunc.Instantiates.Add(class)
+	} else {
+		b.Root.CurFile.Instantiates.Add(class)
+	}
+
+	curClass, ok := b.Root.getCurrentClass()
 	if !ok {
 		return
 	}
 
-	class.AddDepsBy(curClass)
-	curClass.AddDeps(class)
+	curClass.AddDependency(class, symbols.DepNew)
 }
 
 func (b *blockChecker) handleImport(n *ir.ImportExpr) {
@@ -210,34 +414,50 @@ func (b *blockChecker) handleImport(n *ir.ImportExpr) {
 }
 
 func (b *blockChecker) handleStaticMethodCall(n *ir.StaticCallExpr) {
+	callee := &ir.StaticCallExpr{Class: n.Class, Call: n.Call}
+
 	method, ok := n.Call.(*ir.Identifier)
 	if !ok {
+		b.handleUnresolved(n, symbols.UnresolvedDynamicMethod, callee)
 		return
 	}
 	methodName := method.Value
 	className, ok := solver.GetClassName(b.Ctx.ClassParseState(), n.Class)
 	if !ok {
+		b.handleUnresolved(n, symbols.UnresolvedDynamicClass, callee)
 		return
 	}
 	_, ok = meta.Info.GetClassOrTrait(className)
 	if !ok {
+		b.handleUnresolved(n, symbols.UnresolvedUnknownClass, callee)
 		return
 	}
 
 	classType := meta.NewTypesMap(className)
 
-	b.handleMethod(methodName, classType)
+	if !b.handleMethod(n, methodName, classType) {
+		b.handleUnresolved(n, symbols.UnresolvedUnknownMethod, callee)
+	}
 }
 
 func (b *blockChecker) handleMethodCall(n *ir.MethodCallExpr) {
+	callee := &ir.MethodCallExpr{Variable: n.Variable, Method: n.Method}
+
 	method, ok := n.Method.(*ir.Identifier)
 	if !ok {
+		b.handleUnresolved(n, symbols.UnresolvedDynamicMethod, callee)
 		return
 	}
 	methodName := method.Value
 	classType := solver.ExprType(b.Ctx.Scope(), b.Ctx.ClassParseState(), n.Variable)
 
-	b.handleMethod(methodName, classType)
+	if !b.handleMethod(n, methodName, classType) {
+		if hasClassType(classType) {
+			b.handleUnresolved(n, symbols.UnresolvedUnknownMethod, callee)
+		} else {
+			b.handleUnresolved(n, symbols.UnresolvedUnknownReceiver, callee)
+		}
+	}
 
 	for _, nn := range n.Args {
 		nn.Walk(b)
@@ -247,34 +467,42 @@ func (b *blockChecker) handleMethodCall(n *ir.MethodCallExpr) {
 func (b *blockChecker) handleFunctionCall(n *ir.FunctionCallExpr) {
 	name, ok := solver.GetFuncName(b.Ctx.ClassParseState(), n.Function)
 	if !ok {
+		b.handleUnresolved(n, symbols.UnresolvedVariableFunction, n.Function)
 		return
 	}
 
-	calledFuncInfo, ok := meta.Info.GetFunction(name)
+	calledFunc, ok := findFunc(name)
 	if !ok {
+		b.handleUnresolved(n, symbols.UnresolvedUnknownFunction, n.Function)
 		return
 	}
 
-	calledName := name
-	calledFuncKey := symbols.FuncKey{
-		Name: calledName,
+	b.handleCallbackArgs(n, name)
+
+	b.handleCalled(n, calledFunc)
+
+	for _, nn := range n.Args {
+		nn.Walk(b)
 	}
-	calledFunPos := calledFuncInfo.Pos
+}
 
-	calledFunc, found := GlobalCtx.Functions.Get(calledFuncKey)
+// handleMethod adds the call of the method found in one of the passed
+// classes, it returns false if the method is not found.
+func (b *blockChecker) handleMethod(n ir.Node, name string, classType meta.TypesMap) bool {
+	calledFunc, found := findMethodFunc(name, classType)
 	if !found {
-		calledFunc = symbols.NewFunction(calledFuncKey, calledFunPos)
-		GlobalCtx.Functions.Add(calledFunc)
+		return false
 	}
 
-	b.handleCalled(calledFunc)
-
-	for _, nn := range n.Args {
-		nn.Walk(b)
+	if calledFunc != nil {
+		b.handleCalled(n, calledFunc)
 	}
+	return true
 }
 
-func (b *blockChecker) handleMethod(name string, classType meta.TypesMap) {
+// findMethodFunc returns the method found in one of the passed classes,
+// the returned function is nil if the class of the method is unknown.
+func findMethodFunc(name string, classType meta.TypesMap) (*symbols.Function, bool) {
 	var calledMethodInfo solver.FindMethodResult
 
 	found := classType.Find(func(typ string) bool {
@@ -287,7 +515,7 @@ func (b *blockChecker) handleMethod(name string, classType meta.TypesMap) {
 	})
 
 	if !found {
-		return
+		return nil, false
 	}
 
 	calledName := calledMethodInfo.Info.Name
@@ -299,24 +527,59 @@ func (b *blockChecker) handleMethod(name string, classType meta.TypesMap) {
 
 	calledClass, ok := GlobalCtx.Classes.Get(calledMethodInfo.ImplName())
 	if !ok {
-		return
+		return nil, true
 	}
 
 	calledFunc, found := GlobalCtx.Functions.Get(calledFuncKey)
 	if !found {
 		calledFunc = symbols.NewMethod(calledFuncKey, calledFunPos, calledClass)
+		calledFunc.Deprecated = calledMethodInfo.Info.Doc.Deprecated
+		calledFunc.DeprecationMessage = calledMethodInfo.Info.Doc.DeprecationNote
 	}
 
-	b.handleCalled(calledFunc)
+	return calledFunc, true
 }
 
-func (b *blockChecker) handleCalled(calledFunc *symbols.Function) {
-	curFunc, ok := b.Root.getCurrentFunc()
+// findFunc returns the function with the passed name.
+func findFunc(name string) (*symbols.Function, bool) {
+	calledFuncInfo, ok := meta.Info.GetFunction(name)
+	if !ok {
+		return nil, false
+	}
+
+	calledFuncKey := symbols.FuncKey{
+		Name: name,
+	}
+
+	calledFunc, found := GlobalCtx.Functions.Get(calledFuncKey)
+	if !found {
+		calledFunc = symbols.NewFunction(calledFuncKey, calledFuncInfo.Pos)
+		calledFunc.Deprecated = calledFuncInfo.Doc.Deprecated
+		calledFunc.DeprecationMessage = calledFuncInfo.Doc.DeprecationNote
+		GlobalCtx.Functions.Add(calledFunc)
+	}
+
+	return calledFunc, true
+}
+
+func (b *blockChecker) handleCalled(n ir.Node, calledFunc *symbols.Function) {
+	curFunc, ok := b.currentFunc(n)
 	if !ok {
 		calledFunc.AddUse()
+		b.Root.CurFile.Called.Add(calledFunc)
 		return
 	}
 
+	kind := symbols.DepCall
+	switch n.(type) {
+	case *ir.StaticCallExpr:
+		kind = symbols.DepStaticCall
+	case *ir.String, *ir.ArrayExpr:
+		kind = symbols.DepIndirectCall
+	}
+
 	curFunc.AddCalled(calledFunc)
+	curFunc.AddCallEdge(calledFunc, kind, b.location(n))
 	calledFunc.AddCalledBy(curFunc)
+	curFunc.AddCallGuards(calledFunc, b.currentGuards())
 }
@@ -14,7 +14,7 @@ type blockIndexer struct {
 // AfterEnterNode describes the processing logic after entering the node.
 func (b *blockIndexer) AfterEnterNode(n ir.Node) {
 	switch n.(type) {
-	case *ir.ClosureExpr:
+	case *ir.ClosureExpr, *ir.ArrowFunctionExpr:
 		b.Root.Meta.CountAnonymousFunctions++
 	}
 }
@@ -0,0 +1,149 @@
+package walkers
+
+import (
+	"strings"
+
+	"github.com/VKCOM/noverify/src/ir"
+	"github.com/VKCOM/noverify/src/meta"
+	"github.com/VKCOM/noverify/src/solver"
+
+	"github.com/i582/phpstats/internal/stats/symbols"
+)
+
+// callbackArgs contains the positions of the callback arguments
+// of the functions that call the passed callbacks.
+var callbackArgs = map[string][]int{
+	`\call_user_func`:             {0},
+	`\call_user_func_array`:       {0},
+	`\array_map`:                  {0},
+	`\array_filter`:               {1},
+	`\array_reduce`:               {1},
+	`\array_walk`:                 {1},
+	`\array_walk_recursive`:       {1},
+	`\usort`:                      {1},
+	`\uasort`:                     {1},
+	`\uksort`:                     {1},
+	`\iterator_apply`:             {1},
+	`\preg_replace_callback`:      {1},
+	`\register_shutdown_function`: {0},
+	`\spl_autoload_register`:      {0},
+	`\set_error_handler`:          {0},
+	`\set_exception_handler`:      {0},
+	`\add_action`:                 {1},
+	`\add_filter`:                 {1},
+}
+
+// handleCallbackArgs adds the indirect calls of the functions passed by name
+// to the functions taking callbacks, the other callables are handled
+// where they are declared.
+func (b *blockChecker) handleCallbackArgs(n *ir.FunctionCallExpr, name string) {
+	for _, pos := range callbackArgs[name] {
+		if pos >= len(n.Args) {
+			continue
+		}
+
+		arg, ok := n.Args[pos].(*ir.Argument)
+		if !ok {
+			continue
+		}
+
+		switch expr := arg.Expr.(type) {
+		case *ir.ClosureExpr, *ir.ArrowFunctionExpr:
+			continue
+		case *ir.String:
+			if !strings.Contains(expr.Value, "::") {
+				if calledFunc, ok := findFunc(`\` + strings.TrimPrefix(expr.Value, `\`)); ok {
+					b.handleCalled(expr, calledFunc)
+					continue
+				}
+			}
+		}
+
+		if _, ok := b.callableFunc(arg.Expr); ok {
+			continue
+		}
+
+		if isCallUserFunc(name) {
+			b.handleUnresolved(n, symbols.UnresolvedCallUserFunc, arg.Expr)
+		}
+	}
+}
+
+// handleCallable adds the indirect call of the method
+// if the node is a 'Class::method' or [Class::class, 'method'] callable.
+func (b *blockChecker) handleCallable(n ir.Node) {
+	if str, ok := n.(*ir.String); ok && !strings.Contains(str.Value, "::") {
+		return
+	}
+
+	calledFunc, ok := b.callableFunc(n)
+	if !ok {
+		return
+	}
+
+	b.handleCalled(n, calledFunc)
+}
+
+// callableFunc returns the function called by the callable,
+// if the function is statically known.
+func (b *blockChecker) callableFunc(n ir.Node) (*symbols.Function, bool) {
+	switch n := n.(type) {
+	case *ir.String:
+		if !strings.Contains(n.Value, "::") {
+			return findFunc(`\` + strings.TrimPrefix(n.Value, `\`))
+		}
+
+		parts := strings.SplitN(n.Value, "::", 2)
+		classType := meta.NewTypesMap(`\` + strings.TrimPrefix(parts[0], `\`))
+
+		calledFunc, found := findMethodFunc(parts[1], classType)
+		return calledFunc, found && calledFunc != nil
+
+	case *ir.ArrayExpr:
+		if len(n.Items) != 2 {
+			return nil, false
+		}
+
+		for _, item := range n.Items {
+			if item == nil || item.Key != nil || item.Unpack {
+				return nil, false
+			}
+		}
+
+		method, ok := n.Items[1].Val.(*ir.String)
+		if !ok {
+			return nil, false
+		}
+
+		classType := b.callableClassType(n.Items[0].Val)
+		if classType.IsEmpty() {
+			return nil, false
+		}
+
+		calledFunc, found := findMethodFunc(method.Value, classType)
+		return calledFunc, found && calledFunc != nil
+	}
+
+	return nil, false
+}
+
+// callableClassType returns the classes of the first element of the array callable.
+func (b *blockChecker) callableClassType(n ir.Node) meta.TypesMap {
+	switch n := n.(type) {
+	case *ir.String:
+		return meta.NewTypesMap(`\` + strings.TrimPrefix(n.Value, `\`))
+	case *ir.ClassConstFetchExpr:
+		if n.ConstantName.Value != "class" {
+			return meta.TypesMap{}
+		}
+
+		className, ok := solver.GetClassName(b.Ctx.ClassParseState(), n.Class)
+		if !ok {
+			return meta.TypesMap{}
+		}
+
+		return meta.NewTypesMap(className)
+	}
+
+	return solver.ExprType(b.Ctx.Scope(), b.Ctx.ClassParseState(), n)
+}
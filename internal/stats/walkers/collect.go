@@ -64,5 +64,12 @@ func Collect() error {
 	})
 
 	GlobalCtx.BarLinting.Finish()
+
+	GlobalCtx.DetectClones(GlobalCtx.MinCloneTokens)
+	GlobalCtx.DetectTests()
+	GlobalCtx.PropagateExceptions()
+	GlobalCtx.PropagateEffects()
+	GlobalCtx.DetectOverrides()
+	GlobalCtx.ResolveVirtualCalls()
 	return nil
 }
@@ -6,14 +6,25 @@ import (
 	"log"
 	"path/filepath"
 	"regexp"
+	"strings"
 
 	"github.com/VKCOM/noverify/src/linter"
 	"github.com/VKCOM/noverify/src/meta"
 	"github.com/cheggaaa/pb/v3"
 
 	"github.com/i582/phpstats/internal/config"
+	"github.com/i582/phpstats/internal/stats/clones"
+	"github.com/i582/phpstats/internal/stats/coverage"
+	"github.com/i582/phpstats/internal/stats/deprecations"
+	"github.com/i582/phpstats/internal/stats/dispatch"
+	"github.com/i582/phpstats/internal/stats/effects"
+	"github.com/i582/phpstats/internal/stats/exceptions"
 	"github.com/i582/phpstats/internal/stats/filemeta"
+	"github.com/i582/phpstats/internal/stats/impact"
+	"github.com/i582/phpstats/internal/stats/overrides"
+	"github.com/i582/phpstats/internal/stats/reachability"
 	"github.com/i582/phpstats/internal/stats/symbols"
+	"github.com/i582/phpstats/internal/stats/testmap"
 )
 
 // GlobalCtx stores all functions, classes, files, constants and namespaces.
@@ -26,6 +37,10 @@ type globalContext struct {
 	Constants  *symbols.Constants
 	Namespaces *symbols.Namespaces
 
+	// Globals stores the global variables, superglobals
+	// and static properties used in functions.
+	Globals *symbols.Globals
+
 	Packages *config.Packages
 
 	ProjectRoot   string
@@ -37,6 +52,29 @@ type globalContext struct {
 
 	CountCommentLine        int64
 	CountAnonymousFunctions int64
+
+	MinCloneTokens int64
+	Clones         []*clones.Group
+
+	// FoldAnonymous disables the analysis of anonymous functions and classes
+	// as separate symbols, their metrics and calls are attributed to the
+	// named functions in which they are declared.
+	FoldAnonymous bool
+
+	TestBaseClasses       []string
+	TestDirs              []string
+	IncludeTestsInMetrics bool
+
+	CoverageLoaded bool
+
+	// EffectCategories are the categories of side effects of built-in functions.
+	EffectCategories []*effects.Category
+
+	// Overrides stores the methods that override or implement parent methods.
+	Overrides []*overrides.Override
+
+	// EntryPoints describes the entry points for the reachability analysis.
+	EntryPoints reachability.Config
 }
 
 func newGlobalContext() *globalContext {
@@ -46,13 +84,138 @@ func newGlobalContext() *globalContext {
 		Files:      symbols.NewFiles(),
 		Constants:  symbols.NewConstants(),
 		Namespaces: symbols.NewNamespaces(),
+		Globals:    symbols.NewGlobals(),
 		Packages:   &config.Packages{},
+
+		MinCloneTokens: clones.DefaultMinTokens,
+
+		TestBaseClasses: testmap.DefaultBaseClasses,
+
+		EntryPoints: reachability.Config{
+			Scripts: reachability.DefaultScripts,
+			Tags:    reachability.DefaultTags,
+		},
+	}
+}
+
+// DetectClones searches for duplicated code in all collected files
+// and saves the found clone groups.
+func (ctx *globalContext) DetectClones(minTokens int64) {
+	ctx.MinCloneTokens = minTokens
+	ctx.Clones = clones.Detect(ctx.Files, int(minTokens))
+}
+
+// DetectTests marks test classes and maps them to the production code they use.
+func (ctx *globalContext) DetectTests() {
+	testmap.Detect(ctx.Classes, ctx.Functions, testmap.Options{
+		BaseClasses: ctx.TestBaseClasses,
+		Dirs:        ctx.TestDirs,
+		ProjectRoot: ctx.ProjectRoot,
+	})
+}
+
+// PropagateExceptions calculates the exceptions that can be thrown out of each function.
+func (ctx *globalContext) PropagateExceptions() {
+	exceptions.Propagate(ctx.Functions)
+}
+
+// SetEffectCategories sets the side effect categories, the passed
+// categories replace the default categories with the same name.
+func (ctx *globalContext) SetEffectCategories(config map[string][]string) error {
+	categories, err := effects.NewCategories(config)
+	if err != nil {
+		return err
+	}
+
+	ctx.EffectCategories = categories
+	return nil
+}
+
+// PropagateEffects calculates the side effects that each function may perform.
+func (ctx *globalContext) PropagateEffects() {
+	if ctx.EffectCategories == nil {
+		ctx.EffectCategories, _ = effects.NewCategories(nil)
+	}
+
+	effects.Propagate(ctx.Functions, ctx.EffectCategories)
+}
+
+// Deprecations returns the deprecated symbols and the functions
+// and classes that use them directly or transitively.
+func (ctx *globalContext) Deprecations(onlyDirect bool) []*deprecations.Deprecation {
+	return deprecations.Collect(ctx.Functions, ctx.Classes, ctx.Constants, deprecations.Options{
+		OnlyDirect:   onlyDirect,
+		RelativePath: ctx.RelativePath,
+	})
+}
+
+// DetectOverrides finds the overridden and implemented methods and checks their signatures.
+func (ctx *globalContext) DetectOverrides() {
+	ctx.Overrides = overrides.Detect(ctx.Classes)
+}
+
+// ResolveVirtualCalls links the method calls with the overriding methods
+// of subclasses for the polymorphic modes of the call graph.
+func (ctx *globalContext) ResolveVirtualCalls() {
+	dispatch.Resolve(ctx.Functions, ctx.Classes)
+}
+
+// Reachability finds the entry points and the functions, classes
+// and files reachable from them in the passed call graph mode.
+func (ctx *globalContext) Reachability(mode symbols.CallGraphMode) (*reachability.Result, error) {
+	cfg := ctx.EntryPoints
+	cfg.RelativePath = ctx.RelativePath
+
+	return reachability.Analyze(ctx.Files, ctx.Functions, ctx.Classes, cfg, mode)
+}
+
+// ImpactOptions returns the options of the impact analysis,
+// the symbols are grouped by the configured packages.
+func (ctx *globalContext) ImpactOptions(maxDepth int) impact.Options {
+	return impact.Options{
+		MaxDepth: maxDepth,
+		PackageOf: func(className string) (string, bool) {
+			pack, ok := ctx.Packages.GetPackage(className)
+			if !ok {
+				return "", false
+			}
+			return pack.Name, true
+		},
+	}
+}
+
+// LoadCoverage reads the Clover XML report and sets the coverage for all functions.
+func (ctx *globalContext) LoadCoverage(path string) error {
+	report, err := coverage.OpenClover(path)
+	if err != nil {
+		return err
 	}
+
+	coverage.Apply(report, ctx.Functions)
+	ctx.CoverageLoaded = true
+
+	return nil
+}
+
+// RelativePath returns the path relative to the project root,
+// if this is not possible, the path is returned as is.
+func (ctx *globalContext) RelativePath(path string) string {
+	root, err := filepath.Abs(ctx.ProjectRoot)
+	if err != nil {
+		return path
+	}
+
+	rel, err := filepath.Rel(root, path)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return path
+	}
+
+	return rel
 }
 
 // Version returns the current version of the cache.
 func (ctx *globalContext) Version() string {
-	return "1.0.1"
+	return "1.0.5"
 }
 
 // Encode caches the data of one rootWalker of one file.
@@ -91,6 +254,73 @@ func (ctx *globalContext) Decode(r io.Reader, filename string) error {
 	return nil
 }
 
+// linkAnonymous links the anonymous functions and classes of the file
+// with their owners or, if they are folded, adds their metrics to the
+// named functions in which they are declared.
+func (ctx *globalContext) linkAnonymous(f *filemeta.FileMeta) {
+	for _, fn := range f.Funcs.Funcs {
+		if !fn.IsAnonymous() {
+			continue
+		}
+
+		if ctx.FoldAnonymous {
+			ownerName := fn.OwnerName
+			for {
+				owner, ok := f.Funcs.Get(ownerName)
+				if !ok || !owner.IsAnonymous() {
+					break
+				}
+				ownerName = owner.OwnerName
+			}
+
+			owner, ok := ctx.Functions.Get(ownerName)
+			if !ok {
+				continue
+			}
+
+			owner.CyclomaticComplexity += fn.CyclomaticComplexity
+			owner.CountMagicNumbers += fn.CountMagicNumbers
+			continue
+		}
+
+		fun, ok := ctx.Functions.Get(fn.Name)
+		if !ok {
+			continue
+		}
+
+		owner, ok := ctx.Functions.Get(fn.OwnerName)
+		if !ok {
+			continue
+		}
+
+		owner.AddAnonymous(fun)
+		owner.CallEdges.AddAt(fun.Name.String(), symbols.DepCall, symbols.Location{
+			File: ctx.RelativePath(fun.Pos.Filename),
+			Line: fun.Pos.Line,
+		})
+		if fun.Class == nil {
+			fun.Class = owner.Class
+		}
+	}
+
+	if ctx.FoldAnonymous {
+		return
+	}
+
+	for _, class := range f.Classes.Classes {
+		if !class.IsAnonymous {
+			continue
+		}
+
+		cl, ok := ctx.Classes.Get(class.Name)
+		if !ok {
+			continue
+		}
+
+		cl.Owner, _ = ctx.Functions.Get(class.OwnerName)
+	}
+}
+
 // UpdateMeta recovers data by collecting it from each file.
 func (ctx *globalContext) UpdateMeta(f *filemeta.FileMeta, filename string) {
 	for range f.Files.Files {
@@ -105,12 +335,17 @@ func (ctx *globalContext) UpdateMeta(f *filemeta.FileMeta, filename string) {
 	ctx.CountAnonymousFunctions += f.CountAnonymousFunctions
 
 	for _, file := range f.Files.Files {
-		f := symbols.NewFile(file.Path)
+		newFile := symbols.NewFile(file.Path)
+		newFile.Tokens = f.Tokens
 
-		ctx.Files.Add(f)
+		ctx.Files.Add(newFile)
 	}
 
 	for _, class := range f.Classes.Classes {
+		if class.IsAnonymous && ctx.FoldAnonymous {
+			continue
+		}
+
 		var cl *symbols.Class
 
 		file, ok := ctx.Files.Get(class.File.Path)
@@ -129,11 +364,21 @@ func (ctx *globalContext) UpdateMeta(f *filemeta.FileMeta, filename string) {
 		}
 
 		cl.IsVendor = class.IsVendor
+		cl.UsedTraitNames = class.UsedTraitNames
+		cl.TraitAdaptations = class.TraitAdaptations
+		cl.IsAnonymous = class.IsAnonymous
+		cl.OwnerName = class.OwnerName
+		cl.Deprecated = class.Deprecated
+		cl.DeprecationMessage = class.DeprecationMessage
 
 		ctx.Classes.Add(cl)
 	}
 
 	for _, fn := range f.Funcs.Funcs {
+		if fn.IsAnonymous() && ctx.FoldAnonymous {
+			continue
+		}
+
 		fun := symbols.NewFunction(fn.Name, fn.Pos)
 
 		if fun.IsMethod() {
@@ -148,10 +393,16 @@ func (ctx *globalContext) UpdateMeta(f *filemeta.FileMeta, filename string) {
 		fun.CyclomaticComplexity = fn.CyclomaticComplexity
 		fun.CountMagicNumbers = fn.CountMagicNumbers
 		fun.FullyTyped = fn.FullyTyped
+		fun.AnonymousKind = fn.AnonymousKind
+		fun.OwnerName = fn.OwnerName
+		fun.Deprecated = fn.Deprecated
+		fun.DeprecationMessage = fn.DeprecationMessage
 
 		ctx.Functions.Add(fun)
 	}
 
+	ctx.linkAnonymous(f)
+
 	if f.Constants != nil {
 		for _, constant := range f.Constants.Constants {
 			ctx.Constants.Add(constant)
@@ -0,0 +1,207 @@
+package walkers
+
+import (
+	"github.com/VKCOM/noverify/src/ir"
+	"github.com/VKCOM/noverify/src/solver"
+
+	"github.com/i582/phpstats/internal/stats/symbols"
+	"github.com/i582/phpstats/internal/utils"
+)
+
+// handleGlobalStmt remembers the variables imported by the global statement,
+// all subsequent uses of them in the function are uses of global variables.
+func (b *blockChecker) handleGlobalStmt(n *ir.GlobalStmt) {
+	curFunc, ok := b.currentFunc(n)
+	if !ok {
+		return
+	}
+
+	vars, ok := b.Root.globalVars[curFunc]
+	if !ok {
+		vars = map[string]struct{}{}
+		b.Root.globalVars[curFunc] = vars
+	}
+
+	for _, v := range n.Vars {
+		v, ok := v.(*ir.SimpleVar)
+		if !ok {
+			continue
+		}
+
+		vars[v.Name] = struct{}{}
+	}
+}
+
+// handleGlobalAccess records the use of the global state by the current function
+// if the passed node is a global variable, a superglobal or a static property.
+func (b *blockChecker) handleGlobalAccess(n ir.Node, write bool) {
+	curFunc, ok := b.currentFunc(n)
+	if !ok {
+		return
+	}
+
+	name, kind, ok := b.globalName(curFunc, n)
+	if !ok {
+		return
+	}
+
+	GlobalCtx.Globals.AddAccess(name, kind, curFunc, write)
+}
+
+// handleGlobalAssign records the writes to the global state
+// on the left side of the assignment.
+func (b *blockChecker) handleGlobalAssign(n ir.Node) {
+	for {
+		b.Root.firstVisit(n)
+		b.handleGlobalAccess(n, true)
+
+		dim, ok := n.(*ir.ArrayDimFetchExpr)
+		if !ok {
+			return
+		}
+		n = dim.Variable
+	}
+}
+
+func (b *blockChecker) globalName(curFunc *symbols.Function, n ir.Node) (string, symbols.GlobalKind, bool) {
+	switch n := n.(type) {
+	case *ir.SimpleVar:
+		if n.Name == "GLOBALS" {
+			// Handled as part of the $GLOBALS[...] fetch.
+			return "", 0, false
+		}
+
+		if utils.IsSuperGlobal(n.Name) {
+			return "$" + n.Name, symbols.SuperGlobal, true
+		}
+
+		if _, ok := b.Root.globalVars[curFunc][n.Name]; ok {
+			return "$" + n.Name, symbols.GlobalVariable, true
+		}
+
+	case *ir.ArrayDimFetchExpr:
+		v, ok := n.Variable.(*ir.SimpleVar)
+		if !ok || v.Name != "GLOBALS" {
+			return "", 0, false
+		}
+
+		if dim, ok := n.Dim.(*ir.String); ok {
+			return "$" + dim.Value, symbols.GlobalVariable, true
+		}
+
+		return "$GLOBALS", symbols.SuperGlobal, true
+
+	case *ir.StaticPropertyFetchExpr:
+		prop, ok := n.Property.(*ir.SimpleVar)
+		if !ok {
+			return "", 0, false
+		}
+
+		className, ok := solver.GetClassName(b.Root.Ctx.ClassParseState(), n.Class)
+		if !ok {
+			return "", 0, false
+		}
+
+		p, ok := solver.FindProperty(className, "$"+prop.Name)
+		if !ok {
+			return "", 0, false
+		}
+
+		return p.ImplName() + "::$" + prop.Name, symbols.StaticProperty, true
+	}
+
+	return "", 0, false
+}
+
+// isWriteTarget checks if the current node is the variable being modified
+// by an assignment, an increment, a decrement or unset, possibly
+// through the array element fetches.
+func (b *blockChecker) isWriteTarget(n ir.Node) bool {
+	path := b.Ctx.NodePath()
+	if path.Current() != n {
+		return false
+	}
+
+	child := n
+	for i := 1; ; i++ {
+		parent := path.NthParent(i)
+		if parent == nil {
+			return false
+		}
+
+		var variable ir.Node
+		switch parent := parent.(type) {
+		case *ir.ArrayDimFetchExpr:
+			if parent.Variable == child {
+				child = parent
+				continue
+			}
+			return false
+		case *ir.Assign:
+			variable = parent.Variable
+		case *ir.AssignReference:
+			variable = parent.Variable
+		case *ir.AssignPlus:
+			variable = parent.Variable
+		case *ir.AssignMinus:
+			variable = parent.Variable
+		case *ir.AssignMul:
+			variable = parent.Variable
+		case *ir.AssignDiv:
+			variable = parent.Variable
+		case *ir.AssignMod:
+			variable = parent.Variable
+		case *ir.AssignPow:
+			variable = parent.Variable
+		case *ir.AssignConcat:
+			variable = parent.Variable
+		case *ir.AssignCoalesce:
+			variable = parent.Variable
+		case *ir.AssignBitwiseAnd:
+			variable = parent.Variable
+		case *ir.AssignBitwiseOr:
+			variable = parent.Variable
+		case *ir.AssignBitwiseXor:
+			variable = parent.Variable
+		case *ir.AssignShiftLeft:
+			variable = parent.Variable
+		case *ir.AssignShiftRight:
+			variable = parent.Variable
+		case *ir.PreIncExpr:
+			variable = parent.Variable
+		case *ir.PreDecExpr:
+			variable = parent.Variable
+		case *ir.PostIncExpr:
+			variable = parent.Variable
+		case *ir.PostDecExpr:
+			variable = parent.Variable
+		case *ir.UnsetStmt:
+			for _, v := range parent.Vars {
+				if isLValuePart(v, child) {
+					return true
+				}
+			}
+			return false
+		default:
+			return false
+		}
+
+		return isLValuePart(variable, child)
+	}
+}
+
+// isLValuePart checks if the node is the assigned variable itself
+// or the variable whose element is assigned.
+func isLValuePart(variable, n ir.Node) bool {
+	for {
+		if variable == n {
+			return true
+		}
+
+		dim, ok := variable.(*ir.ArrayDimFetchExpr)
+		if !ok {
+			return false
+		}
+		variable = dim.Variable
+	}
+}
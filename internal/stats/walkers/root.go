@@ -19,11 +19,31 @@ type rootChecker struct {
 	Ctx *linter.RootContext
 
 	CurFile *symbols.File
+
+	// anonymousRanges contains the anonymous functions of the current
+	// function, the nodes inside them belong to these functions.
+	anonymousRanges []anonymousRange
+
+	// visitedNodes contains the nodes of the file that have already been
+	// processed, since some nodes are walked several times.
+	visitedNodes map[ir.Node]struct{}
+
+	// globalVars contains the variables imported by the global
+	// statement in each function of the file.
+	globalVars map[*symbols.Function]map[string]struct{}
+}
+
+// anonymousRange describes the part of the file occupied by an anonymous function.
+type anonymousRange struct {
+	start, end int
+	fn         *symbols.Function
 }
 
 // BeforeEnterFile describes the processing logic before entering the file.
 func (r *rootChecker) BeforeEnterFile() {
 	filename := r.Ctx.Filename()
+	r.visitedNodes = map[ir.Node]struct{}{}
+	r.globalVars = map[*symbols.Function]map[string]struct{}{}
 
 	var ok bool
 	r.CurFile, ok = GlobalCtx.Files.Get(filename)
@@ -37,6 +57,66 @@ func (r *rootChecker) BeforeEnterFile() {
 	GlobalCtx.BarLinting.Increment()
 }
 
+// BeforeEnterNode describes the processing logic before entering the node.
+func (r *rootChecker) BeforeEnterNode(n ir.Node) {
+	switch n := n.(type) {
+	case *ir.FunctionStmt:
+		namespace := r.Ctx.ClassParseState().Namespace
+		funcName := namespace + `\` + n.FunctionName.Value
+		if namespace == "" {
+			funcName = `\` + n.FunctionName.Value
+		}
+
+		r.handleAnonymousRanges(symbols.NewFuncKey(funcName), &ir.StmtList{
+			Stmts: n.Stmts,
+		})
+	case *ir.ClassMethodStmt:
+		r.anonymousRanges = nil
+
+		class, ok := r.getCurrentClass()
+		if !ok {
+			return
+		}
+
+		body, ok := n.Stmt.(*ir.StmtList)
+		if !ok {
+			return
+		}
+
+		r.handleAnonymousRanges(symbols.NewMethodKey(n.MethodName.Value, class.Name), body)
+	}
+}
+
+// handleAnonymousRanges collects the ranges of the anonymous functions
+// declared in the body of the function that is being entered.
+func (r *rootChecker) handleAnonymousRanges(owner symbols.FuncKey, body *ir.StmtList) {
+	r.anonymousRanges = nil
+
+	if GlobalCtx.FoldAnonymous {
+		return
+	}
+
+	anonymous, _ := collectAnonymous(owner, body)
+
+	for _, sym := range anonymous {
+		pos := ir.GetPosition(sym.Node)
+		if pos == nil {
+			continue
+		}
+
+		fn, ok := GlobalCtx.Functions.Get(sym.Key)
+		if !ok {
+			continue
+		}
+
+		r.anonymousRanges = append(r.anonymousRanges, anonymousRange{
+			start: pos.StartPos,
+			end:   pos.EndPos,
+			fn:    fn,
+		})
+	}
+}
+
 // AfterEnterNode describes the processing logic after entering the node.
 func (r *rootChecker) AfterEnterNode(n ir.Node) {
 	switch n := n.(type) {
@@ -50,6 +130,8 @@ func (r *rootChecker) AfterEnterNode(n ir.Node) {
 		r.handleClass(n)
 	case *ir.InterfaceStmt:
 		r.handleInterface(n)
+	case *ir.TraitStmt:
+		r.handleTrait(n)
 	case *ir.ClassConstListStmt:
 		r.handleClassConstList(n)
 	case *ir.PropertyListStmt:
@@ -75,6 +157,8 @@ func (r *rootChecker) handleFunction(n *ir.FunctionStmt) {
 		return
 	}
 
+	fun.DocTags = docTags(n.PhpDoc)
+
 	r.CurFile.AddFunc(fun)
 	GlobalCtx.Namespaces.AddFunctionToNamespace(r.Ctx.ClassParseState().Namespace, fun)
 }
@@ -85,10 +169,13 @@ func (r *rootChecker) handlePropertyList(n *ir.PropertyListStmt) bool {
 		return true
 	}
 
+	addTypeDeps(curClass, typeHintClassNames(r.Ctx.ClassParseState(), n.Type))
+
 	for _, prop := range n.Properties {
 		prop := prop.(*ir.PropertyStmt)
 
 		curClass.Fields.Add(symbols.NewField(prop.Variable.Name, curClass))
+		addTypeDeps(curClass, phpDocClassNames(r.Ctx.ClassParseState(), prop.PhpDoc))
 	}
 	return false
 }
@@ -127,11 +214,65 @@ func (r *rootChecker) handleInterface(n *ir.InterfaceStmt) {
 	r.CurFile.AddClass(iface)
 	GlobalCtx.Namespaces.AddClassToNamespace(r.Ctx.ClassParseState().Namespace, iface)
 
+	if n.Extends != nil {
+		for _, name := range n.Extends.InterfaceNames {
+			parentName, ok := solver.GetClassName(r.Ctx.ClassParseState(), name)
+			if !ok {
+				continue
+			}
+
+			parent, ok := GlobalCtx.Classes.Get(parentName)
+			if !ok {
+				continue
+			}
+
+			iface.AddExtends(parent)
+			iface.AddDependency(parent, symbols.DepExtends)
+		}
+	}
+
 	for _, stmt := range n.Stmts {
 		r.handleClassInterfaceMethodsConstants(iface, stmt)
 	}
 }
 
+func (r *rootChecker) handleTrait(n *ir.TraitStmt) {
+	traitName, ok := solver.GetClassName(r.Ctx.ClassParseState(), &ir.Name{
+		Value: n.TraitName.Value,
+	})
+	if !ok {
+		return
+	}
+
+	trait, ok := GlobalCtx.Classes.Get(traitName)
+	if !ok {
+		return
+	}
+
+	r.CurFile.AddClass(trait)
+	GlobalCtx.Namespaces.AddClassToNamespace(r.Ctx.ClassParseState().Namespace, trait)
+
+	r.handleUsedTraits(trait)
+
+	for _, stmt := range n.Stmts {
+		r.handleClassInterfaceMethodsConstants(trait, stmt)
+	}
+}
+
+// handleUsedTraits resolves the traits from the `use` statements
+// of the class collected during indexing.
+func (r *rootChecker) handleUsedTraits(class *symbols.Class) {
+	for _, traitName := range class.UsedTraitNames {
+		trait, ok := GlobalCtx.Classes.Get(traitName)
+		if !ok {
+			continue
+		}
+
+		class.AddUses(trait)
+		class.AddDependency(trait, symbols.DepTrait)
+	}
+}
+
 func (r *rootChecker) handleClass(n *ir.ClassStmt) {
 	className, ok := solver.GetClassName(r.Ctx.ClassParseState(), &ir.Name{
 		Value: n.ClassName.Value,
@@ -162,8 +303,7 @@ func (r *rootChecker) handleClass(n *ir.ClassStmt) {
 			}
 
 			class.AddImplements(iface)
-			class.AddDeps(iface)
-			iface.AddDepsBy(class)
+			class.AddDependency(iface, symbols.DepImplements)
 		}
 	}
 
@@ -172,15 +312,18 @@ func (r *rootChecker) handleClass(n *ir.ClassStmt) {
 			Value: n.Extends.ClassName.Value,
 		})
 		if ok {
+			class.ParentName = className
+
 			extend, ok := GlobalCtx.Classes.Get(className)
 			if ok {
 				class.AddExtends(extend)
-				class.AddDeps(extend)
-				extend.AddDepsBy(class)
+				class.AddDependency(extend, symbols.DepExtends)
 			}
 		}
 	}
 
+	r.handleUsedTraits(class)
+
 	GlobalCtx.Namespaces.AddClassToNamespace(r.Ctx.ClassParseState().Namespace, class)
 
 	for _, stmt := range n.Stmts {
@@ -199,6 +342,10 @@ func (r *rootChecker) handleClassInterfaceMethodsConstants(class *symbols.Class,
 		}
 
 		class.AddMethod(method)
+		method.Signature = methodSignature(r.Ctx.ClassParseState(), class, n)
+		method.DocTags = docTags(n.PhpDoc)
+
+		addTypeDeps(class, signatureClassNames(r.Ctx.ClassParseState(), n.Params, n.ReturnType, n.PhpDoc))
 
 	case *ir.ClassConstListStmt:
 		for _, c := range n.Consts {
@@ -250,6 +397,20 @@ func (r *rootChecker) handleNamespace(n *ir.NamespaceStmt) {
 	GlobalCtx.Namespaces.AddFileToNamespace(nsName, r.CurFile)
 }
 
+// firstVisit returns true if the node is processed for the first time.
+func (r *rootChecker) firstVisit(n ir.Node) bool {
+	if r.visitedNodes == nil {
+		r.visitedNodes = map[ir.Node]struct{}{}
+	}
+
+	if _, ok := r.visitedNodes[n]; ok {
+		return false
+	}
+	r.visitedNodes[n] = struct{}{}
+
+	return true
+}
+
 func (r *rootChecker) getCurrentFunc() (*symbols.Function, bool) {
 	if r.Ctx.ClassParseState().CurrentFunction == "" {
 		return nil, false
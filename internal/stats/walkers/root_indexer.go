@@ -9,8 +9,10 @@ import (
 	"github.com/VKCOM/noverify/src/linter"
 	"github.com/VKCOM/noverify/src/meta"
 	"github.com/VKCOM/noverify/src/php/parser/freefloating"
+	"github.com/VKCOM/noverify/src/phpdoc"
 	"github.com/VKCOM/noverify/src/solver"
 
+	"github.com/i582/phpstats/internal/stats/clones"
 	"github.com/i582/phpstats/internal/stats/filemeta"
 	"github.com/i582/phpstats/internal/stats/symbols"
 )
@@ -28,6 +30,10 @@ func (r *rootIndexer) BeforeEnterFile() {
 	curFile := symbols.NewFile(curFileName)
 
 	r.Meta.Files.Add(curFile)
+
+	if !r.inVendor() {
+		r.Meta.Tokens = clones.Tokenize(r.Ctx.FileContents())
+	}
 }
 
 // AfterLeaveFile describes the processing logic after leaving the file.
@@ -97,7 +103,68 @@ func (r *rootIndexer) handleFunction(n *ir.FunctionStmt) {
 	fn.CyclomaticComplexity = cc
 	fn.CountMagicNumbers = cmn
 	fn.FullyTyped = hasReturnTypeHint && hasParamsTypeHints
+	fn.Deprecated, fn.DeprecationMessage = parseDeprecation(n.PhpDocComment)
 	r.Meta.Funcs.Add(fn)
+
+	r.handleAnonymous(fn.Name, &ir.StmtList{
+		Stmts: n.Stmts,
+	})
+}
+
+// handleAnonymous indexes the closures, arrow functions and
+// anonymous classes declared in the body of the function.
+func (r *rootIndexer) handleAnonymous(owner symbols.FuncKey, body *ir.StmtList) {
+	curFile, ok := r.Meta.Files.Get(r.Ctx.Filename())
+	if !ok {
+		return
+	}
+
+	anonymous, classes := collectAnonymous(owner, body)
+
+	for _, sym := range anonymous {
+		var cc, cmn int64
+		if sym.Body != nil {
+			cc = r.calculateCyclomaticComplexity(sym.Body)
+			cmn = r.calculateCountMagicNumbers(sym.Body)
+		}
+
+		fn := symbols.NewFunction(sym.Key, r.getElementPos(sym.Node))
+		fn.CyclomaticComplexity = cc
+		fn.CountMagicNumbers = cmn
+		fn.FullyTyped = r.hasReturnTypeHint(sym.ReturnType) && r.hasParamsTypeHints(sym.Params)
+		fn.AnonymousKind = sym.Kind
+		fn.OwnerName = sym.Owner
+		r.Meta.Funcs.Add(fn)
+	}
+
+	for _, anonymousClass := range classes {
+		class := symbols.NewClass(anonymousClass.Name, curFile)
+		class.IsVendor = r.inVendor()
+		class.IsAnonymous = true
+		class.OwnerName = anonymousClass.Owner
+		r.Meta.Classes.Add(class)
+	}
+}
+
+// parseDeprecation returns true and the message of the @deprecated
+// tag if the doc comment contains the tag.
+func parseDeprecation(doc string) (bool, string) {
+	if !strings.Contains(doc, "@deprecated") {
+		return false, ""
+	}
+
+	for _, part := range phpdoc.Parse(phpdoc.NewTypeParser(), doc) {
+		if part.Name() != "deprecated" {
+			continue
+		}
+
+		if part, ok := part.(*phpdoc.RawCommentPart); ok {
+			return true, part.ParamsText
+		}
+		return true, ""
+	}
+
+	return false, ""
 }
 
 func (r *rootIndexer) hasParamsTypeHints(params []ir.Node) bool {
@@ -138,6 +205,7 @@ func (r *rootIndexer) handleTrait(n *ir.TraitStmt) {
 	class := symbols.NewClass(className, curFile)
 	class.IsVendor = r.inVendor()
 	class.IsTrait = true
+	class.Deprecated, class.DeprecationMessage = parseDeprecation(n.PhpDocComment)
 
 	r.Meta.Classes.Add(class)
 
@@ -163,6 +231,7 @@ func (r *rootIndexer) handleInterface(n *ir.InterfaceStmt) {
 
 	iface := symbols.NewInterface(ifaceName, curFile)
 	iface.IsVendor = r.inVendor()
+	iface.Deprecated, iface.DeprecationMessage = parseDeprecation(n.PhpDocComment)
 	r.Meta.Classes.Add(iface)
 
 	for _, n := range n.Stmts {
@@ -195,6 +264,7 @@ func (r *rootIndexer) handleClass(n *ir.ClassStmt) {
 	class := symbols.NewClass(className, curFile)
 	class.IsAbstract = isAbstract
 	class.IsVendor = r.inVendor()
+	class.Deprecated, class.DeprecationMessage = parseDeprecation(n.PhpDocComment)
 
 	r.Meta.Classes.Add(class)
 
@@ -205,6 +275,9 @@ func (r *rootIndexer) handleClass(n *ir.ClassStmt) {
 
 func (r *rootIndexer) handleClassInterfaceMethodsConstants(class *symbols.Class, n ir.Node) {
 	switch n := n.(type) {
+	case *ir.TraitUseStmt:
+		r.handleTraitUse(class, n)
+
 	case *ir.ClassMethodStmt:
 		methodName := n.MethodName.Value
 		pos := r.getElementPos(n)
@@ -223,15 +296,96 @@ func (r *rootIndexer) handleClassInterfaceMethodsConstants(class *symbols.Class,
 		fn.CyclomaticComplexity = cc
 		fn.CountMagicNumbers = cmn
 		fn.FullyTyped = hasReturnTypeHint && hasParamsTypeHints
+		fn.Deprecated, fn.DeprecationMessage = parseDeprecation(n.PhpDocComment)
 		r.Meta.Funcs.Add(fn)
 
+		if n, ok := n.Stmt.(*ir.StmtList); ok {
+			r.handleAnonymous(fn.Name, n)
+		}
+
 	case *ir.ClassConstListStmt:
 		for _, c := range n.Consts {
-			r.Meta.Constants.Add(symbols.NewConstant(c.(*ir.ConstantStmt).ConstantName.Value, class))
+			c := c.(*ir.ConstantStmt)
+
+			constant := symbols.NewConstant(c.ConstantName.Value, class)
+			constant.Deprecated, constant.DeprecationMessage = parseDeprecation(c.PhpDocComment)
+			r.Meta.Constants.Add(constant)
 		}
 	}
 }
 
+func (r *rootIndexer) handleTraitUse(class *symbols.Class, n *ir.TraitUseStmt) {
+	for _, trait := range n.Traits {
+		traitName, ok := solver.GetClassName(r.Ctx.ClassParseState(), trait)
+		if !ok {
+			continue
+		}
+
+		class.UsedTraitNames = append(class.UsedTraitNames, traitName)
+	}
+
+	adaptationList, ok := n.TraitAdaptationList.(*ir.TraitAdaptationListStmt)
+	if !ok {
+		return
+	}
+
+	for _, adaptation := range adaptationList.Adaptations {
+		switch adaptation := adaptation.(type) {
+		case *ir.TraitUsePrecedenceStmt:
+			traitAdaptation, ok := r.traitAdaptationByRef(adaptation.Ref)
+			if !ok {
+				continue
+			}
+
+			for _, insteadOf := range adaptation.Insteadof {
+				traitName, ok := solver.GetClassName(r.Ctx.ClassParseState(), insteadOf)
+				if !ok {
+					continue
+				}
+
+				traitAdaptation.InsteadOf = append(traitAdaptation.InsteadOf, traitName)
+			}
+
+			class.TraitAdaptations = append(class.TraitAdaptations, traitAdaptation)
+
+		case *ir.TraitUseAliasStmt:
+			traitAdaptation, ok := r.traitAdaptationByRef(adaptation.Ref)
+			if !ok {
+				continue
+			}
+
+			if adaptation.Alias != nil {
+				traitAdaptation.Alias = adaptation.Alias.Value
+			}
+			if modifier, ok := adaptation.Modifier.(*ir.Identifier); ok && modifier != nil {
+				traitAdaptation.Modifier = modifier.Value
+			}
+
+			class.TraitAdaptations = append(class.TraitAdaptations, traitAdaptation)
+		}
+	}
+}
+
+func (r *rootIndexer) traitAdaptationByRef(n ir.Node) (*symbols.TraitAdaptation, bool) {
+	ref, ok := n.(*ir.TraitMethodRefStmt)
+	if !ok || ref == nil || ref.Method == nil {
+		return nil, false
+	}
+
+	adaptation := &symbols.TraitAdaptation{
+		Method: ref.Method.Value,
+	}
+
+	if trait, ok := ref.Trait.(*ir.Name); ok && trait != nil {
+		traitName, ok := solver.GetClassName(r.Ctx.ClassParseState(), trait)
+		if ok {
+			adaptation.Trait = traitName
+		}
+	}
+
+	return adaptation, true
+}
+
 func (r *rootIndexer) calculateCyclomaticComplexity(stmts *ir.StmtList) int64 {
 	var complexity int64
 	irutil.Inspect(stmts, func(n ir.Node) bool {
@@ -241,6 +395,9 @@ func (r *rootIndexer) calculateCyclomaticComplexity(stmts *ir.StmtList) int64 {
 			*ir.GotoStmt, *ir.CatchStmt, *ir.TernaryExpr, *ir.CoalesceExpr,
 			*ir.BooleanOrExpr, *ir.BooleanAndExpr:
 			complexity++
+		case *ir.ClosureExpr, *ir.ArrowFunctionExpr, *ir.AnonClassExpr:
+			// Anonymous functions and classes have their own complexity.
+			return false
 		}
 		return true
 	})
@@ -269,6 +426,8 @@ func (r *rootIndexer) calculateCountMagicNumbers(stmts *ir.StmtList) int64 {
 			return false
 		case *ir.ArrayDimFetchExpr:
 			return false
+		case *ir.ClosureExpr, *ir.ArrowFunctionExpr, *ir.AnonClassExpr:
+			return false
 		}
 		return true
 	})
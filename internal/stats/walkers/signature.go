@@ -0,0 +1,141 @@
+package walkers
+
+import (
+	"strings"
+
+	"github.com/VKCOM/noverify/src/ir"
+	"github.com/VKCOM/noverify/src/meta"
+	"github.com/VKCOM/noverify/src/phpdoc"
+	"github.com/VKCOM/noverify/src/solver"
+
+	"github.com/i582/phpstats/internal/stats/symbols"
+)
+
+// builtinTypes are the type hints that are not class names.
+var builtinTypes = map[string]struct{}{
+	"int": {}, "float": {}, "string": {}, "bool": {}, "array": {}, "callable": {},
+	"iterable": {}, "object": {}, "mixed": {}, "void": {}, "null": {}, "false": {},
+	"static": {},
+}
+
+// methodSignature returns the signature of the method declared in the class.
+func methodSignature(st *meta.ClassParseState, class *symbols.Class, n *ir.ClassMethodStmt) *symbols.Signature {
+	sign := &symbols.Signature{
+		ReturnType: typeHintString(st, class, n.ReturnType),
+		Visibility: "public",
+	}
+
+	for _, modifier := range n.Modifiers {
+		switch value := strings.ToLower(modifier.Value); value {
+		case "public", "protected", "private":
+			sign.Visibility = value
+		case "abstract":
+			sign.IsAbstract = true
+		case "static":
+			sign.IsStatic = true
+		}
+	}
+
+	if class.IsInterface {
+		sign.IsAbstract = true
+	}
+
+	for _, param := range n.Params {
+		param, ok := param.(*ir.Parameter)
+		if !ok {
+			continue
+		}
+
+		sign.Params = append(sign.Params, symbols.Param{
+			Name:     param.Variable.Name,
+			Type:     typeHintString(st, class, param.VariableType),
+			Optional: param.DefaultValue != nil,
+			Variadic: param.Variadic,
+		})
+	}
+
+	if stmts, ok := n.Stmt.(*ir.StmtList); ok {
+		switch len(stmts.Stmts) {
+		case 0:
+			sign.IsTrivial = true
+		case 1:
+			_, sign.IsTrivial = stmts.Stmts[0].(*ir.ThrowStmt)
+		}
+	}
+
+	return sign
+}
+
+// docTags returns the names of the PHPDoc tags with the @ prefix.
+func docTags(doc []phpdoc.CommentPart) []string {
+	var tags []string
+	for _, part := range doc {
+		tags = append(tags, "@"+part.Name())
+	}
+	return tags
+}
+
+// typeHintString returns the type hint with the full class names,
+// self is replaced with the name of the class.
+func typeHintString(st *meta.ClassParseState, class *symbols.Class, n ir.Node) string {
+	switch n := n.(type) {
+	case *ir.Nullable:
+		typ := typeHintString(st, class, n.Expr)
+		if typ == "" {
+			return ""
+		}
+		return "?" + typ
+	case *ir.Identifier:
+		return typeHintName(st, class, &ir.Name{Value: n.Value})
+	case *ir.Name:
+		return typeHintName(st, class, n)
+	}
+
+	return ""
+}
+
+func typeHintName(st *meta.ClassParseState, class *symbols.Class, n *ir.Name) string {
+	lower := strings.ToLower(n.Value)
+	if _, ok := builtinTypes[lower]; ok {
+		return lower
+	}
+	if lower == "self" {
+		return class.Name
+	}
+
+	className, ok := solver.GetClassName(st, n)
+	if !ok {
+		return n.Value
+	}
+	return className
+}
+
+// handleParentCall marks the current method as calling
+// the parent implementation, if the call is parent::method().
+func (b *blockChecker) handleParentCall(n *ir.StaticCallExpr) {
+	var className string
+	switch class := n.Class.(type) {
+	case *ir.Name:
+		className = class.Value
+	case *ir.Identifier:
+		className = class.Value
+	}
+
+	if !strings.EqualFold(className, "parent") {
+		return
+	}
+
+	method, ok := n.Call.(*ir.Identifier)
+	if !ok {
+		return
+	}
+
+	curFunc, ok := b.currentFunc(n)
+	if !ok || !curFunc.IsMethod() {
+		return
+	}
+
+	if strings.EqualFold(curFunc.Name.Name, method.Value) {
+		curFunc.CallsParent = true
+	}
+}
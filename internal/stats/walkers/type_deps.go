@@ -0,0 +1,105 @@
+package walkers
+
+import (
+	"github.com/VKCOM/noverify/src/ir"
+	"github.com/VKCOM/noverify/src/meta"
+	"github.com/VKCOM/noverify/src/phpdoc"
+	"github.com/VKCOM/noverify/src/solver"
+
+	"github.com/i582/phpstats/internal/stats/symbols"
+)
+
+// addTypeDeps adds the classes from the passed class names
+// to the dependencies of the class.
+func addTypeDeps(class *symbols.Class, classNames []string) {
+	for _, className := range classNames {
+		dep, ok := GlobalCtx.Classes.Get(className)
+		if !ok {
+			continue
+		}
+
+		class.AddDependency(dep, symbols.DepTypeHint)
+	}
+}
+
+// signatureClassNames returns the names of the classes used in the type hints
+// of the parameters, the return type hint and the PHPDoc of the function.
+func signatureClassNames(st *meta.ClassParseState, params []ir.Node, returnType ir.Node, doc []phpdoc.CommentPart) []string {
+	var names []string
+
+	for _, param := range params {
+		param, ok := param.(*ir.Parameter)
+		if !ok {
+			continue
+		}
+
+		names = append(names, typeHintClassNames(st, param.VariableType)...)
+	}
+
+	names = append(names, typeHintClassNames(st, returnType)...)
+	names = append(names, phpDocClassNames(st, doc)...)
+
+	return names
+}
+
+// typeHintClassNames returns the names of the classes used in the type hint.
+func typeHintClassNames(st *meta.ClassParseState, n ir.Node) []string {
+	switch n := n.(type) {
+	case *ir.Nullable:
+		return typeHintClassNames(st, n.Expr)
+	case *ir.Name:
+		className, ok := solver.GetClassName(st, n)
+		if !ok {
+			return nil
+		}
+		return []string{className}
+	}
+
+	return nil
+}
+
+// phpDocClassNames returns the names of the classes used
+// in the @param, @return and @var tags of the PHPDoc.
+func phpDocClassNames(st *meta.ClassParseState, doc []phpdoc.CommentPart) []string {
+	var names []string
+
+	for _, part := range doc {
+		var typ phpdoc.Type
+
+		switch part := part.(type) {
+		case *phpdoc.TypeCommentPart:
+			if part.Name() != "return" {
+				continue
+			}
+			typ = part.Type
+		case *phpdoc.TypeVarCommentPart:
+			if part.Name() != "param" && part.Name() != "var" {
+				continue
+			}
+			typ = part.Type
+		default:
+			continue
+		}
+
+		names = append(names, phpDocTypeClassNames(st, typ.Expr)...)
+	}
+
+	return names
+}
+
+func phpDocTypeClassNames(st *meta.ClassParseState, expr phpdoc.TypeExpr) []string {
+	if expr.Kind == phpdoc.ExprName {
+		className, ok := solver.GetClassName(st, &ir.Name{Value: expr.Value})
+		if !ok {
+			return nil
+		}
+		return []string{className}
+	}
+
+	var names []string
+	for _, arg := range expr.Args {
+		names = append(names, phpDocTypeClassNames(st, arg)...)
+	}
+
+	return names
+}
@@ -0,0 +1,43 @@
+package walkers
+
+import (
+	"github.com/VKCOM/noverify/src/ir"
+	"github.com/VKCOM/noverify/src/ir/irutil"
+	"github.com/VKCOM/noverify/src/meta"
+
+	"github.com/i582/phpstats/internal/stats/symbols"
+)
+
+// handleUnresolved records the call or the object creation
+// whose target could not be determined.
+func (b *blockChecker) handleUnresolved(n ir.Node, kind symbols.UnresolvedKind, expr ir.Node) {
+	curFunc, ok := b.currentFunc(n)
+	if !ok {
+		return
+	}
+
+	curFunc.Unresolved.Add(&symbols.UnresolvedCall{
+		Kind: kind,
+		Func: curFunc,
+		Site: b.location(n),
+		Expr: irutil.FmtNode(expr),
+	})
+}
+
+// location returns the place of the node in the current file.
+func (b *blockChecker) location(n ir.Node) symbols.Location {
+	return symbols.Location{
+		File: GlobalCtx.RelativePath(b.Root.Ctx.Filename()),
+		Line: int32(ir.GetPosition(n).StartLine),
+	}
+}
+
+// hasClassType checks if at least one of the types is a class.
+func hasClassType(tp meta.TypesMap) bool {
+	return tp.Find(meta.IsClassType)
+}
+
+// isCallUserFunc checks if the function calls its first argument.
+func isCallUserFunc(name string) bool {
+	return name == `\call_user_func` || name == `\call_user_func_array`
+}
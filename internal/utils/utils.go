@@ -88,7 +88,6 @@ func IsEmbeddedConstant(name string) bool {
 }
 
 func IsSuperGlobal(name string) bool {
-	name = strings.ToLower(name)
 	return name == "GLOBALS" ||
 		name == "_SERVER" ||
 		name == "_GET" ||
@@ -0,0 +1,61 @@
+package tests
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/i582/phpstats/internal/stats/symbols"
+)
+
+func TestAnonymous(t *testing.T) {
+	dispatch := getMethod(t, `\Anonymous\Dispatcher`, "dispatch")
+
+	want := []string{
+		`\Anonymous\Dispatcher::dispatch{closure:25}`,
+		`\Anonymous\Dispatcher::dispatch{closure:33#2}`,
+		`\Anonymous\Dispatcher::dispatch{closure:33}`,
+		`\Anonymous\Dispatcher::dispatch{fn:31}`,
+	}
+	if got := funcNames(dispatch.Anonymous); !reflect.DeepEqual(got, want) {
+		t.Errorf("anonymous functions of Dispatcher::dispatch: got %v, want %v", got, want)
+	}
+
+	closure := getFunc(t, `\Anonymous\Dispatcher::dispatch{closure:25}`)
+	if closure.AnonymousKind != symbols.AnonymousClosure || closure.Class == nil || closure.Class.Name != `\Anonymous\Dispatcher` {
+		t.Errorf("closure must belong to Dispatcher, got kind %q", closure.AnonymousKind)
+	}
+	if got := funcNames(closure.Called); !reflect.DeepEqual(got, []string{`\Anonymous\Mailer::send`}) {
+		t.Errorf("closure calls %v", got)
+	}
+
+	arrow := getFunc(t, `\Anonymous\Dispatcher::dispatch{fn:31}`)
+	if arrow.AnonymousKind != symbols.AnonymousArrowFunction {
+		t.Errorf("expected arrow function, got %q", arrow.AnonymousKind)
+	}
+
+	class := getClass(t, `\Anonymous\Dispatcher::handler{class:39}`)
+	if !class.IsAnonymous || class.Owner == nil || class.Owner.Name.String() != `\Anonymous\Dispatcher::handler` {
+		t.Errorf("anonymous class must be owned by Dispatcher::handler")
+	}
+
+	handle := getMethod(t, class.Name, "handle")
+	if handle.AnonymousKind != symbols.AnonymousClassMethod {
+		t.Errorf("expected anonymous class method, got %q", handle.AnonymousKind)
+	}
+
+	nested := getFunc(t, `\Anonymous\Dispatcher::handler{class:39}::handle{closure:47}`)
+	if _, ok := handle.Anonymous.Get(nested.Name); !ok {
+		t.Errorf("nested closure must be linked with the anonymous class method")
+	}
+	if got := funcNames(nested.Called); !reflect.DeepEqual(got, []string{`\Anonymous\Mailer::send`}) {
+		t.Errorf("nested closure calls %v", got)
+	}
+
+	run := getFunc(t, `\Anonymous\runAnonymous{closure:58}`)
+	if run.Class != nil {
+		t.Errorf("closure in a function must not belong to a class")
+	}
+	if got := funcNames(run.Called); !reflect.DeepEqual(got, []string{`\Anonymous\Dispatcher::dispatch`}) {
+		t.Errorf("closure in runAnonymous calls %v", got)
+	}
+}
@@ -0,0 +1,57 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/i582/phpstats/internal/stats/symbols"
+)
+
+func TestCallables(t *testing.T) {
+	formatter := `\Callables\Formatter`
+	upper := getMethod(t, formatter, "upper")
+
+	tests := []struct {
+		caller *symbols.Function
+		callee *symbols.Function
+		count  int64
+		lines  []int32
+	}{
+		// A string 'Class::method' and an array [Class::class, 'method'].
+		{caller: getFunc(t, `\Callables\formatAll`), callee: upper, count: 2, lines: []int32{25, 26}},
+		// A string with the name of a function passed to call_user_func.
+		{caller: getFunc(t, `\Callables\formatAll`), callee: getFunc(t, `\Callables\trimAll`), count: 1, lines: []int32{27}},
+		// An array [$this, 'method'].
+		{caller: getMethod(t, formatter, "sortAll"), callee: getMethod(t, formatter, "compare"), count: 1, lines: []int32{15}},
+		// A string with the name of a built-in function.
+		{caller: getFunc(t, `\Callables\trimAll`), callee: getFunc(t, `\trim`), count: 1, lines: []int32{21}},
+	}
+
+	for _, tt := range tests {
+		if _, ok := tt.caller.Called.Get(tt.callee.Name); !ok {
+			t.Errorf("%s must call %s", tt.caller.Name, tt.callee.Name)
+		}
+
+		dep, ok := tt.caller.CallEdges.Get(tt.callee.Name.String())
+		if !ok || dep.Counts[symbols.DepIndirectCall] != tt.count {
+			t.Errorf("%s -> %s: expected %d indirect calls, got %v", tt.caller.Name, tt.callee.Name, tt.count, dep)
+			continue
+		}
+
+		sites := dep.SortedSites()
+		if len(sites) != len(tt.lines) {
+			t.Errorf("%s -> %s: got sites %v, want lines %v", tt.caller.Name, tt.callee.Name, sites, tt.lines)
+			continue
+		}
+		for i, site := range sites {
+			if site.Line != tt.lines[i] {
+				t.Errorf("%s -> %s: got sites %v, want lines %v", tt.caller.Name, tt.callee.Name, sites, tt.lines)
+				break
+			}
+		}
+	}
+
+	unknown := getFunc(t, `\Callables\unknownCallback`)
+	if unknown.Called.Len() != 1 || unknown.Unresolved.Len() != 1 {
+		t.Errorf("the callback from a variable must stay unresolved, calls %v", funcNames(unknown.Called))
+	}
+}
@@ -0,0 +1,41 @@
+package tests
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/i582/phpstats/internal/stats/symbols"
+)
+
+func TestCallSites(t *testing.T) {
+	main := getFunc(t, `\Exceptions\exceptionsMain`)
+	repository := `\Exceptions\Repository`
+
+	tests := []struct {
+		caller *symbols.Function
+		callee *symbols.Function
+		want   []symbols.Location
+	}{
+		{
+			caller: main,
+			callee: getMethod(t, repository, "get"),
+			want:   []symbols.Location{{File: "Exceptions/Exceptions.php", Line: 48}},
+		},
+		{
+			caller: main,
+			callee: getMethod(t, repository, "store"),
+			want:   []symbols.Location{{File: "Exceptions/Exceptions.php", Line: 49}},
+		},
+		{
+			caller: getMethod(t, repository, "get"),
+			callee: getMethod(t, `\Exceptions\Storage`, "find"),
+			want:   []symbols.Location{{File: "Exceptions/Exceptions.php", Line: 35}},
+		},
+	}
+
+	for _, tt := range tests {
+		if got := tt.caller.CallSites(tt.callee); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s -> %s: got %v, want %v", tt.caller.Name, tt.callee.Name, got, tt.want)
+		}
+	}
+}
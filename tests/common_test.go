@@ -0,0 +1,39 @@
+package tests
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/i582/phpstats/internal/relations"
+	"github.com/i582/phpstats/internal/stats/symbols"
+)
+
+func TestCommonRelation(t *testing.T) {
+	get := getMethod(t, `\Exceptions\Repository`, "get")
+	save := getMethod(t, `\Exceptions\Storage`, "save")
+
+	rel := relations.GetCommonRelation([]*symbols.Function{get, save}, nil, symbols.DirectCalls)
+
+	names := func(funcs []*symbols.Function) []string {
+		var res []string
+		for _, fn := range funcs {
+			res = append(res, fn.Name.String())
+		}
+		return res
+	}
+
+	if got := names(rel.CommonCallees); !reflect.DeepEqual(got, []string{`\Exceptions\Storage::find`}) {
+		t.Errorf("common callees: got %v", got)
+	}
+	if len(rel.CommonCallers) != 0 {
+		t.Errorf("expected no direct common callers, got %v", names(rel.CommonCallers))
+	}
+
+	if len(rel.LowestCommonCallers) != 1 {
+		t.Fatalf("expected 1 lowest common caller, got %d", len(rel.LowestCommonCallers))
+	}
+	lowest := rel.LowestCommonCallers[0]
+	if lowest.Func.Name.String() != `\Exceptions\exceptionsMain` || !reflect.DeepEqual(lowest.Distances, []int{1, 2}) {
+		t.Errorf("expected exceptionsMain at distances [1 2], got %s at %v", lowest.Func.Name, lowest.Distances)
+	}
+}
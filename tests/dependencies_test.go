@@ -0,0 +1,42 @@
+package tests
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/i582/phpstats/internal/stats/symbols"
+)
+
+func TestTypeHintDependencies(t *testing.T) {
+	service := getClass(t, `\Dependencies\OrderService`)
+
+	// Each dependency comes from a different place: a typed property and a constructor
+	// parameter, a PHPDoc @var and a constructor parameter, @param, @return and the
+	// return type, instanceof and catch.
+	want := map[string]int64{
+		`\Dependencies\LoggerInterface`:     2,
+		`\Dependencies\CacheInterface`:      2,
+		`\Dependencies\Clock`:               1,
+		`\Dependencies\Entity`:              2,
+		`\Dependencies\Event`:               1,
+		`\Dependencies\ValidationException`: 1,
+	}
+
+	got := make(map[string]int64)
+	for _, name := range service.DepEdges.Names() {
+		dep, _ := service.DepEdges.Get(name)
+		got[name] = dep.Counts[symbols.DepTypeHint]
+		if dep.Count() != dep.Counts[symbols.DepTypeHint] {
+			t.Errorf("dependency on %s must be a type hint only, got %s", name, dep)
+		}
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("type hint dependencies: got %v, want %v", got, want)
+	}
+
+	logger := getClass(t, `\Dependencies\LoggerInterface`)
+	if _, ok := logger.DepsBy.Get(service.Name); !ok {
+		t.Errorf("OrderService must be a dependent of LoggerInterface")
+	}
+}
@@ -0,0 +1,75 @@
+package tests
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/i582/phpstats/internal/stats/deprecations"
+	"github.com/i582/phpstats/internal/stats/walkers"
+)
+
+func TestDeprecations(t *testing.T) {
+	found := make(map[string]*deprecations.Deprecation)
+	for _, d := range walkers.GlobalCtx.Deprecations(false) {
+		found[d.Name] = d
+	}
+
+	type user struct {
+		name     string
+		distance int
+		via      string
+	}
+
+	tests := []struct {
+		name  string
+		kind  string
+		users []user
+	}{
+		{
+			name: `\Deprecations\oldFormat`,
+			kind: deprecations.KindFunction,
+			users: []user{
+				{name: `\Deprecations\LegacyMailer::send`, distance: 1, via: `\Deprecations\oldFormat`},
+				{name: `\Deprecations\Notifier::notify`, distance: 2, via: `\Deprecations\LegacyMailer::send`},
+				{name: `\Deprecations\notifyAll`, distance: 3, via: `\Deprecations\Notifier::notify`},
+			},
+		},
+		{
+			name: `\Deprecations\Mailer::sendLater`,
+			kind: deprecations.KindMethod,
+			users: []user{
+				{name: `\Deprecations\Notifier::notify`, distance: 1, via: `\Deprecations\Mailer::sendLater`},
+				{name: `\Deprecations\notifyAll`, distance: 2, via: `\Deprecations\Notifier::notify`},
+			},
+		},
+		{
+			// notifyAll creates the object itself, so it is a direct user.
+			name: `\Deprecations\LegacyMailer`,
+			kind: deprecations.KindClass,
+			users: []user{
+				{name: `\Deprecations\Notifier`, distance: 1, via: `\Deprecations\LegacyMailer`},
+				{name: `\Deprecations\Notifier::notify`, distance: 1, via: `\Deprecations\LegacyMailer`},
+				{name: `\Deprecations\notifyAll`, distance: 1, via: `\Deprecations\LegacyMailer`},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		d, ok := found[tt.name]
+		if !ok {
+			t.Errorf("deprecation %s not found", tt.name)
+			continue
+		}
+		if d.Kind != tt.kind {
+			t.Errorf("%s: got kind %s, want %s", tt.name, d.Kind, tt.kind)
+		}
+
+		var got []user
+		for _, u := range d.Users {
+			got = append(got, user{name: u.Name(), distance: u.Distance, via: u.Via})
+		}
+		if !reflect.DeepEqual(got, tt.users) {
+			t.Errorf("%s users:\ngot  %+v\nwant %+v", tt.name, got, tt.users)
+		}
+	}
+}
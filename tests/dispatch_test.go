@@ -0,0 +1,43 @@
+package tests
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/i582/phpstats/internal/stats/symbols"
+)
+
+func TestDispatch(t *testing.T) {
+	total := getFunc(t, `\Dispatch\totalArea`)
+
+	tests := []struct {
+		mode symbols.CallGraphMode
+		want []string
+	}{
+		{mode: symbols.DirectCalls, want: []string{`\Dispatch\Shape::area`}},
+		{
+			mode: symbols.CHACalls,
+			want: []string{`\Dispatch\Circle::area`, `\Dispatch\Shape::area`, `\Dispatch\Square::area`, `\Dispatch\Triangle::area`},
+		},
+		// Triangle is never created with new.
+		{
+			mode: symbols.RTACalls,
+			want: []string{`\Dispatch\Circle::area`, `\Dispatch\Shape::area`, `\Dispatch\Square::area`},
+		},
+	}
+
+	for _, tt := range tests {
+		if got := funcNames(total.Callees(tt.mode)); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s callees: got %v, want %v", tt.mode, got, tt.want)
+		}
+	}
+
+	for _, name := range []string{`\Dispatch\Square`, `\Dispatch\Circle`} {
+		if !getClass(t, name).IsInstantiated {
+			t.Errorf("%s must be instantiated", name)
+		}
+	}
+	if getClass(t, `\Dispatch\Triangle`).IsInstantiated {
+		t.Errorf("Triangle must not be instantiated")
+	}
+}
@@ -0,0 +1,34 @@
+package tests
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/i582/phpstats/internal/stats/symbols"
+)
+
+func TestEffects(t *testing.T) {
+	tests := []struct {
+		fn     *symbols.Function
+		direct []string
+		all    []string
+	}{
+		{fn: getMethod(t, `\Effects\UserRepository`, "find"), direct: []string{}, all: []string{"db"}},
+		{fn: getFunc(t, `\Effects\logAccess`), direct: []string{}, all: []string{"filesystem", "time"}},
+		{fn: getFunc(t, `\Effects\renderUser`), direct: []string{}, all: []string{}},
+		{fn: getMethod(t, `\Effects\UserController`, "show"), direct: []string{"output"}, all: []string{"db", "filesystem", "output", "time"}},
+	}
+
+	for _, tt := range tests {
+		if got := tt.fn.DirectEffects.Sorted(); !reflect.DeepEqual(got, tt.direct) {
+			t.Errorf("%s direct effects: got %v, want %v", tt.fn.Name, got, tt.direct)
+		}
+		if got := tt.fn.Effects.Sorted(); !reflect.DeepEqual(got, tt.all) {
+			t.Errorf("%s effects: got %v, want %v", tt.fn.Name, got, tt.all)
+		}
+	}
+
+	if !getFunc(t, `\Effects\renderUser`).IsPure() {
+		t.Errorf("renderUser must be pure")
+	}
+}
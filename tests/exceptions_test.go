@@ -0,0 +1,37 @@
+package tests
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExceptions(t *testing.T) {
+	find := getMethod(t, `\Exceptions\Storage`, "find")
+	if got := classNames(find.ThrowsTransitive); !reflect.DeepEqual(got, []string{`\Exceptions\NotFoundException`}) {
+		t.Errorf("Storage::find throws %v", got)
+	}
+
+	get := getMethod(t, `\Exceptions\Repository`, "get")
+	if get.Catches.Len() != 1 {
+		t.Errorf("Repository::get must catch 1 exception, got %d", get.Catches.Len())
+	}
+	if get.ThrowsTransitive.Len() != 0 {
+		t.Errorf("Repository::get must not throw anything out, got %v", classNames(get.ThrowsTransitive))
+	}
+
+	store := getMethod(t, `\Exceptions\Repository`, "store")
+	want := []string{`\Exceptions\NotFoundException`, `\InvalidArgumentException`}
+	if got := classNames(store.ThrowsTransitive); !reflect.DeepEqual(got, want) {
+		t.Errorf("Repository::store throws %v, want %v", got, want)
+	}
+
+	main := getFunc(t, `\Exceptions\exceptionsMain`)
+	if got := classNames(main.ThrowsTransitive); !reflect.DeepEqual(got, want) {
+		t.Errorf("exceptionsMain throws %v, want %v", got, want)
+	}
+
+	notFound := getClass(t, `\Exceptions\NotFoundException`)
+	if _, ok := notFound.UncaughtIn.Get(main.Name); !ok {
+		t.Errorf("NotFoundException must be uncaught in exceptionsMain, got %v", funcNames(notFound.UncaughtIn))
+	}
+}
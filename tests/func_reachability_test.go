@@ -0,0 +1,45 @@
+package tests
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/i582/phpstats/internal/relations"
+)
+
+func TestFunctionReachability(t *testing.T) {
+	main := getFunc(t, `\Exceptions\exceptionsMain`)
+	find := getMethod(t, `\Exceptions\Storage`, "find")
+
+	rel := relations.GetReachabilityFunction(main, find, relations.ReachabilityOptions{MaxPaths: 10})
+	if !rel.Reachable || rel.LimitReached {
+		t.Fatalf("expected Storage::find to be reachable without the limit")
+	}
+
+	var got [][]string
+	for _, path := range rel.Paths {
+		var names []string
+		for _, fn := range path {
+			names = append(names, fn.Name.String())
+		}
+		got = append(got, names)
+	}
+
+	want := [][]string{
+		{`\Exceptions\exceptionsMain`, `\Exceptions\Repository::get`, `\Exceptions\Storage::find`},
+		{`\Exceptions\exceptionsMain`, `\Exceptions\Repository::store`, `\Exceptions\Storage::save`, `\Exceptions\Storage::find`},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("paths:\ngot  %v\nwant %v", got, want)
+	}
+
+	rel = relations.GetReachabilityFunction(main, find, relations.ReachabilityOptions{MaxPaths: 1})
+	if len(rel.Paths) != 1 || len(rel.Paths[0]) != 3 || !rel.LimitReached {
+		t.Errorf("expected only the shortest path with the limit reached")
+	}
+
+	rel = relations.GetReachabilityFunction(find, main, relations.ReachabilityOptions{MaxPaths: 10})
+	if rel.Reachable {
+		t.Errorf("exceptionsMain must be unreachable from Storage::find")
+	}
+}
@@ -0,0 +1,48 @@
+package tests
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/i582/phpstats/internal/stats/symbols"
+	"github.com/i582/phpstats/internal/stats/walkers"
+)
+
+func TestGlobals(t *testing.T) {
+	tests := []struct {
+		name    string
+		kind    symbols.GlobalKind
+		readers []string
+		writers []string
+	}{
+		{name: "$config", kind: symbols.GlobalVariable, readers: []string{`\Globals\isDebug`}, writers: []string{`\Globals\setupConfig`}},
+		{name: "$locale", kind: symbols.GlobalVariable, readers: []string{`\Globals\currentLocale`}, writers: []string{`\Globals\setupConfig`}},
+		{name: "$_SESSION", kind: symbols.SuperGlobal, readers: []string{`\Globals\currentUser`}, writers: []string{`\Globals\login`}},
+		{name: "$_GET", kind: symbols.SuperGlobal, readers: []string{`\Globals\currentUser`}, writers: []string{}},
+		{name: `\Globals\Registry::$count`, kind: symbols.StaticProperty, readers: []string{`\Globals\Registry::count`}, writers: []string{`\Globals\Registry::register`}},
+		{name: `\Globals\Registry::$items`, kind: symbols.StaticProperty, readers: []string{}, writers: []string{`\Globals\Registry::register`}},
+	}
+
+	for _, tt := range tests {
+		global, ok := walkers.GlobalCtx.Globals.Get(tt.name)
+		if !ok {
+			t.Errorf("global %s not found", tt.name)
+			continue
+		}
+
+		if global.Kind != tt.kind {
+			t.Errorf("%s: got kind %s, want %s", tt.name, global.Kind, tt.kind)
+		}
+		if got := funcNames(global.Readers); !reflect.DeepEqual(got, tt.readers) {
+			t.Errorf("%s readers: got %v, want %v", tt.name, got, tt.readers)
+		}
+		if got := funcNames(global.Writers); !reflect.DeepEqual(got, tt.writers) {
+			t.Errorf("%s writers: got %v, want %v", tt.name, got, tt.writers)
+		}
+	}
+
+	login := getFunc(t, `\Globals\login`)
+	if _, ok := login.WritesGlobals.Get("$_SESSION"); !ok {
+		t.Errorf("login must write $_SESSION")
+	}
+}
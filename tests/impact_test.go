@@ -0,0 +1,46 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/i582/phpstats/internal/stats/impact"
+	"github.com/i582/phpstats/internal/stats/symbols"
+	"github.com/i582/phpstats/internal/stats/walkers"
+)
+
+func TestImpact(t *testing.T) {
+	config := getClass(t, `\Impact\Core\Config`)
+	limit, ok := config.Constants.Get(symbols.NewConstantKey("LIMIT", config))
+	if !ok {
+		t.Fatal("constant Config::LIMIT not found")
+	}
+
+	res := impact.ForConstant(limit, walkers.GlobalCtx.ImpactOptions(0))
+
+	distances := make(map[string]int)
+	for _, node := range res.Affected {
+		distances[node.Name()] = node.Distance
+	}
+
+	want := map[string]int{
+		`\Impact\Core\Config::limit`:       1,
+		`\Impact\Core\loadConfig`:          2,
+		`\Impact\App\Service::run`:         3,
+		`\Impact\App\ServiceTest::testRun`: 4,
+	}
+	for name, distance := range want {
+		if got, ok := distances[name]; !ok || got != distance {
+			t.Errorf("%s: got distance %d, want %d", name, got, distance)
+		}
+	}
+
+	tests := res.Tests()
+	if len(tests) != 1 || tests[0].Name() != `\Impact\App\ServiceTest::testRun` {
+		t.Errorf("expected ServiceTest::testRun to be the only affected test")
+	}
+
+	// The test is hidden by default, so it does not count.
+	if res.MaxDistance() != 3 {
+		t.Errorf("expected max distance 3, got %d", res.MaxDistance())
+	}
+}
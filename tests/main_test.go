@@ -1,10 +1,80 @@
 package tests
 
 import (
+	"flag"
 	"fmt"
+	"os"
+	"sort"
 	"testing"
+
+	"github.com/i582/phpstats/internal/stats/symbols"
+	"github.com/i582/phpstats/internal/stats/walkers"
 )
 
-func TestDummy(t *testing.T) {
-	fmt.Println("not test yet")
+// TestMain collects the information about all files from testdata
+// once, the tests then check the collected symbols.
+func TestMain(m *testing.M) {
+	flag.Parse()
+
+	args := os.Args
+	os.Args = []string{args[0], "-disable-cache", "testdata"}
+	err := walkers.Collect()
+	os.Args = args
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "collect: %v\n", err)
+		os.Exit(1)
+	}
+
+	os.Exit(m.Run())
+}
+
+func getFunc(t *testing.T, name string) *symbols.Function {
+	t.Helper()
+
+	fn, ok := walkers.GlobalCtx.Functions.Get(symbols.NewFuncKey(name))
+	if !ok {
+		t.Fatalf("function %s not found", name)
+	}
+	return fn
+}
+
+func getMethod(t *testing.T, className, name string) *symbols.Function {
+	t.Helper()
+
+	fn, ok := walkers.GlobalCtx.Functions.Get(symbols.NewMethodKey(name, className))
+	if !ok {
+		t.Fatalf("method %s::%s not found", className, name)
+	}
+	return fn
+}
+
+func getClass(t *testing.T, name string) *symbols.Class {
+	t.Helper()
+
+	class, ok := walkers.GlobalCtx.Classes.Get(name)
+	if !ok {
+		t.Fatalf("class %s not found", name)
+	}
+	return class
+}
+
+// classNames returns the sorted names of the classes.
+func classNames(classes *symbols.Classes) []string {
+	names := make([]string, 0, classes.Len())
+	for _, class := range classes.Classes {
+		names = append(names, class.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// funcNames returns the sorted names of the functions.
+func funcNames(funcs *symbols.Functions) []string {
+	names := make([]string, 0, funcs.Len())
+	for _, fn := range funcs.Funcs {
+		names = append(names, fn.Name.String())
+	}
+	sort.Strings(names)
+	return names
 }
@@ -0,0 +1,62 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/i582/phpstats/internal/stats/reachability"
+	"github.com/i582/phpstats/internal/stats/symbols"
+	"github.com/i582/phpstats/internal/stats/walkers"
+)
+
+func TestReachability(t *testing.T) {
+	res, err := walkers.GlobalCtx.Reachability(symbols.DirectCalls)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entries := make(map[string]*reachability.EntryPoint)
+	for _, entry := range res.EntryPoints {
+		entries[entry.Name] = entry
+	}
+
+	script, ok := entries["Reachability/web/index.php"]
+	if !ok || script.Kind != reachability.KindScript {
+		t.Fatalf("expected the script entry point web/index.php")
+	}
+	for _, fn := range []*symbols.Function{
+		getMethod(t, `\Reachability\App`, "__construct"),
+		getMethod(t, `\Reachability\App`, "boot"),
+		getMethod(t, `\Reachability\App`, "run"),
+		getFunc(t, `\Reachability\render`),
+	} {
+		if !script.Reachable.HasFunc(fn) {
+			t.Errorf("%s must be reachable from the script", fn.Name)
+		}
+	}
+
+	cron := getFunc(t, `\Reachability\cronJob`)
+	tagged, ok := entries[cron.Name.String()]
+	if !ok || tagged.Kind != reachability.KindTagged {
+		t.Fatalf("expected the tagged entry point %s", cron.Name)
+	}
+	if !tagged.Reachable.HasFunc(getFunc(t, `\Reachability\cleanup`)) {
+		t.Errorf("cleanup must be reachable from the tagged function")
+	}
+
+	unreachable := res.Unreachable(walkers.GlobalCtx.Files, walkers.GlobalCtx.Functions, walkers.GlobalCtx.Classes)
+	for _, fn := range []*symbols.Function{
+		getFunc(t, `\Reachability\forgotten`),
+		getFunc(t, `\Reachability\helperOfForgotten`),
+		getMethod(t, `\Reachability\Legacy`, "handle"),
+	} {
+		if !unreachable.HasFunc(fn) {
+			t.Errorf("%s must be unreachable", fn.Name)
+		}
+	}
+	if !unreachable.HasClass(getClass(t, `\Reachability\Legacy`)) {
+		t.Errorf("Legacy must be unreachable")
+	}
+	if unreachable.HasFunc(getFunc(t, `\Reachability\render`)) {
+		t.Errorf("render must be reachable")
+	}
+}
@@ -0,0 +1,58 @@
+package tests
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/i582/phpstats/internal/shell/commands"
+)
+
+func TestHtmlReport(t *testing.T) {
+	dir := t.TempDir()
+
+	err := commands.WriteHtmlReport(dir, commands.HtmlReportOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	read := func(page string) string {
+		t.Helper()
+
+		data, err := ioutil.ReadFile(filepath.Join(dir, page))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(data)
+	}
+
+	index := read("index.html")
+	for _, want := range []string{
+		`<caption>Overview</caption>`,
+		`<a href="classes.html">Classes</a>`,
+		`<a href="namespaces.html">Namespaces</a>`,
+	} {
+		if !strings.Contains(index, want) {
+			t.Errorf("index.html must contain %s", want)
+		}
+	}
+
+	classes := read("classes.html")
+	if !strings.Contains(classes, `<a href="classes/Exceptions.Repository.html">\Exceptions\Repository</a>`) {
+		t.Errorf("classes.html must link to the page of Repository")
+	}
+
+	class := read("classes/Exceptions.Repository.html")
+	for _, want := range []string{
+		`<h1>\Exceptions\Repository</h1>`,
+		`<li>File: Exceptions/Exceptions.php</li>`,
+		`<a href="../namespaces/Exceptions.html">\Exceptions</a>`,
+		`<a href="../classes/Exceptions.Storage.html">\Exceptions\Storage</a>`,
+		`<caption>Dependencies of \Exceptions\Repository</caption>`,
+	} {
+		if !strings.Contains(class, want) {
+			t.Errorf("the page of Repository must contain %s", want)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+<?php
+
+namespace Anonymous;
+
+class Mailer {
+  public function send(string $to) {
+    echo $to;
+  }
+}
+
+interface Handler {
+  public function handle(array $items): array;
+}
+
+class Dispatcher {
+  private $mailer;
+
+  public function __construct() {
+    $this->mailer = new Mailer();
+  }
+
+  public function dispatch(array $recipients) {
+    $mailer = $this->mailer;
+
+    array_map(function ($to) use ($mailer) {
+      if ($to !== "") {
+        $mailer->send($to);
+      }
+    }, $recipients);
+
+    $lengths = array_map(fn($to) => strlen($to) > 10 ? 10 : strlen($to), $recipients);
+
+    $first = function () { return 1; }; $second = function () { return 2; };
+
+    return $lengths;
+  }
+
+  public function handler(): Handler {
+    return new class($this->mailer) implements Handler {
+      private $mailer;
+
+      public function __construct(Mailer $mailer) {
+        $this->mailer = $mailer;
+      }
+
+      public function handle(array $items): array {
+        return array_filter($items, function ($item) {
+          $this->mailer->send($item);
+          return $item !== null;
+        });
+      }
+    };
+  }
+}
+
+function runAnonymous() {
+  $dispatcher = new Dispatcher();
+  $send = function () use ($dispatcher) {
+    $dispatcher->dispatch(["a", "b"]);
+  };
+  $send();
+}
@@ -0,0 +1,32 @@
+<?php
+
+namespace Callables;
+
+class Formatter {
+  public static function upper($value) {
+    return strtoupper($value);
+  }
+
+  public function compare($a, $b) {
+    return $a <=> $b;
+  }
+
+  public function sortAll(array $items) {
+    usort($items, [$this, 'compare']);
+    return $items;
+  }
+}
+
+function trimAll(array $items) {
+  return array_map('trim', $items);
+}
+
+function formatAll(array $items) {
+  $items = array_map('Callables\Formatter::upper', $items);
+  $items = array_map([Formatter::class, 'upper'], $items);
+  return call_user_func('\Callables\trimAll', $items);
+}
+
+function unknownCallback(array $items, $callback) {
+  return call_user_func($callback, $items);
+}
@@ -0,0 +1,49 @@
+<?php
+
+namespace Dependencies;
+
+interface LoggerInterface {
+  public function log(string $message);
+}
+
+interface CacheInterface {}
+
+class Clock {}
+
+class Entity {}
+
+class Event {}
+
+class ValidationException extends \Exception {}
+
+class OrderService {
+  private LoggerInterface $logger;
+
+  /** @var CacheInterface */
+  private $cache;
+
+  public function __construct(LoggerInterface $logger, CacheInterface $cache) {
+    $this->logger = $logger;
+    $this->cache = $cache;
+  }
+
+  /**
+   * @param Clock $clock
+   * @return Entity[]
+   */
+  public function orders($clock) {
+    return [];
+  }
+
+  public function handle($event): ?Entity {
+    if ($event instanceof Event) {
+      return null;
+    }
+
+    try {
+      return null;
+    } catch (ValidationException $e) {
+      return null;
+    }
+  }
+}
@@ -0,0 +1,55 @@
+<?php
+
+namespace Deprecations;
+
+/**
+ * @deprecated use newFormat() instead
+ */
+function oldFormat(string $s): string {
+    return $s;
+}
+
+function newFormat(string $s): string {
+    return $s;
+}
+
+/**
+ * @deprecated since 2.0, use Mailer
+ */
+class LegacyMailer {
+    /**
+     * @deprecated
+     */
+    const DEFAULT_HOST = "localhost";
+
+    public function send(string $to) {
+        return oldFormat($to);
+    }
+}
+
+class Mailer {
+    /**
+     * @deprecated will be removed in 3.0
+     */
+    public function sendLater(string $to) {
+        return $this->send($to);
+    }
+
+    public function send(string $to) {
+        return newFormat($to);
+    }
+}
+
+class Notifier {
+    public function notify(Mailer $m, LegacyMailer $l) {
+        $m->sendLater("a");
+        $l->send("b");
+        echo LegacyMailer::DEFAULT_HOST;
+    }
+}
+
+function notifyAll() {
+    $n = new Notifier();
+    $n->notify(new Mailer(), new LegacyMailer());
+    return each([]);
+}
@@ -0,0 +1,41 @@
+<?php
+
+namespace Dispatch;
+
+interface Shape {
+    public function area(): float;
+}
+
+abstract class Polygon implements Shape {
+    public function describe(): string {
+        return "polygon with area " . $this->area();
+    }
+}
+
+class Square extends Polygon {
+    public function area(): float {
+        return 4.0;
+    }
+}
+
+class Triangle extends Polygon {
+    public function area(): float {
+        return 3.0;
+    }
+}
+
+class Circle implements Shape {
+    public function area(): float {
+        return 3.14;
+    }
+}
+
+function totalArea(Shape $shape): float {
+    return $shape->area();
+}
+
+function main() {
+    $square = new Square();
+    echo totalArea($square);
+    echo totalArea(new Circle());
+}
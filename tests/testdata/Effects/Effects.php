@@ -0,0 +1,28 @@
+<?php
+
+namespace Effects;
+
+class UserRepository {
+  /** @var \PDO */
+  private $pdo;
+
+  public function find(int $id) {
+    return $this->pdo->query("SELECT * FROM users WHERE id = $id");
+  }
+}
+
+class UserController {
+  public function show(UserRepository $repository, int $id) {
+    $user = $repository->find($id);
+    echo renderUser($user);
+    logAccess($id);
+  }
+}
+
+function renderUser($user) {
+  return "<b>" . htmlspecialchars($user['name']) . "</b>";
+}
+
+function logAccess(int $id) {
+  file_put_contents('/tmp/access.log', date('c') . " $id\n", FILE_APPEND);
+}
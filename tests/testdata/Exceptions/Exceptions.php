@@ -0,0 +1,50 @@
+<?php
+
+namespace Exceptions;
+
+class StorageException extends \Exception {}
+
+class NotFoundException extends StorageException {}
+
+class Storage {
+    public function find(int $id) {
+        if ($id < 0) {
+            throw new NotFoundException("not found");
+        }
+        return $id;
+    }
+
+    public function save(int $id) {
+        if ($id == 0) {
+            throw new \InvalidArgumentException("empty id");
+        }
+        return $this->find($id);
+    }
+}
+
+class Repository {
+    /** @var Storage */
+    private $storage;
+
+    public function __construct() {
+        $this->storage = new Storage();
+    }
+
+    public function get(int $id) {
+        try {
+            return $this->storage->find($id);
+        } catch (StorageException $e) {
+            return null;
+        }
+    }
+
+    public function store(int $id) {
+        return $this->storage->save($id);
+    }
+}
+
+function exceptionsMain() {
+    $repo = new Repository();
+    $repo->get(1);
+    $repo->store(1);
+}
@@ -0,0 +1,44 @@
+<?php
+
+namespace Globals;
+
+class Registry {
+  public static $items = [];
+  public static $count = 0;
+
+  public static function register($item) {
+    self::$items[] = $item;
+    self::$count++;
+  }
+
+  public static function count() {
+    return self::$count;
+  }
+}
+
+function setupConfig() {
+  global $config;
+  $config = ['debug' => true];
+  $GLOBALS['locale'] = 'en';
+}
+
+function isDebug() {
+  global $config;
+  return $config['debug'];
+}
+
+function currentLocale() {
+  return $GLOBALS['locale'];
+}
+
+function currentUser() {
+  if (isset($_SESSION['user'])) {
+    return $_SESSION['user'];
+  }
+  return $_GET['user'];
+}
+
+function login($user) {
+  $_SESSION['user'] = $user;
+  Registry::register($user);
+}
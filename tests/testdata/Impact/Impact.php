@@ -0,0 +1,40 @@
+<?php
+
+namespace Impact\Core;
+
+class Config {
+  const LIMIT = 10;
+
+  public $name = "";
+
+  public function limit() {
+    return self::LIMIT;
+  }
+}
+
+function loadConfig() {
+  $c = new Config();
+  return $c->limit();
+}
+
+namespace Impact\App;
+
+use Impact\Core\Config;
+use function Impact\Core\loadConfig;
+
+class Service {
+  public function run() {
+    return loadConfig();
+  }
+
+  public function name(Config $c) {
+    return $c->name;
+  }
+}
+
+class ServiceTest extends \PHPUnit\Framework\TestCase {
+  public function testRun() {
+    $s = new Service();
+    $s->run();
+  }
+}
@@ -0,0 +1,53 @@
+<?php
+
+namespace Overrides;
+
+interface Entity {}
+
+class User implements Entity {}
+
+interface Repository {
+    public function find(int $id): ?Entity;
+}
+
+interface UserRepository extends Repository {
+    public function findByName(string $name): ?User;
+}
+
+abstract class BaseRepository implements UserRepository {
+    abstract public function find(int $id): ?Entity;
+
+    public function save(Entity $entity) {
+        echo "save";
+    }
+
+    protected function log(string $message) {
+        echo $message;
+    }
+}
+
+class DbUserRepository extends BaseRepository {
+    public function find($id): User {
+        return new User();
+    }
+
+    public function findByName(string $name): ?User {
+        return null;
+    }
+
+    public function save(User $entity, bool $flush) {
+        parent::save($entity);
+    }
+
+    protected function log(string $message) {}
+}
+
+class CachedUserRepository extends DbUserRepository {
+    public function find($id): ?Entity {
+        return parent::find($id);
+    }
+
+    public function save(User $entity, bool $flush = false) {
+        throw new \LogicException("read only");
+    }
+}
@@ -0,0 +1,38 @@
+<?php
+
+namespace Reachability;
+
+class App {
+    public function __construct() {
+        $this->boot();
+    }
+
+    private function boot() {}
+
+    public function run(): string {
+        return "ok";
+    }
+}
+
+function render(string $s): string {
+    return "<p>" . $s . "</p>";
+}
+
+/**
+ * @entrypoint
+ */
+function cronJob() {
+    cleanup();
+}
+
+function cleanup() {}
+
+function forgotten() {
+    helperOfForgotten();
+}
+
+function helperOfForgotten() {}
+
+class Legacy {
+    public function handle() {}
+}
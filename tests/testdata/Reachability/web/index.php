@@ -0,0 +1,6 @@
+<?php
+
+require_once __DIR__ . '/../Lib.php';
+
+$app = new \Reachability\App();
+echo \Reachability\render($app->run());
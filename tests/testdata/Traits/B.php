@@ -0,0 +1,39 @@
+<?php
+
+namespace Traits;
+
+trait Counter {
+  private $count = 0;
+
+  public function increment() {
+    $this->count++;
+  }
+
+  public function log() {
+    echo $this->count;
+  }
+}
+
+trait Logger {
+  public function log() {
+    echo 'log';
+  }
+}
+
+class CountingService {
+  use Counter, Logger {
+    Counter::log insteadof Logger;
+    Logger::log as protected logMessage;
+  }
+
+  private $name = '';
+
+  public function run() {
+    $this->increment();
+    $this->log();
+  }
+
+  public function getName() {
+    return $this->name;
+  }
+}
@@ -0,0 +1,29 @@
+<?php
+
+namespace UnitTests;
+
+class Calculator {
+  private Adder $adder;
+
+  public function __construct() {
+    $this->adder = new Adder();
+  }
+
+  public function sum(int $a, int $b) {
+    return $this->adder->add($a, $b);
+  }
+
+  public function untestedMethod() {
+    return 0;
+  }
+}
+
+class Adder {
+  public function add(int $a, int $b) {
+    return $a + $b;
+  }
+}
+
+class Untested {
+  public function method() {}
+}
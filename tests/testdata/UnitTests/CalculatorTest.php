@@ -0,0 +1,21 @@
+<?php
+
+namespace UnitTests;
+
+use PHPUnit\Framework\TestCase;
+
+class CalculatorTest extends TestCase {
+  private Calculator $calculator;
+
+  public function setUp() {
+    $this->calculator = new Calculator();
+  }
+
+  public function testSum() {
+    $this->assertEquals(3, $this->calculator->sum(1, 2));
+  }
+
+  public function testSumWithZero() {
+    $this->assertEquals(1, $this->calculator->sum(1, 0));
+  }
+}
@@ -0,0 +1,7 @@
+<?php
+
+namespace PHPUnit\Framework;
+
+abstract class TestCase {
+  public function assertEquals($expected, $actual) {}
+}
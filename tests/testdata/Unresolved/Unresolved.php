@@ -0,0 +1,26 @@
+<?php
+
+namespace Unresolved;
+
+class Handler {
+  public function handle() {}
+
+  public static function create() {
+    return new static();
+  }
+}
+
+function dispatch($name, $method, $class, $payload) {
+  $handler = new Handler();
+  $handler->handle();
+  $handler->$method();
+
+  $name($payload);
+  call_user_func($name, $payload);
+
+  $class::create();
+  $object = new $class();
+  $object->handle();
+
+  undefinedFunction();
+}
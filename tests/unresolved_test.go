@@ -0,0 +1,50 @@
+package tests
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/i582/phpstats/internal/stats/symbols"
+)
+
+func TestUnresolved(t *testing.T) {
+	dispatch := getFunc(t, `\Unresolved\dispatch`)
+
+	type site struct {
+		kind symbols.UnresolvedKind
+		line int32
+		expr string
+	}
+
+	want := []site{
+		{kind: symbols.UnresolvedDynamicMethod, line: 16, expr: "$handler->$method()"},
+		{kind: symbols.UnresolvedVariableFunction, line: 18, expr: "$name"},
+		{kind: symbols.UnresolvedCallUserFunc, line: 19, expr: "$name"},
+		{kind: symbols.UnresolvedDynamicClass, line: 21, expr: "$class::create()"},
+		{kind: symbols.UnresolvedDynamicNew, line: 22, expr: "new $class"},
+		{kind: symbols.UnresolvedUnknownReceiver, line: 23, expr: "$object->handle()"},
+		{kind: symbols.UnresolvedUnknownFunction, line: 25, expr: "undefinedFunction"},
+	}
+
+	var got []site
+	for _, call := range dispatch.Unresolved.Sorted("") {
+		if call.Site.File != "Unresolved/Unresolved.php" {
+			t.Errorf("unexpected file %s", call.Site.File)
+		}
+		got = append(got, site{kind: call.Kind, line: call.Site.Line, expr: call.Expr})
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unresolved sites:\ngot  %+v\nwant %+v", got, want)
+	}
+
+	// The creation of an object is not a call.
+	if dispatch.Unresolved.CountCalls() != 6 {
+		t.Errorf("expected 6 unresolved calls, got %d", dispatch.Unresolved.CountCalls())
+	}
+
+	// The resolved call is still in the call graph.
+	if got := funcNames(dispatch.Called); !reflect.DeepEqual(got, []string{`\Unresolved\Handler::handle`, `\call_user_func`}) {
+		t.Errorf("dispatch calls %v", got)
+	}
+}